@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "fmt"
+
+// AliasPolicy selects how DecideAliases combines decisions of the paths
+// naming one logical file.
+type AliasPolicy uint8
+
+const (
+	// AliasAnyExcludedWins excludes the file when any alias is excluded.
+	AliasAnyExcludedWins AliasPolicy = iota
+	// AliasPrimaryWins takes the first alias decision when a rule matched
+	// it, otherwise the first alias matched by a rule, otherwise the first.
+	AliasPrimaryWins
+)
+
+// String returns policy name.
+func (p AliasPolicy) String() string {
+	switch p {
+	case AliasAnyExcludedWins:
+		return "any-excluded-wins"
+	case AliasPrimaryWins:
+		return "primary-wins"
+	default:
+		return fmt.Sprintf("alias-policy(%d)", uint8(p))
+	}
+}
+
+// valid reports whether policy value is supported.
+func (p AliasPolicy) valid() bool {
+	return p <= AliasPrimaryWins
+}
+
+// AliasResult is the combined decision for aliases of one file.
+type AliasResult struct {
+	// MatchResult is the combined decision, Results[Alias].
+	MatchResult
+	// Alias is the index of the alias whose decision was taken.
+	Alias int `json:"alias" yaml:"alias"`
+	// Results are the decisions of every alias, in input order.
+	Results []MatchResult `json:"results" yaml:"results"`
+}
+
+// DecideAliases decides every alias of one logical file with d and combines
+// the results with policy. The first alias is the primary one, typically
+// the path as given; others may be its resolved symlink target or a case
+// folded variant (see NormalizePath).
+//
+// The first alias error is returned with the alias name. Calls without
+// aliases fail with ErrInvalidPath.
+func DecideAliases(d Decider, policy AliasPolicy, isDir bool, aliases ...string) (AliasResult, error) {
+	if d == nil {
+		return AliasResult{}, fmt.Errorf("%w: nil decider", ErrInvalidOptions)
+	}
+
+	if !policy.valid() {
+		return AliasResult{}, fmt.Errorf("%w: unsupported alias policy %s", ErrInvalidOptions, policy)
+	}
+
+	if len(aliases) == 0 {
+		return AliasResult{}, fmt.Errorf("%w: no aliases", ErrInvalidPath)
+	}
+
+	results := make([]MatchResult, len(aliases))
+	for i, alias := range aliases {
+		res, err := d.Decide(alias, isDir)
+		if err != nil {
+			return AliasResult{}, fmt.Errorf("alias %q: %w", alias, err)
+		}
+
+		results[i] = res
+	}
+
+	winner := 0
+	switch policy {
+	case AliasAnyExcludedWins:
+		for i, res := range results {
+			if !res.Included {
+				winner = i
+				break
+			}
+		}
+	case AliasPrimaryWins:
+		if !results[0].Matched {
+			for i, res := range results {
+				if res.Matched {
+					winner = i
+					break
+				}
+			}
+		}
+	}
+
+	return AliasResult{MatchResult: results[winner], Alias: winner, Results: results}, nil
+}
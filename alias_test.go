@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecideAliases(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher([]Rule{
+		{Action: ActionExclude, Pattern: "private/"},
+		{Action: ActionInclude, Pattern: "public/keep.txt"},
+	}, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	d := m.Decider()
+
+	res, err := DecideAliases(d, AliasAnyExcludedWins, false, "link/a.txt", "private/a.txt")
+	if err != nil || res.Included || res.Alias != 1 || len(res.Results) != 2 {
+		t.Fatalf("any-excluded=%+v err=%v, want excluded by alias 1", res, err)
+	}
+
+	res, err = DecideAliases(d, AliasAnyExcludedWins, false, "link/a.txt", "docs/a.txt")
+	if err != nil || !res.Included || res.Alias != 0 {
+		t.Fatalf("any-excluded=%+v err=%v, want included primary", res, err)
+	}
+
+	res, err = DecideAliases(d, AliasPrimaryWins, false, "public/keep.txt", "private/keep.txt")
+	if err != nil || !res.Included || res.Alias != 0 {
+		t.Fatalf("primary=%+v err=%v, want matched primary included", res, err)
+	}
+
+	res, err = DecideAliases(d, AliasPrimaryWins, false, "link/a.txt", "docs/a.txt", "private/a.txt")
+	if err != nil || res.Included || res.Alias != 2 {
+		t.Fatalf("primary=%+v err=%v, want first matched alias", res, err)
+	}
+
+	res, err = DecideAliases(d, AliasPrimaryWins, false, "link/a.txt", "docs/a.txt")
+	if err != nil || !res.Included || res.Alias != 0 || res.Matched {
+		t.Fatalf("primary=%+v err=%v, want unmatched primary", res, err)
+	}
+}
+
+func TestDecideAliasesErrors(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewProvider(t.TempDir(), ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if _, err := DecideAliases(p, AliasAnyExcludedWins, false, "a.txt", "../a.txt"); !errors.Is(err, ErrPathOutsideRoot) {
+		t.Fatalf("err=%v, want ErrPathOutsideRoot", err)
+	}
+
+	if _, err := DecideAliases(p, AliasAnyExcludedWins, false); !errors.Is(err, ErrInvalidPath) {
+		t.Fatalf("err=%v, want ErrInvalidPath", err)
+	}
+
+	if _, err := DecideAliases(p, AliasPrimaryWins+1, false, "a.txt"); !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("err=%v, want ErrInvalidOptions", err)
+	}
+
+	if _, err := DecideAliases(nil, AliasAnyExcludedWins, false, "a.txt"); !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("err=%v, want ErrInvalidOptions", err)
+	}
+
+	if got := AliasPrimaryWins.String(); got != "primary-wins" {
+		t.Fatalf("String()=%q, want primary-wins", got)
+	}
+}
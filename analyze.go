@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// Finding is one AnalyzeRules result about a rule and the rule causing it.
+type Finding struct {
+	// Code is a stable finding identifier.
+	Code DiagnosticCode `json:"code" yaml:"code"`
+	// Message is a human-readable description.
+	Message string `json:"message" yaml:"message"`
+	// Pattern is the pattern of the rule at RuleIndex.
+	Pattern string `json:"pattern" yaml:"pattern"`
+	// RuleIndex is the index of the rule the finding is about.
+	RuleIndex int `json:"rule_index" yaml:"rule_index"`
+	// RelatedIndex is the index of the rule causing the finding.
+	RelatedIndex int `json:"related_index" yaml:"related_index"`
+	// Severity is finding severity.
+	Severity Severity `json:"severity" yaml:"severity"`
+}
+
+// Report is a structured rule set analysis.
+type Report struct {
+	// Findings are ordered by RuleIndex, then RelatedIndex.
+	Findings []Finding `json:"findings,omitempty" yaml:"findings,omitempty"`
+}
+
+// AnalyzeRules reports conflicting, redundant and dead rules.
+//
+// Analysis uses DialectDefault semantics and reports only provable cases:
+//   - DiagConflictingRule (error): a later rule has the same pattern and the
+//     opposite action, so this rule never applies
+//   - DiagDeadNegation (error): a later exclude rule matches every path this
+//     include rule re-includes ("!keep.tmp" before "*.tmp")
+//   - DiagShadowedRule (warning): any other rule fully covered by a later rule
+//   - DiagDuplicateRule (warning): rule repeats an earlier rule
+//   - DiagRedundantRule (warning): an earlier rule with the same action
+//     ("build/**") already matches every path of this rule and no rule in
+//     between has the opposite action
+func AnalyzeRules(rules []Rule) Report {
+	var report Report
+	for _, c := range findShadowedRules(rules) {
+		rule, later := rules[c.rule], rules[c.later]
+		switch {
+		case c.same && rule.Action == later.Action:
+			report.add(DiagDuplicateRule, SeverityWarning, rules, c.later, c.rule,
+				fmt.Sprintf("duplicates rule %d", c.rule))
+		case c.same:
+			report.add(DiagConflictingRule, SeverityError, rules, c.rule, c.later,
+				fmt.Sprintf("rule %d has the same pattern with the opposite action", c.later))
+		case rule.Action == ActionInclude && later.Action == ActionExclude:
+			report.add(DiagDeadNegation, SeverityError, rules, c.rule, c.later,
+				fmt.Sprintf("negation never applies: later rule %d (%q) excludes every path it re-includes", c.later, later.Pattern))
+		default:
+			report.add(DiagShadowedRule, SeverityWarning, rules, c.rule, c.later,
+				fmt.Sprintf("never wins: rule %d (%q) matches every path it matches", c.later, later.Pattern))
+		}
+	}
+
+	for _, r := range findRedundantRules(rules) {
+		report.add(DiagRedundantRule, SeverityWarning, rules, r.rule, r.later,
+			fmt.Sprintf("redundant: earlier rule %d (%q) already decides its paths the same way", r.later, rules[r.later].Pattern))
+	}
+
+	sortFindings(report.Findings)
+	return report
+}
+
+// HasSeverity reports whether any finding is at least min severe.
+func (r Report) HasSeverity(minimum Severity) bool {
+	for i := range r.Findings {
+		if r.Findings[i].Severity >= minimum {
+			return true
+		}
+	}
+
+	return false
+}
+
+// add appends one finding about rule index with related rule.
+func (r *Report) add(code DiagnosticCode, severity Severity, rules []Rule, index int, related int, msg string) {
+	r.Findings = append(r.Findings, Finding{
+		Code:         code,
+		Message:      msg,
+		Pattern:      rules[index].Pattern,
+		RuleIndex:    index,
+		RelatedIndex: related,
+		Severity:     severity,
+	})
+}
+
+// findRedundantRules returns rules covered by an earlier rule with the same
+// action and no opposite-action rule in between. The later field holds the
+// earlier covering rule index.
+//
+// Rules with different priorities are not analyzed.
+func findRedundantRules(rules []Rule) []shadowedRule {
+	if slices.ContainsFunc(rules, func(r Rule) bool { return r.Priority != rules[0].Priority }) {
+		return nil
+	}
+
+	shapes := make([]ruleShape, len(rules))
+	valid := make([]bool, len(rules))
+	for i := range rules {
+		shapes[i], valid[i] = newRuleShape(rules[i])
+	}
+
+	var out []shadowedRule
+	for j := range rules {
+		if !valid[j] {
+			continue
+		}
+
+		for i := j - 1; i >= 0; i-- {
+			if rules[i].Action != rules[j].Action {
+				break
+			}
+
+			// Identical rules are reported as duplicates.
+			if valid[i] && !shapes[i].same(&shapes[j]) && shapes[i].covers(&shapes[j]) {
+				out = append(out, shadowedRule{rule: j, later: i})
+				break
+			}
+		}
+	}
+
+	return out
+}
+
+// sortFindings orders findings by rule index, then related index.
+func sortFindings(findings []Finding) {
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		if c := cmp.Compare(a.RuleIndex, b.RuleIndex); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.RelatedIndex, b.RelatedIndex)
+	})
+}
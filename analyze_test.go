@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeRules(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionExclude, Pattern: "/build/**"},      // 0
+		{Action: ActionExclude, Pattern: "/build/cache/x"}, // 1 redundant after 0
+		{Action: ActionInclude, Pattern: "keep.tmp"},       // 2 dead negation, 4 excludes it
+		{Action: ActionInclude, Pattern: "/dist/"},         // 3 conflicts with 5
+		{Action: ActionExclude, Pattern: "*.tmp"},          // 4
+		{Action: ActionExclude, Pattern: "/dist/"},         // 5
+		{Action: ActionExclude, Pattern: "/dist/a.txt"},    // 6 redundant after 5
+		{Action: ActionExclude, Pattern: "*.tmp"},          // 7 duplicates 4
+	}
+
+	report := AnalyzeRules(rules)
+	want := []struct {
+		code    DiagnosticCode
+		rule    int
+		related int
+	}{
+		{DiagRedundantRule, 1, 0},
+		{DiagDeadNegation, 2, 4},
+		{DiagConflictingRule, 3, 5},
+		{DiagRedundantRule, 6, 5},
+		{DiagDuplicateRule, 7, 4},
+	}
+
+	if len(report.Findings) != len(want) {
+		t.Fatalf("Findings=%+v, want %d", report.Findings, len(want))
+	}
+
+	for i, w := range want {
+		f := report.Findings[i]
+		if f.Code != w.code || f.RuleIndex != w.rule || f.RelatedIndex != w.related {
+			t.Fatalf("Findings[%d]=%+v, want %s rule %d related %d", i, f, w.code, w.rule, w.related)
+		}
+	}
+
+	if !report.HasSeverity(SeverityError) {
+		t.Fatalf("HasSeverity(error)=false, want true")
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	if !strings.Contains(string(data), `"severity":"error"`) {
+		t.Fatalf("json=%s, want named severity", data)
+	}
+
+	if clean := AnalyzeRules([]Rule{{Action: ActionExclude, Pattern: "*.log"}}); clean.HasSeverity(SeverityWarning) {
+		t.Fatalf("clean report has findings: %+v", clean)
+	}
+}
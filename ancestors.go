@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// loadAncestors loads rules files of directories above provider root,
+// outermost first, up to the directory holding boundary or the filesystem
+// root when boundary is empty.
+//
+// Nothing is loaded when root itself holds boundary or the root marker, or
+// when boundary is set but no ancestor holds it.
+func (p *Provider) loadAncestors(boundary string) ([]providerDirMatcher, error) {
+	for _, name := range []string{boundary, p.rootMarker} {
+		if found, err := ancestorHas(p.root, name); err != nil || found {
+			return nil, err
+		}
+	}
+
+	var dirs []string
+	for dir := p.root; ; {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			if boundary != "" {
+				return nil, nil
+			}
+
+			break
+		}
+
+		dir = parent
+		dirs = append(dirs, dir)
+
+		atBoundary, err := ancestorHas(dir, boundary)
+		if err != nil {
+			return nil, err
+		}
+
+		atMarker, err := ancestorHas(dir, p.rootMarker)
+		if err != nil {
+			return nil, err
+		}
+
+		if atBoundary || atMarker {
+			break
+		}
+	}
+
+	out := make([]providerDirMatcher, 0, len(dirs))
+	for i := len(dirs) - 1; i >= 0; i-- {
+		up, err := filepath.Rel(p.root, dirs[i])
+		if err != nil {
+			return nil, fmt.Errorf("ancestor %s: %w", dirs[i], err)
+		}
+
+		outer, err := filepath.Rel(dirs[i], p.root)
+		if err != nil {
+			return nil, fmt.Errorf("ancestor %s: %w", dirs[i], err)
+		}
+
+		entry := providerDirMatcher{prefix: filepath.ToSlash(up), outer: filepath.ToSlash(outer)}
+		rulesPath := filepath.Join(dirs[i], p.rulesFileName)
+		f, err := os.Open(rulesPath)
+		file, err := readRulesFile(f, err, rulesPath, p.maxRulesFileSize)
+		if err == nil && file.found {
+			entry.matcher, err = p.compileDirRules(file.content, file.path)
+		}
+
+		if err != nil {
+			err = &LoadError{Err: err, Dir: entry.prefix, Path: rulesPath}
+			p.reportRuleFileError(entry.prefix, err)
+			switch p.onRuleFileError {
+			case RuleFileErrorSkipFile:
+				continue
+			case RuleFileErrorExcludeSubtree:
+				// The whole tree is below the broken file, deeper ancestors do not matter.
+				return append(out, providerDirMatcher{prefix: entry.prefix, excluded: true}), nil
+			default:
+				return nil, err
+			}
+		}
+
+		if entry.matcher != nil {
+			p.reportRulesLoaded(entry.prefix, entry.matcher)
+			out = append(out, entry)
+		}
+	}
+
+	return out, nil
+}
+
+// ancestorHas reports whether dir holds an entry called name, false for empty name.
+func ancestorHas(dir, name string) (bool, error) {
+	if name == "" {
+		return false, nil
+	}
+
+	_, err := os.Lstat(filepath.Join(dir, name))
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("stat %s: %w", filepath.Join(dir, name), err)
+}
+
+// candidate returns normalized relative to the matcher directory and
+// reports whether its rules apply.
+func (dm *providerDirMatcher) candidate(normalized string) (string, bool) {
+	if dm.outer != "" {
+		return dm.outer + "/" + normalized, true
+	}
+
+	return dirCandidate(dm.prefix, normalized)
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProviderScanAncestors(t *testing.T) {
+	t.Parallel()
+
+	project := t.TempDir()
+	root := filepath.Join(project, "tool")
+	if err := os.MkdirAll(filepath.Join(project, ".git"), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	writeRulesFile(t, filepath.Join(project, ".pathrules"), "*.log\n/tool/gen/\n")
+	writeRulesFile(t, filepath.Join(root, ".pathrules"), "!keep.log\n")
+
+	cases := []struct {
+		opts ProviderOptions
+		want map[string]bool
+	}{
+		{
+			opts: ProviderOptions{ScanAncestors: true, AncestorBoundary: ".git"},
+			want: map[string]bool{"a.log": false, "keep.log": true, "gen": false, "src": true},
+		},
+		{
+			opts: ProviderOptions{},
+			want: map[string]bool{"a.log": true, "gen": true},
+		},
+		{
+			opts: ProviderOptions{ScanAncestors: true, AncestorBoundary: ".missing"},
+			want: map[string]bool{"a.log": true, "gen": true},
+		},
+		{
+			opts: ProviderOptions{ScanAncestors: true, AncestorBoundary: ".git", DirPrecedence: DirPrecedenceNearest},
+			want: map[string]bool{"a.log": false, "keep.log": true, "gen": false},
+		},
+	}
+
+	for _, tc := range cases {
+		p, err := NewProvider(root, tc.opts)
+		if err != nil {
+			t.Fatalf("NewProvider(%+v): %v", tc.opts, err)
+		}
+
+		for path, want := range tc.want {
+			isDir := filepath.Ext(path) == ""
+			if got, err := p.Included(path, isDir); err != nil || got != want {
+				t.Fatalf("%+v: Included(%s)=%v err=%v, want %v", tc.opts, path, got, err, want)
+			}
+
+			got, err := p.IncludedInDir("", []DirEntry{{Name: path, IsDir: isDir}})
+			if err != nil || got[0] != want {
+				t.Fatalf("%+v: IncludedInDir(%s)=%v err=%v, want %v", tc.opts, path, got, err, want)
+			}
+		}
+	}
+
+	writeRulesFile(t, filepath.Join(project, ".pathrules"), "#pragma default=bogus\n")
+	if _, err := NewProvider(root, ProviderOptions{ScanAncestors: true, AncestorBoundary: ".git"}); err == nil {
+		t.Fatalf("NewProvider with broken ancestor rules must fail")
+	}
+
+	p, err := NewProvider(root, ProviderOptions{
+		ScanAncestors:    true,
+		AncestorBoundary: ".git",
+		OnRuleFileError:  RuleFileErrorExcludeSubtree,
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if got, err := p.Included("keep.log", false); err != nil || got {
+		t.Fatalf("Included(keep.log)=%v err=%v, want excluded by broken ancestor", got, err)
+	}
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"path"
+	"path/filepath"
+)
+
+// RulesLoadedHook receives the rules of one rules file after it was loaded
+// and compiled. relDir is the file directory relative to root, "" for root.
+type RulesLoadedHook func(relDir string, rules []Rule)
+
+// DecisionOverriddenHook receives a decision change made by a rules file:
+// path is relative to root, prev and next are the decisions before and
+// after the file applied, source is the rules file path.
+type DecisionOverriddenHook func(path string, prev, next MatchResult, source string)
+
+// reportRulesLoaded passes freshly loaded rules of relDir to OnRulesLoaded.
+func (p *Provider) reportRulesLoaded(relDir string, matcher *Matcher) {
+	if p.onRulesLoaded == nil || matcher == nil {
+		return
+	}
+
+	// Shared matchers may come from another directory with identical content.
+	source := p.rulesFilePath(relDir)
+	rules := make([]Rule, matcher.ruleCount)
+	for i := range rules {
+		rules[i] = matcher.compiled[matcher.compiledIndex(i)].source
+		rules[i].Source = source
+	}
+
+	p.onRulesLoaded(relDir, rules)
+}
+
+// applyDirDecision merges a matched decision of the relDir rules file into
+// res, reporting a changed inclusion to OnDecisionOverridden.
+func (p *Provider) applyDirDecision(relDir, normalized string, matcher *Matcher, decision MatchResult, res *MatchResult) {
+	p.origin.set(DecisionSourceRulesFile, relDir, matcher, decision.RuleIndex)
+	prev := *res
+	res.Included = decision.Included
+	res.Matched = true
+	res.RuleIndex = decision.RuleIndex
+	res.Action = decision.Action
+	res.Rule = decision.Rule
+	// Shared matchers may come from another directory with identical content.
+	if res.Rule != nil && p.source == nil {
+		if source := p.rulesFilePath(relDir); res.Rule.Source != source {
+			rule := *res.Rule
+			rule.Source = source
+			res.Rule = &rule
+		}
+	}
+
+	if p.onDecisionOverridden != nil && prev.Included != res.Included {
+		p.onDecisionOverridden(normalized, prev, *res, p.rulesFilePath(relDir))
+	}
+}
+
+// rulesFilePath returns the rules file path of relDir as used in errors and Rule.Source.
+func (p *Provider) rulesFilePath(relDir string) string {
+	if p.fsys != nil {
+		return path.Join(relDir, p.rulesFileName)
+	}
+
+	return filepath.Join(p.root, filepath.FromSlash(relDir), p.rulesFileName)
+}
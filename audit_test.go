@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+func TestProviderAuditHooks(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":     {Data: []byte("*.tmp\n")},
+		"a/.pathrules":   {Data: []byte("!*.tmp\n*.log\n")},
+		"a/b/.pathrules": {Data: []byte("!*.tmp\n")},
+	}
+
+	type override struct {
+		path, source string
+		prev, next   bool
+	}
+
+	var (
+		mu        sync.Mutex
+		loaded    = map[string][]Rule{}
+		overrides []override
+	)
+
+	p, err := NewProviderFS(fsys, ProviderOptions{
+		OnRulesLoaded: func(relDir string, rules []Rule) {
+			mu.Lock()
+			loaded[relDir] = rules
+			mu.Unlock()
+		},
+		OnDecisionOverridden: func(path string, prev, next MatchResult, source string) {
+			mu.Lock()
+			overrides = append(overrides, override{path: path, source: source, prev: prev.Included, next: next.Included})
+			mu.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	if included, err := p.Included("a/b/x.tmp", false); err != nil || !included {
+		t.Fatalf("Included(a/b/x.tmp)=%v err=%v, want included", included, err)
+	}
+
+	want := []override{
+		{path: "a/b/x.tmp", source: ".pathrules", prev: true, next: false},
+		{path: "a/b/x.tmp", source: "a/.pathrules", prev: false, next: true},
+	}
+
+	if len(overrides) != len(want) {
+		t.Fatalf("overrides=%+v, want %+v", overrides, want)
+	}
+
+	for i := range want {
+		if overrides[i] != want[i] {
+			t.Fatalf("overrides[%d]=%+v, want %+v", i, overrides[i], want[i])
+		}
+	}
+
+	rules := loaded["a"]
+	if len(loaded) != 3 || len(rules) != 2 || rules[1].Pattern != "*.log" || rules[1].Source != "a/.pathrules" {
+		t.Fatalf("loaded=%+v, want rules of 3 files with a/.pathrules source", loaded)
+	}
+
+	if loaded["a/b"][0].Source != "a/b/.pathrules" {
+		t.Fatalf("loaded[a/b]=%+v, want own source for shared matcher", loaded["a/b"])
+	}
+}
@@ -86,6 +86,28 @@ func BenchmarkMatcherDecide(b *testing.B) {
 	}
 }
 
+func BenchmarkMatcherDecideLiteralRules(b *testing.B) {
+	rules := make([]Rule, 0, 5000)
+	for i := range 5000 {
+		rules = append(rules, Rule{Action: ActionExclude, Pattern: fmt.Sprintf("/generated/group_%03d/file_%04d.bin", i%97, i)})
+	}
+
+	m, err := NewMatcher(rules, MatcherOptions{
+		DefaultAction: ActionInclude,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	paths := benchmarkPaths(benchPathCount)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchDecisionSink = m.Decide(paths[i%len(paths)], false)
+	}
+}
+
 func BenchmarkProviderDecideCached(b *testing.B) {
 	root := b.TempDir()
 	prepareProviderBenchTree(b, root)
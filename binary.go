@@ -0,0 +1,507 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/fs"
+	"math"
+	"regexp"
+	"time"
+)
+
+// matcherBinaryMagic prefixes serialized matchers; the last byte is the format version.
+const matcherBinaryMagic = "PRM\x01"
+
+// Compiled rule flags in serialized matchers.
+const (
+	binaryRuleAnchored byte = 1 << iota
+	binaryRuleDirOnly
+	binaryRuleRequireDir
+	binaryRuleHasSlash
+	binaryRuleGlobWildcard
+	binaryRuleFoldCase
+)
+
+// Metadata predicate flags in serialized matchers.
+const (
+	binaryMetaPresent byte = 1 << iota
+	binaryMetaBefore
+	binaryMetaAfter
+	binaryMetaSizeAbove
+	binaryMetaSizeBelow
+	binaryMetaSymlinkSet
+	binaryMetaSymlink
+)
+
+// Matcher flags in serialized matchers.
+const (
+	binaryMatcherCaseInsensitive byte = 1 << iota
+	binaryMatcherExplicitDefault
+	binaryMatcherFirstMatch
+	binaryMatcherPOSIXSeparators
+	binaryMatcherMaxPathLength
+	binaryMatcherResultRule
+	binaryMatcherStrictPaths
+)
+
+// MarshalBinary encodes compiled matcher state.
+//
+// The encoding stores the matching strategy chosen for every rule, so
+// UnmarshalBinary skips pattern analysis and glob translation; regular
+// expressions are recompiled from their stored source. The decision memo
+// (MatcherOptions.MemoSize), coverage counters and action handlers are not
+// stored; custom action matches of a decoded matcher get the default action.
+// The format is versioned and tied to this package, not meant for other tools.
+func (m *Matcher) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, 64+len(m.compiled)*48)
+	buf = append(buf, matcherBinaryMagic...)
+
+	var flags byte
+	if m.caseInsensitive {
+		flags |= binaryMatcherCaseInsensitive
+	}
+
+	if m.explicitDefault {
+		flags |= binaryMatcherExplicitDefault
+	}
+
+	if m.firstMatch {
+		flags |= binaryMatcherFirstMatch
+	}
+
+	if m.posixSeparators {
+		flags |= binaryMatcherPOSIXSeparators
+	}
+
+	if m.maxPathLength > 0 {
+		flags |= binaryMatcherMaxPathLength
+	}
+
+	if m.resultRule {
+		flags |= binaryMatcherResultRule
+	}
+
+	if m.strictPaths {
+		flags |= binaryMatcherStrictPaths
+	}
+
+	buf = append(buf, byte(m.defaultAction), byte(m.dialect), flags)
+	if m.maxPathLength > 0 {
+		buf = binary.AppendUvarint(buf, uint64(m.maxPathLength))
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(m.implicitBefore))
+	buf = binary.AppendUvarint(buf, uint64(m.ruleCount))
+	buf = binary.AppendUvarint(buf, uint64(len(m.compiled)))
+	for i := range m.compiled {
+		buf = appendCompiledRule(buf, &m.compiled[i])
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary replaces m with a matcher decoded from MarshalBinary output.
+//
+// Malformed or foreign data fails with ErrInvalidMatcherData.
+func (m *Matcher) UnmarshalBinary(data []byte) error {
+	if len(data) < len(matcherBinaryMagic) || string(data[:len(matcherBinaryMagic)]) != matcherBinaryMagic {
+		return fmt.Errorf("%w: unknown header", ErrInvalidMatcherData)
+	}
+
+	d := &binaryDecoder{data: data[len(matcherBinaryMagic):]}
+	decoded := Matcher{
+		defaultAction: Action(d.byte()),
+		dialect:       Dialect(d.byte()),
+	}
+
+	flags := d.byte()
+	decoded.caseInsensitive = flags&binaryMatcherCaseInsensitive != 0
+	decoded.explicitDefault = flags&binaryMatcherExplicitDefault != 0
+	decoded.firstMatch = flags&binaryMatcherFirstMatch != 0
+	decoded.posixSeparators = flags&binaryMatcherPOSIXSeparators != 0
+	decoded.resultRule = flags&binaryMatcherResultRule != 0
+	decoded.strictPaths = flags&binaryMatcherStrictPaths != 0
+	if flags&binaryMatcherMaxPathLength != 0 {
+		if limit := d.uvarint(); limit <= math.MaxInt32 {
+			decoded.maxPathLength = int(limit)
+		} else {
+			d.fail("path length limit out of range")
+		}
+	}
+
+	decoded.implicitBefore = d.count()
+	decoded.ruleCount = d.count()
+
+	count := d.count()
+	if d.err == nil {
+		decoded.compiled = make([]compiledRule, 0, count)
+	}
+
+	for i := 0; i < count && d.err == nil; i++ {
+		decoded.compiled = append(decoded.compiled, d.compiledRule())
+	}
+
+	if d.err == nil && len(d.data) != 0 {
+		d.fail("trailing data")
+	}
+
+	if d.err == nil && (!decoded.defaultAction.valid() || !decoded.dialect.valid() ||
+		decoded.implicitBefore+decoded.ruleCount > len(decoded.compiled)) {
+		d.fail("inconsistent matcher header")
+	}
+
+	if d.err != nil {
+		return d.err
+	}
+
+	decoded.initRuleOrder()
+	*m = decoded
+	return nil
+}
+
+// appendCompiledRule appends one serialized compiled rule to buf.
+func appendCompiledRule(buf []byte, r *compiledRule) []byte {
+	var flags byte
+	for _, f := range []struct {
+		set  bool
+		flag byte
+	}{
+		{r.anchored, binaryRuleAnchored},
+		{r.dirOnly, binaryRuleDirOnly},
+		{r.requireDir, binaryRuleRequireDir},
+		{r.hasSlash, binaryRuleHasSlash},
+		{r.componentGlob.wildcard, binaryRuleGlobWildcard},
+		{r.foldCase, binaryRuleFoldCase},
+	} {
+		if f.set {
+			flags |= f.flag
+		}
+	}
+
+	buf = append(buf, flags, byte(r.source.Action), byte(r.source.Syntax))
+	buf = appendBinaryString(buf, r.source.Pattern)
+	buf = appendBinaryString(buf, r.source.Source)
+	buf = appendBinaryString(buf, r.source.Section)
+	buf = appendBinaryStrings(buf, r.source.Tags)
+	buf = binary.AppendUvarint(buf, uint64(max(r.source.Line, 0)))
+	buf = binary.AppendVarint(buf, int64(r.source.Priority))
+	buf = appendBinaryMeta(buf, r.source.Meta)
+
+	buf = appendBinaryString(buf, r.componentExact)
+	buf = appendBinaryString(buf, r.componentGlob.text)
+	buf = appendBinaryString(buf, r.pathExact)
+	buf = appendBinarySegments(buf, r.pathSegments)
+	buf = appendBinarySegments(buf, r.pathPrefixSegments)
+	for _, re := range []*regexp.Regexp{r.componentRE, r.pathRE, r.pathDirRE, r.searchRE} {
+		expr := ""
+		if re != nil {
+			expr = re.String()
+		}
+
+		buf = appendBinaryString(buf, expr)
+	}
+
+	return buf
+}
+
+// appendBinaryMeta appends an optional metadata predicate.
+func appendBinaryMeta(buf []byte, p *MetaPredicate) []byte {
+	if p == nil {
+		return append(buf, 0)
+	}
+
+	flags := binaryMetaPresent
+	for _, f := range []struct {
+		set  bool
+		flag byte
+	}{
+		{!p.ModifiedBefore.IsZero(), binaryMetaBefore},
+		{!p.ModifiedAfter.IsZero(), binaryMetaAfter},
+		{p.SizeAbove != nil, binaryMetaSizeAbove},
+		{p.SizeBelow != nil, binaryMetaSizeBelow},
+		{p.Symlink != nil, binaryMetaSymlinkSet},
+		{p.Symlink != nil && *p.Symlink, binaryMetaSymlink},
+	} {
+		if f.set {
+			flags |= f.flag
+		}
+	}
+
+	buf = append(buf, flags)
+	for _, t := range []time.Time{p.ModifiedBefore, p.ModifiedAfter} {
+		if !t.IsZero() {
+			buf = binary.AppendVarint(buf, t.Unix())
+			buf = binary.AppendUvarint(buf, uint64(t.Nanosecond()))
+		}
+	}
+
+	for _, size := range []*int64{p.SizeAbove, p.SizeBelow} {
+		if size != nil {
+			buf = binary.AppendVarint(buf, *size)
+		}
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(p.ModeMask))
+	return binary.AppendUvarint(buf, uint64(p.Mode))
+}
+
+// appendBinaryString appends a length-prefixed string.
+func appendBinaryString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+// appendBinarySegments appends length-prefixed segment patterns.
+func appendBinarySegments(buf []byte, segments []segmentPattern) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(segments)))
+	for _, segment := range segments {
+		buf = appendBinaryString(buf, segment.text)
+	}
+
+	return buf
+}
+
+// appendBinaryStrings appends length-prefixed strings.
+func appendBinaryStrings(buf []byte, values []string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(values)))
+	for _, v := range values {
+		buf = appendBinaryString(buf, v)
+	}
+
+	return buf
+}
+
+// binaryDecoder reads serialized matcher fields and keeps the first error.
+type binaryDecoder struct {
+	// err is the first decoding error.
+	err error
+	// data is the unread input.
+	data []byte
+}
+
+// fail records a decoding error unless one is already recorded.
+func (d *binaryDecoder) fail(msg string) {
+	if d.err == nil {
+		d.err = fmt.Errorf("%w: %s", ErrInvalidMatcherData, msg)
+	}
+}
+
+// byte reads one byte.
+func (d *binaryDecoder) byte() byte {
+	if d.err != nil || len(d.data) == 0 {
+		d.fail("unexpected end of data")
+		return 0
+	}
+
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b
+}
+
+// uvarint reads one unsigned varint.
+func (d *binaryDecoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+
+	v, n := binary.Uvarint(d.data)
+	if n <= 0 {
+		d.fail("invalid varint")
+		return 0
+	}
+
+	d.data = d.data[n:]
+	return v
+}
+
+// varint reads one signed varint.
+func (d *binaryDecoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+
+	v, n := binary.Varint(d.data)
+	if n <= 0 {
+		d.fail("invalid varint")
+		return 0
+	}
+
+	d.data = d.data[n:]
+	return v
+}
+
+// count reads a length bounded by the remaining input size.
+func (d *binaryDecoder) count() int {
+	v := d.uvarint()
+	if v > uint64(len(d.data)) {
+		d.fail("length out of range")
+		return 0
+	}
+
+	return int(v)
+}
+
+// string reads a length-prefixed string.
+func (d *binaryDecoder) string() string {
+	n := d.count()
+	if d.err != nil || n > len(d.data) {
+		d.fail("string out of range")
+		return ""
+	}
+
+	s := string(d.data[:n])
+	d.data = d.data[n:]
+	return s
+}
+
+// strings reads length-prefixed strings, nil when empty.
+func (d *binaryDecoder) strings() []string {
+	n := d.count()
+	if d.err != nil || n == 0 {
+		return nil
+	}
+
+	values := make([]string, n)
+	for i := range values {
+		values[i] = d.string()
+	}
+
+	return values
+}
+
+// segments reads length-prefixed segment patterns.
+func (d *binaryDecoder) segments() []segmentPattern {
+	n := d.count()
+	if d.err != nil || n == 0 {
+		return nil
+	}
+
+	segments := make([]segmentPattern, n)
+	for i := range segments {
+		segments[i] = newSegmentPattern(d.string())
+	}
+
+	return segments
+}
+
+// regexp reads and compiles an optional regular expression.
+func (d *binaryDecoder) regexp() *regexp.Regexp {
+	expr := d.string()
+	if d.err != nil || expr == "" {
+		return nil
+	}
+
+	re, err := compileRegexp(expr)
+	if err != nil {
+		d.fail(fmt.Sprintf("compile %q: %v", expr, err))
+		return nil
+	}
+
+	return re
+}
+
+// meta reads an optional metadata predicate.
+func (d *binaryDecoder) meta() *MetaPredicate {
+	flags := d.byte()
+	if flags&binaryMetaPresent == 0 {
+		return nil
+	}
+
+	p := &MetaPredicate{}
+	for _, f := range []struct {
+		t    *time.Time
+		flag byte
+	}{
+		{&p.ModifiedBefore, binaryMetaBefore},
+		{&p.ModifiedAfter, binaryMetaAfter},
+	} {
+		if flags&f.flag == 0 {
+			continue
+		}
+
+		sec := d.varint()
+		nsec := d.uvarint()
+		if nsec >= uint64(time.Second) {
+			d.fail("nanoseconds out of range")
+		}
+
+		*f.t = time.Unix(sec, int64(nsec))
+	}
+
+	for _, f := range []struct {
+		size **int64
+		flag byte
+	}{
+		{&p.SizeAbove, binaryMetaSizeAbove},
+		{&p.SizeBelow, binaryMetaSizeBelow},
+	} {
+		if flags&f.flag != 0 {
+			size := d.varint()
+			*f.size = &size
+		}
+	}
+
+	if flags&binaryMetaSymlinkSet != 0 {
+		symlink := flags&binaryMetaSymlink != 0
+		p.Symlink = &symlink
+	}
+
+	for _, mode := range []*fs.FileMode{&p.ModeMask, &p.Mode} {
+		if v := d.uvarint(); v <= math.MaxUint32 {
+			*mode = fs.FileMode(v)
+		} else {
+			d.fail("mode out of range")
+		}
+	}
+
+	return p
+}
+
+// compiledRule reads one serialized compiled rule.
+func (d *binaryDecoder) compiledRule() compiledRule {
+	flags := d.byte()
+	r := compiledRule{
+		anchored:   flags&binaryRuleAnchored != 0,
+		dirOnly:    flags&binaryRuleDirOnly != 0,
+		requireDir: flags&binaryRuleRequireDir != 0,
+		hasSlash:   flags&binaryRuleHasSlash != 0,
+		foldCase:   flags&binaryRuleFoldCase != 0,
+	}
+
+	r.source.Action = Action(d.byte())
+	r.source.Syntax = PatternSyntax(d.byte())
+	r.source.Pattern = d.string()
+	r.source.Source = d.string()
+	r.source.Section = d.string()
+	r.source.Tags = d.strings()
+	if line := d.uvarint(); line <= math.MaxInt32 {
+		r.source.Line = int(line)
+	} else {
+		d.fail("line out of range")
+	}
+
+	if priority := d.varint(); priority >= math.MinInt32 && priority <= math.MaxInt32 {
+		r.source.Priority = int(priority)
+	} else {
+		d.fail("priority out of range")
+	}
+
+	r.source.Meta = d.meta()
+	r.componentExact = d.string()
+	r.componentGlob = segmentPattern{
+		text:     d.string(),
+		wildcard: flags&binaryRuleGlobWildcard != 0,
+	}
+	r.pathExact = d.string()
+	r.pathSegments = d.segments()
+	r.pathPrefixSegments = d.segments()
+	r.componentRE = d.regexp()
+	r.pathRE = d.regexp()
+	r.pathDirRE = d.regexp()
+	r.searchRE = d.regexp()
+	return r
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMatcherBinaryRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	rules, err := ParseRulesString(buildBenchmarkRulesSource(96))
+	if err != nil {
+		t.Fatalf("ParseRulesString: %v", err)
+	}
+
+	rules = append(rules,
+		Rule{Action: ActionInclude, Pattern: `^logs/keep\.log$`, Syntax: PatternRegexp, Source: "x", Line: 1 << 20},
+		Rule{Action: ActionExclude, Pattern: "build?/"},
+	)
+	for i := range 20 {
+		rules = append(rules, Rule{Action: ActionExclude, Pattern: fmt.Sprintf("/gen/f%02d.bin", i)})
+	}
+
+	paths := append(benchmarkPaths(128), "logs/keep.log", "build1", "gen/f03.bin", "gen/f99.bin")
+	for _, opts := range []MatcherOptions{
+		{DefaultAction: ActionInclude},
+		{DefaultAction: ActionExclude, CaseInsensitive: true},
+		{Dialect: DialectGit},
+		{Dialect: DialectPrettier},
+	} {
+		m, err := NewMatcher(rules, opts)
+		if err != nil {
+			t.Fatalf("NewMatcher: %v", err)
+		}
+
+		data, err := m.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary: %v", err)
+		}
+
+		var decoded Matcher
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary: %v", err)
+		}
+
+		if (decoded.index == nil) != (m.index == nil) {
+			t.Fatalf("literal index not restored")
+		}
+
+		for _, path := range paths {
+			for _, isDir := range []bool{false, true} {
+				if got, want := decoded.Decide(path, isDir), m.Decide(path, isDir); got != want {
+					t.Fatalf("opts %+v: Decide(%q, %v)=%+v, want %+v", opts, path, isDir, got, want)
+				}
+			}
+		}
+
+		for cut := len(data) - 1; cut >= 0; cut -= 1 + len(data)/64 {
+			if err := new(Matcher).UnmarshalBinary(data[:cut]); !errors.Is(err, ErrInvalidMatcherData) {
+				t.Fatalf("UnmarshalBinary(data[:%d]) err=%v, want ErrInvalidMatcherData", cut, err)
+			}
+		}
+	}
+}
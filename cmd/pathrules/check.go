@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/woozymasta/pathrules"
+)
+
+// checkFlags are check command flags.
+type checkFlags struct {
+	// provider are tree evaluation flags.
+	provider providerFlags
+	// stdin reads paths from standard input.
+	stdin bool
+	// nul separates input and output records with NUL.
+	nul bool
+	// nonMatching also prints paths no rule matched.
+	nonMatching bool
+}
+
+// runCheck prints the rule deciding each path, like "git check-ignore -v".
+//
+// Exit code is 0 when at least one path is excluded, 1 when none is.
+func runCheck(args []string, env *cmdEnv) int {
+	var f checkFlags
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	flags.SetOutput(env.stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(env.stderr, "usage: pathrules check [flags] <path>...")
+		fmt.Fprintln(env.stderr, "       pathrules check [flags] -stdin")
+		fmt.Fprintln(env.stderr)
+		fmt.Fprintln(env.stderr, "Prints \"<rules file>:<line>:<pattern>\\t<path>\" for excluded paths")
+		fmt.Fprintln(env.stderr, "and, with -n, for all paths. Paths are relative to the tree root.")
+		fmt.Fprintln(env.stderr)
+		flags.PrintDefaults()
+	}
+
+	f.provider.register(flags)
+	flags.BoolVar(&f.stdin, "stdin", false, "read paths from standard input, one per line")
+	flags.BoolVar(&f.nul, "z", false, "NUL-separated input and output records")
+	flags.BoolVar(&f.nonMatching, "n", false, "print included and unmatched paths too")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	paths := flags.Args()
+	if f.stdin == (len(paths) > 0) {
+		flags.Usage()
+		return exitError
+	}
+
+	opts, err := f.provider.options()
+	if err != nil {
+		return failf(env, "check: %v", err)
+	}
+
+	p, err := pathrules.NewProvider(f.provider.root, opts)
+	if err != nil {
+		return failf(env, "check: %v", err)
+	}
+
+	out := bufio.NewWriter(env.stdout)
+	defer func() { _ = out.Flush() }()
+
+	excluded := false
+	check := func(raw string) error {
+		rel, isDir, err := checkPath(f.provider.root, raw)
+		if err != nil {
+			return err
+		}
+
+		res, err := p.DecideVerbose(rel, isDir)
+		if err != nil {
+			return err
+		}
+
+		if !res.Included {
+			excluded = true
+		} else if !f.nonMatching {
+			return nil
+		}
+
+		// Paths decided by the default action have no rule, as in git.
+		source := "::"
+		if res.Matched {
+			source = decidingRule(res, f.provider.rulesFile)
+		}
+
+		return writeRecord(out, source+"\t"+raw, f.nul)
+	}
+
+	if f.stdin {
+		err = readRecords(env.stdin, f.nul, check)
+	} else {
+		for _, raw := range paths {
+			if err = check(raw); err != nil {
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		_ = out.Flush()
+		return failf(env, "check: %v", err)
+	}
+
+	if !excluded {
+		return exitNoMatch
+	}
+
+	return exitOK
+}
+
+// checkPath returns raw path relative to root in slash form and whether it is a directory.
+//
+// A trailing "/" marks a directory; otherwise the file system is consulted.
+func checkPath(root string, raw string) (string, bool, error) {
+	isDir := strings.HasSuffix(raw, "/")
+	rel := raw
+	if filepath.IsAbs(raw) {
+		absRoot, err := filepath.Abs(root)
+		if err != nil {
+			return "", false, err
+		}
+
+		if rel, err = filepath.Rel(absRoot, raw); err != nil {
+			return "", false, err
+		}
+	}
+
+	rel = path.Clean(filepath.ToSlash(rel))
+	if !isDir {
+		if info, err := os.Stat(filepath.Join(root, filepath.FromSlash(rel))); err == nil {
+			isDir = info.IsDir()
+		}
+	}
+
+	return rel, isDir, nil
+}
+
+// decidingRule formats "<rules file>:<line>:<pattern>" of the rule deciding res.
+//
+// Rules files are named relative to the tree root.
+func decidingRule(res pathrules.VerboseResult, rulesFile string) string {
+	source := res.Rule.Source
+	if res.Source == pathrules.DecisionSourceRulesFile {
+		source = path.Join(res.Dir, rulesFile)
+	}
+
+	rule := res.Rule
+	rule.Section = ""
+	pattern := strings.TrimSuffix(pathrules.FormatRules([]pathrules.Rule{rule}), "\n")
+	return fmt.Sprintf("%s:%d:%s", source, rule.Line, pattern)
+}
+
+// readRecords calls fn for each newline or NUL separated record of r.
+func readRecords(r io.Reader, nul bool, fn func(string) error) error {
+	s := bufio.NewScanner(r)
+	if nul {
+		s.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+			if i := bytes.IndexByte(data, 0); i >= 0 {
+				return i + 1, data[:i], nil
+			}
+
+			if atEOF && len(data) > 0 {
+				return len(data), data, nil
+			}
+
+			return 0, nil, nil
+		})
+	}
+
+	for s.Scan() {
+		record := s.Text()
+		if !nul {
+			record = strings.TrimSuffix(record, "\r")
+		}
+
+		if record == "" {
+			continue
+		}
+
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+
+	return s.Err()
+}
+
+// writeRecord writes one newline or NUL terminated record.
+func writeRecord(w *bufio.Writer, record string, nul bool) error {
+	if _, err := w.WriteString(record); err != nil {
+		return err
+	}
+
+	if nul {
+		return w.WriteByte(0)
+	}
+
+	return w.WriteByte('\n')
+}
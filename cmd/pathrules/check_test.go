@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTree creates files with content under root.
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		full := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+
+		if err := os.WriteFile(full, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+}
+
+// runCmd runs the command line and returns exit code, stdout and stderr.
+func runCmd(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &cmdEnv{stdin: strings.NewReader(stdin), stdout: &stdout, stderr: &stderr})
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		".pathrules":     "*.log\n!keep.log\n",
+		"a/.pathrules":   "/local.tmp\n",
+		"b/.pathrules":   "/local.tmp\n",
+		"b/sub/data.txt": "",
+	})
+
+	code, out, errOut := runCmd("", "check", "-C", root, "x.log", "keep.log", "b/local.tmp", "a/local.tmp", "b/sub")
+	if code != exitOK {
+		t.Fatalf("exit=%d stderr=%s, want %d", code, errOut, exitOK)
+	}
+
+	want := ".pathrules:1:*.log\tx.log\n" +
+		"b/.pathrules:1:/local.tmp\tb/local.tmp\n" +
+		"a/.pathrules:1:/local.tmp\ta/local.tmp\n"
+	if out != want {
+		t.Fatalf("stdout=%q, want %q", out, want)
+	}
+
+	code, out, _ = runCmd("keep.log\x00b/sub\x00", "check", "-C", root, "-stdin", "-z", "-n")
+	if code != exitNoMatch {
+		t.Fatalf("exit=%d, want %d", code, exitNoMatch)
+	}
+
+	if want := ".pathrules:2:!keep.log\tkeep.log\x00::\tb/sub\x00"; out != want {
+		t.Fatalf("stdout=%q, want %q", out, want)
+	}
+}
+
+func TestCheckUsageErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		{"check"},
+		{"check", "-stdin", "a"},
+		{"check", "-dialect", "nope", "a"},
+		{"nope"},
+		{},
+	} {
+		if code, _, _ := runCmd("", args...); code != exitError {
+			t.Fatalf("run(%q) exit=%d, want %d", args, code, exitError)
+		}
+	}
+}
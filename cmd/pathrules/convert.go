@@ -0,0 +1,291 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/woozymasta/pathrules"
+)
+
+// Convert formats accepted by -from and -to.
+const (
+	convertPathrules    = "pathrules"
+	convertGitignore    = "gitignore"
+	convertDockerignore = "dockerignore"
+	convertRsyncFilter  = "rsync-filter"
+	convertExtensions   = "extensions"
+)
+
+// exportFormats maps convert target names to library export formats.
+var exportFormats = map[string]pathrules.ExportFormat{
+	convertPathrules:    pathrules.ExportPathrules,
+	convertGitignore:    pathrules.ExportGitignore,
+	convertDockerignore: pathrules.ExportDockerignore,
+	convertRsyncFilter:  pathrules.ExportRsyncFilter,
+}
+
+// convertFlags are convert command flags.
+type convertFlags struct {
+	// from is the input format name.
+	from string
+	// to is the output format name.
+	to string
+}
+
+// runConvert translates one rules file between formats.
+//
+// Rules are imported into pathrules semantics and exported with
+// ExportRules. Warnings go to stderr; exit code is 1 when a rule could
+// not be translated and was dropped.
+func runConvert(args []string, env *cmdEnv) int {
+	var f convertFlags
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	flags.SetOutput(env.stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(env.stderr, "usage: pathrules convert -from <format> -to <format> [file]")
+		fmt.Fprintln(env.stderr)
+		fmt.Fprintln(env.stderr, "Formats: pathrules, gitignore, dockerignore, rsync-filter, extensions.")
+		fmt.Fprintln(env.stderr, "Reads standard input when file is omitted or \"-\".")
+		fmt.Fprintln(env.stderr)
+		flags.PrintDefaults()
+	}
+
+	flags.StringVar(&f.from, "from", convertPathrules, "input `format`")
+	flags.StringVar(&f.to, "to", "", "output `format`")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	if f.to == "" || flags.NArg() > 1 {
+		flags.Usage()
+		return exitError
+	}
+
+	if _, ok := exportFormats[f.to]; !ok && f.to != convertExtensions {
+		return failf(env, "convert: unknown output format %q", f.to)
+	}
+
+	name, in := "-", env.stdin
+	if flags.NArg() == 1 && flags.Arg(0) != "-" {
+		name = flags.Arg(0)
+		file, err := os.Open(name)
+		if err != nil {
+			return failf(env, "convert: %v", err)
+		}
+		defer func() { _ = file.Close() }()
+
+		in = file
+	}
+
+	rules, diags, err := importRules(in, name, f.from)
+	if err != nil {
+		return failf(env, "convert: %v", err)
+	}
+
+	var text string
+	var exportDiags []pathrules.Diagnostic
+	if f.to == convertExtensions {
+		text, exportDiags = exportExtensions(rules)
+	} else {
+		text, exportDiags, err = pathrules.ExportRules(rules, exportFormats[f.to])
+		if err != nil {
+			return failf(env, "convert: %v", err)
+		}
+	}
+
+	// Export diagnostics index imported rules; report them by source line.
+	code := exitOK
+	for _, d := range append(diags, exportDiags...) {
+		line := 0
+		if d.RuleIndex >= 0 && d.RuleIndex < len(rules) {
+			line = rules[d.RuleIndex].Line
+		}
+
+		fmt.Fprintf(env.stderr, "%s:%d: %s [%s]\n", name, line, d, d.Code)
+		if d.Severity == pathrules.SeverityError {
+			code = exitNoMatch
+		}
+	}
+
+	if _, err := io.WriteString(env.stdout, text); err != nil {
+		return failf(env, "convert: %v", err)
+	}
+
+	return code
+}
+
+// importRules parses r in format from and translates rules to pathrules semantics.
+//
+// Returned diagnostics index the returned rules.
+func importRules(r io.Reader, name string, from string) ([]pathrules.Rule, []pathrules.Diagnostic, error) {
+	switch from {
+	case convertPathrules:
+		rules, err := pathrules.ParseRulesNamed(r, name)
+		return rules, nil, err
+	case convertGitignore:
+		rules, err := pathrules.ParseRulesNamed(r, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return importGitignore(rules)
+	case convertDockerignore:
+		rules, err := pathrules.ParseRulesNamed(r, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for i := range rules {
+			rules[i].Pattern = importDockerignorePattern(rules[i].Pattern)
+		}
+
+		return rules, nil, nil
+	case convertRsyncFilter:
+		filter, err := pathrules.ParseRsyncFilter(r, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return importRsyncFilter(filter)
+	case convertExtensions:
+		rules, err := importExtensions(r, name)
+		return rules, nil, err
+	default:
+		return nil, nil, fmt.Errorf("unknown input format %q", from)
+	}
+}
+
+// importGitignore anchors gitignore slash patterns, which git matches from
+// the rules file directory, and flags backslash escapes.
+func importGitignore(rules []pathrules.Rule) ([]pathrules.Rule, []pathrules.Diagnostic, error) {
+	var diags []pathrules.Diagnostic
+	for i := range rules {
+		pattern := rules[i].Pattern
+		if strings.Contains(pattern, `\`) {
+			diags = append(diags, convertDiagnostic(rules[i], i, pathrules.DiagUnsupportedSyntax, pathrules.SeverityWarning,
+				`"\" escapes are matched literally by pathrules`))
+		}
+
+		body := strings.TrimSuffix(pattern, "/")
+		if !strings.HasPrefix(pattern, "/") && !strings.HasPrefix(pattern, "**/") && strings.Contains(body, "/") {
+			rules[i].Pattern = "/" + pattern
+		}
+	}
+
+	return rules, diags, nil
+}
+
+// importDockerignorePattern anchors a dockerignore pattern to the context root.
+//
+// Docker has no directory-only patterns, a trailing "/" is dropped.
+func importDockerignorePattern(pattern string) string {
+	body := strings.TrimSuffix(strings.TrimPrefix(pattern, "/"), "/")
+	if body == "**" || strings.HasPrefix(body, "**/") {
+		return body
+	}
+
+	return "/" + body
+}
+
+// importRsyncFilter rewrites "dir/***" patterns and flags constructs
+// with no pathrules equivalent.
+func importRsyncFilter(filter *pathrules.RsyncFilter) ([]pathrules.Rule, []pathrules.Diagnostic, error) {
+	rules := filter.Rules
+	var diags []pathrules.Diagnostic
+	for i := range rules {
+		if body, ok := strings.CutSuffix(rules[i].Pattern, "/***"); ok {
+			rules[i].Pattern = body + "/"
+			continue
+		}
+
+		if rules[i].Action == pathrules.ActionInclude && strings.HasSuffix(rules[i].Pattern, "/") {
+			diags = append(diags, convertDiagnostic(rules[i], i, pathrules.DiagDirOnlyDropped, pathrules.SeverityWarning,
+				"rsync includes the directory only, pathrules includes its contents too"))
+		}
+	}
+
+	for _, merge := range filter.DirMerge {
+		diags = append(diags, pathrules.Diagnostic{
+			Code:      pathrules.DiagUnsupportedSyntax,
+			Message:   fmt.Sprintf("dir-merge %s dropped, per-directory rules need a Provider", merge),
+			Pattern:   merge,
+			RuleIndex: -1,
+			Offset:    -1,
+			Severity:  pathrules.SeverityError,
+		})
+	}
+
+	return rules, diags, nil
+}
+
+// importExtensions reads an extension allowlist: extensions separated by
+// whitespace or commas, "#" starts a comment. Everything else is excluded.
+func importExtensions(r io.Reader, name string) ([]pathrules.Rule, error) {
+	var exts []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line, _, _ := strings.Cut(s.Text(), "#")
+		exts = append(exts, strings.FieldsFunc(line, func(c rune) bool {
+			return c == ',' || c == ' ' || c == '\t'
+		})...)
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("scan %s: %w", name, err)
+	}
+
+	return append([]pathrules.Rule{{Action: pathrules.ActionExclude, Pattern: "*"}}, pathrules.ParseExtensions(exts)...), nil
+}
+
+// exportExtensions returns sorted extensions of "*.ext" include rules, one per line.
+//
+// A leading exclude "*" rule is implied; other rules cannot be represented
+// and are reported as errors.
+func exportExtensions(rules []pathrules.Rule) (string, []pathrules.Diagnostic) {
+	var exts []string
+	var diags []pathrules.Diagnostic
+	for i, rule := range rules {
+		if i == 0 && rule.Action == pathrules.ActionExclude && rule.Pattern == "*" {
+			continue
+		}
+
+		ext, ok := strings.CutPrefix(rule.Pattern, "*.")
+		if !ok || rule.Action != pathrules.ActionInclude || rule.Syntax != pathrules.PatternGlob ||
+			ext == "" || strings.ContainsAny(ext, `/*?[\`) {
+			diags = append(diags, convertDiagnostic(rule, i, pathrules.DiagUnsupportedSyntax, pathrules.SeverityError,
+				`extension lists hold only "*.ext" include rules`))
+			continue
+		}
+
+		exts = append(exts, strings.ToLower(ext))
+	}
+
+	slices.Sort(exts)
+	exts = slices.Compact(exts)
+	if len(exts) == 0 {
+		return "", diags
+	}
+
+	return strings.Join(exts, "\n") + "\n", diags
+}
+
+// convertDiagnostic builds one convert diagnostic for rule at index.
+func convertDiagnostic(rule pathrules.Rule, index int, code pathrules.DiagnosticCode, severity pathrules.Severity, msg string) pathrules.Diagnostic {
+	return pathrules.Diagnostic{
+		Code:      code,
+		Message:   msg,
+		Pattern:   rule.Pattern,
+		RuleIndex: index,
+		Offset:    -1,
+		Severity:  severity,
+	}
+}
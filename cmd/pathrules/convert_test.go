@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		input    string
+		args     []string
+		want     string
+		warnings []string
+		code     int
+	}{
+		{
+			name:  "gitignore to dockerignore",
+			input: "*.log\ndocs/build/\n!keep.log\n",
+			args:  []string{"-from", "gitignore", "-to", "dockerignore"},
+			want:  "**/*.log\ndocs/build\n!**/keep.log\n",
+			// Dockerignore has no directory-only patterns.
+			warnings: []string{"-:2: warning: dockerignore has no directory-only patterns"},
+		},
+		{
+			name:  "dockerignore to gitignore",
+			input: "node_modules\n**/*.tmp\n",
+			args:  []string{"-from", "dockerignore", "-to", "gitignore"},
+			want:  "/node_modules\n**/*.tmp\n",
+		},
+		{
+			name:     "rsync to pathrules",
+			input:    "+ keep/***\n+ */\n- *\ndir-merge .rules\n",
+			args:     []string{"-from", "rsync-filter", "-to", "pathrules"},
+			want:     "*\n!*/\n!keep/\n",
+			warnings: []string{"-:2: warning: rsync includes the directory only", "-:0: error: dir-merge .rules dropped"},
+			code:     exitNoMatch,
+		},
+		{
+			name:  "extensions to gitignore",
+			input: "txt, .md # docs\n*.GO\n",
+			args:  []string{"-from", "extensions", "-to", "gitignore"},
+			want:  "*\n!*.txt\n!*.md\n!*.go\n",
+		},
+		{
+			name:     "pathrules to extensions",
+			input:    "*\n!*.go\n!*.md\n!*.go\n!docs/\n",
+			args:     []string{"-to", "extensions"},
+			want:     "go\nmd\n",
+			warnings: []string{`-:5: error: extension lists hold only "*.ext" include rules`},
+			code:     exitNoMatch,
+		},
+	}
+
+	for _, tc := range cases {
+		code, out, errOut := runCmd(tc.input, append([]string{"convert"}, tc.args...)...)
+		if code != tc.code || out != tc.want {
+			t.Fatalf("%s: exit=%d stdout=%q stderr=%s, want %d %q", tc.name, code, out, errOut, tc.code, tc.want)
+		}
+
+		for _, w := range tc.warnings {
+			if !strings.Contains(errOut, w) {
+				t.Fatalf("%s: stderr=%q, want %q", tc.name, errOut, w)
+			}
+		}
+	}
+
+	if code, _, _ := runCmd("", "convert", "-to", "nope"); code != exitError {
+		t.Fatalf("unknown format exit=%d, want %d", code, exitError)
+	}
+}
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/woozymasta/pathrules"
+)
+
+// diffFlags are diff command flags.
+type diffFlags struct {
+	// provider are tree evaluation flags shared by both sides.
+	provider providerFlags
+	// oldRoot is a directory holding the old version of the tree rules files.
+	oldRoot string
+	// nul terminates output records with NUL.
+	nul bool
+}
+
+// runDiff prints tree paths whose walk decision differs between two rule sets.
+//
+// Output lines are "+ path" for newly included and "- path" for newly
+// excluded paths, directories with a trailing "/" and without their
+// contents. Exit code is 1 when any decision changed, as with diff(1).
+func runDiff(args []string, env *cmdEnv) int {
+	var f diffFlags
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flags.SetOutput(env.stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(env.stderr, "usage: pathrules diff [flags] <old rules> <new rules>")
+		fmt.Fprintln(env.stderr, "       pathrules diff [flags] -old-root <dir>")
+		fmt.Fprintln(env.stderr)
+		fmt.Fprintln(env.stderr, "The first form evaluates the tree with each rules file as base rules,")
+		fmt.Fprintln(env.stderr, "the second with rules files read from the old tree and from -C.")
+		fmt.Fprintln(env.stderr)
+		flags.PrintDefaults()
+	}
+
+	f.provider.register(flags)
+	flags.StringVar(&f.oldRoot, "old-root", "", "`dir` with the old version of the tree rules files")
+	flags.BoolVar(&f.nul, "z", false, "terminate records with NUL")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	if (f.oldRoot == "") != (flags.NArg() == 2) || (f.oldRoot != "" && flags.NArg() != 0) {
+		flags.Usage()
+		return exitError
+	}
+
+	opts, err := f.provider.options()
+	if err != nil {
+		return failf(env, "diff: %v", err)
+	}
+
+	oldRoot, oldOpts, newOpts := f.provider.root, opts, opts
+	if f.oldRoot != "" {
+		oldRoot = f.oldRoot
+	} else {
+		if oldOpts.BaseRules, err = pathrules.LoadRulesFile(flags.Arg(0)); err != nil {
+			return failf(env, "diff: %v", err)
+		}
+
+		if newOpts.BaseRules, err = pathrules.LoadRulesFile(flags.Arg(1)); err != nil {
+			return failf(env, "diff: %v", err)
+		}
+	}
+
+	oldP, err := pathrules.NewProvider(oldRoot, oldOpts)
+	if err != nil {
+		return failf(env, "diff: %v", err)
+	}
+
+	newP, err := pathrules.NewProvider(f.provider.root, newOpts)
+	if err != nil {
+		return failf(env, "diff: %v", err)
+	}
+
+	out := bufio.NewWriter(env.stdout)
+	changed := false
+	err = walkDiff(f.provider.root, oldP, newP, func(relPath string, d fs.DirEntry, included bool) error {
+		changed = true
+		mark := "- "
+		if included {
+			mark = "+ "
+		}
+
+		if d.IsDir() {
+			relPath += "/"
+		}
+
+		return writeRecord(out, mark+relPath, f.nul)
+	})
+
+	if flushErr := out.Flush(); err == nil {
+		err = flushErr
+	}
+
+	if err != nil {
+		return failf(env, "diff: %v", err)
+	}
+
+	if changed {
+		return exitNoMatch
+	}
+
+	return exitOK
+}
+
+// walkDiff walks root in lexical order and calls fn for entries walked by
+// exactly one provider, with included reporting whether newP walks it.
+//
+// As in Provider.Walk, an excluded directory hides its contents, so changed
+// directories are reported once and not descended.
+func walkDiff(root string, oldP, newP *pathrules.Provider, fn func(string, fs.DirEntry, bool) error) error {
+	return filepath.WalkDir(root, func(full string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if full == root {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, full)
+		if err != nil {
+			return err
+		}
+
+		rel = filepath.ToSlash(rel)
+		oldIncluded, err := oldP.Included(rel, d.IsDir())
+		if err != nil {
+			return err
+		}
+
+		newIncluded, err := newP.Included(rel, d.IsDir())
+		if err != nil {
+			return err
+		}
+
+		if oldIncluded != newIncluded {
+			if err := fn(rel, d, newIncluded); err != nil {
+				return err
+			}
+		}
+
+		if d.IsDir() && (!oldIncluded || !newIncluded) {
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"a.log":          "",
+		"a.txt":          "",
+		"build/out.bin":  "",
+		"docs/x.md":      "",
+		"src/.pathrules": "*.tmp\n",
+		"src/y.tmp":      "",
+	})
+
+	rules := t.TempDir()
+	writeTree(t, rules, map[string]string{
+		"old": "*.log\nbuild/\n",
+		"new": "*.txt\n/docs/\n",
+	})
+
+	code, out, errOut := runCmd("", "diff", "-C", root, filepath.Join(rules, "old"), filepath.Join(rules, "new"))
+	if code != exitNoMatch {
+		t.Fatalf("exit=%d stderr=%s, want %d", code, errOut, exitNoMatch)
+	}
+
+	if want := "+ a.log\n- a.txt\n+ build/\n- docs/\n"; out != want {
+		t.Fatalf("stdout=%q, want %q", out, want)
+	}
+
+	oldTree := t.TempDir()
+	writeTree(t, oldTree, map[string]string{"src/.pathrules": "*.bak\n"})
+
+	code, out, _ = runCmd("", "diff", "-C", root, "-old-root", oldTree, "-z")
+	if code != exitNoMatch || out != "- src/y.tmp\x00" {
+		t.Fatalf("old-root: exit=%d stdout=%q", code, out)
+	}
+
+	same := filepath.Join(rules, "old")
+	if code, out, _ := runCmd("", "diff", "-C", root, same, same); code != exitOK || out != "" {
+		t.Fatalf("same rules: exit=%d stdout=%q, want no changes", code, out)
+	}
+
+	if code, _, _ := runCmd("", "diff", "-C", root, same); code != exitError {
+		t.Fatalf("one rules file exit=%d, want %d", code, exitError)
+	}
+}
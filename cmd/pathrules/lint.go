@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/woozymasta/pathrules"
+)
+
+// lintCodeInvalidRule marks rules files lines rejected by the parser.
+const lintCodeInvalidRule pathrules.DiagnosticCode = "invalid-rule"
+
+// lintFlags are lint command flags.
+type lintFlags struct {
+	// rulesFormat is a formatNames key.
+	rulesFormat string
+	// output is "text" or "json".
+	output string
+	// failOn is the minimum severity name failing the run.
+	failOn string
+}
+
+// lintRecord is one lint finding in a rules file.
+type lintRecord struct {
+	// File is the rules file path.
+	File string `json:"file"`
+	// Diagnostic is the finding; RuleIndex counts rules in File.
+	pathrules.Diagnostic
+	// Line is the 1-based line in File, 0 when unknown.
+	Line int `json:"line,omitempty"`
+}
+
+// runLint validates rules files and reports shadowed and duplicate rules.
+//
+// Exit code is 1 when any finding reaches -fail-on severity.
+func runLint(args []string, env *cmdEnv) int {
+	var f lintFlags
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(env.stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(env.stderr, "usage: pathrules lint [flags] <rules file>...")
+		fmt.Fprintln(env.stderr)
+		flags.PrintDefaults()
+	}
+
+	flags.StringVar(&f.rulesFormat, "rules-format", "pathrules", "rules file format: pathrules, rsync-filter, hgignore")
+	flags.StringVar(&f.output, "format", "text", "output format: text or json")
+	flags.StringVar(&f.failOn, "fail-on", "error", "minimum severity failing the run: error or warning")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitError
+	}
+
+	format, ok := formatNames[strings.ToLower(f.rulesFormat)]
+	if !ok {
+		return failf(env, "lint: unknown rules format %q", f.rulesFormat)
+	}
+
+	var failOn pathrules.Severity
+	switch strings.ToLower(f.failOn) {
+	case "error":
+		failOn = pathrules.SeverityError
+	case "warning":
+		failOn = pathrules.SeverityWarning
+	default:
+		return failf(env, "lint: unknown severity %q", f.failOn)
+	}
+
+	if f.output != "text" && f.output != "json" {
+		return failf(env, "lint: unknown output format %q", f.output)
+	}
+
+	records := []lintRecord{}
+	for _, file := range flags.Args() {
+		fileRecords, err := lintFile(file, format)
+		if err != nil {
+			return failf(env, "lint: %v", err)
+		}
+
+		records = append(records, fileRecords...)
+	}
+
+	if f.output == "json" {
+		enc := json.NewEncoder(env.stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(records); err != nil {
+			return failf(env, "lint: %v", err)
+		}
+	} else {
+		for _, r := range records {
+			fmt.Fprintf(env.stdout, "%s:%d: %s [%s]\n", r.File, r.Line, r.Diagnostic, r.Code)
+		}
+	}
+
+	for _, r := range records {
+		if r.Severity >= failOn {
+			return exitNoMatch
+		}
+	}
+
+	return exitOK
+}
+
+// lintFile parses one rules file and returns its findings in rule order.
+//
+// Lines rejected by the parser are reported as errors and the file is not
+// analyzed further.
+func lintFile(file string, format pathrules.RulesFormat) ([]lintRecord, error) {
+	r, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = r.Close() }()
+
+	rules, err := parseRulesFile(r, file, format)
+	var records []lintRecord
+	var parseErr *pathrules.ParseError
+	switch {
+	case errors.As(err, &parseErr):
+		for _, ruleErr := range parseErr.Errors {
+			records = append(records, lintRecord{
+				File: file,
+				Line: ruleErr.Line,
+				Diagnostic: pathrules.Diagnostic{
+					Code:      lintCodeInvalidRule,
+					Message:   ruleErr.Err.Error(),
+					Pattern:   ruleErr.Pattern,
+					RuleIndex: -1,
+					Offset:    -1,
+					Severity:  pathrules.SeverityError,
+				},
+			})
+		}
+
+		return records, nil
+	case err != nil:
+		return nil, err
+	}
+
+	diags := append(pathrules.CheckRules(rules), pathrules.LintRules(rules)...)
+	for _, d := range diags {
+		records = append(records, lintRecord{File: file, Line: rules[d.RuleIndex].Line, Diagnostic: d})
+	}
+
+	slices.SortStableFunc(records, func(a, b lintRecord) int {
+		return cmp.Compare(a.RuleIndex, b.RuleIndex)
+	})
+
+	return records, nil
+}
+
+// parseRulesFile parses rules in format from r.
+func parseRulesFile(r io.Reader, name string, format pathrules.RulesFormat) ([]pathrules.Rule, error) {
+	switch format {
+	case pathrules.RulesFormatRsyncFilter:
+		f, err := pathrules.ParseRsyncFilter(r, name)
+		if err != nil {
+			return nil, err
+		}
+
+		return f.Rules, nil
+	case pathrules.RulesFormatHgignore:
+		return pathrules.ParseHgignore(r, name)
+	default:
+		return pathrules.ParseRulesWithOptions(r, pathrules.ParseOptions{SourceName: name, Strict: true})
+	}
+}
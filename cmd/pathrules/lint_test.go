@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"clean":  "*.log\n!keep.log\n",
+		"dead":   "# comment\nlogs/debug.log\n*.log\nsrc/[abc\n",
+		"broken": "*.tmp\n/\n",
+	})
+
+	clean := filepath.Join(root, "clean")
+	dead := filepath.Join(root, "dead")
+	broken := filepath.Join(root, "broken")
+
+	if code, out, errOut := runCmd("", "lint", clean); code != exitOK || out != "" {
+		t.Fatalf("clean: exit=%d stdout=%q stderr=%s", code, out, errOut)
+	}
+
+	code, out, _ := runCmd("", "lint", dead)
+	if code != exitOK {
+		t.Fatalf("dead: exit=%d, want %d for warnings", code, exitOK)
+	}
+
+	want := dead + `:2: warning: never wins: rule 1 ("*.log") matches every path it matches [shadowed-rule]` + "\n" +
+		dead + `:4: warning: unclosed "[" is matched literally (offset 4) [unclosed-char-class]` + "\n"
+	if out != want {
+		t.Fatalf("dead: stdout=%q, want %q", out, want)
+	}
+
+	if code, _, _ := runCmd("", "lint", "-fail-on", "warning", dead); code != exitNoMatch {
+		t.Fatalf("dead -fail-on warning: exit=%d, want %d", code, exitNoMatch)
+	}
+
+	code, out, _ = runCmd("", "lint", "-format", "json", clean, broken)
+	if code != exitNoMatch {
+		t.Fatalf("broken: exit=%d, want %d", code, exitNoMatch)
+	}
+
+	var records []struct {
+		File     string `json:"file"`
+		Code     string `json:"code"`
+		Severity string `json:"severity"`
+		Line     int    `json:"line"`
+	}
+	if err := json.Unmarshal([]byte(out), &records); err != nil {
+		t.Fatalf("Unmarshal(%q): %v", out, err)
+	}
+
+	if len(records) != 1 || records[0].File != broken || records[0].Line != 2 ||
+		records[0].Code != "invalid-rule" || records[0].Severity != "error" {
+		t.Fatalf("records=%+v, want one invalid-rule error on line 2", records)
+	}
+
+	if code, _, _ := runCmd("", "lint", filepath.Join(root, "missing")); code != exitError {
+		t.Fatalf("missing file exit=%d, want %d", code, exitError)
+	}
+}
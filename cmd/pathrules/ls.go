@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/woozymasta/pathrules"
+)
+
+// lsFlags are ls command flags.
+type lsFlags struct {
+	// provider are tree evaluation flags.
+	provider providerFlags
+	// excluded lists excluded instead of included paths.
+	excluded bool
+	// dirs lists directories too, with a trailing "/".
+	dirs bool
+	// nul terminates output records with NUL.
+	nul bool
+}
+
+// runLs lists included (or excluded) paths of a tree in Provider.Walk order.
+func runLs(args []string, env *cmdEnv) int {
+	var f lsFlags
+	flags := flag.NewFlagSet("ls", flag.ContinueOnError)
+	flags.SetOutput(env.stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(env.stderr, "usage: pathrules ls [flags]")
+		fmt.Fprintln(env.stderr)
+		fmt.Fprintln(env.stderr, "Lists included files relative to the tree root. Excluded directories")
+		fmt.Fprintln(env.stderr, "are pruned; with -excluded they are listed once and not descended.")
+		fmt.Fprintln(env.stderr)
+		flags.PrintDefaults()
+	}
+
+	f.provider.register(flags)
+	flags.BoolVar(&f.excluded, "excluded", false, "list excluded paths instead of included ones")
+	flags.BoolVar(&f.dirs, "dirs", false, "list directories too, with a trailing \"/\"")
+	flags.BoolVar(&f.nul, "null", false, "terminate records with NUL (for xargs -0, tar --null)")
+	flags.BoolVar(&f.nul, "z", false, "alias for -null")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	if flags.NArg() > 0 {
+		flags.Usage()
+		return exitError
+	}
+
+	opts, err := f.provider.options()
+	if err != nil {
+		return failf(env, "ls: %v", err)
+	}
+
+	p, err := pathrules.NewProvider(f.provider.root, opts)
+	if err != nil {
+		return failf(env, "ls: %v", err)
+	}
+
+	out := bufio.NewWriter(env.stdout)
+	write := func(relPath string, d fs.DirEntry) error {
+		if d.IsDir() {
+			if !f.dirs && !f.excluded {
+				return nil
+			}
+
+			relPath += "/"
+		}
+
+		return writeRecord(out, relPath, f.nul)
+	}
+
+	if f.excluded {
+		err = walkExcluded(f.provider.root, p, write)
+	} else {
+		err = p.Walk(context.Background(), write)
+	}
+
+	if flushErr := out.Flush(); err == nil {
+		err = flushErr
+	}
+
+	if err != nil {
+		return failf(env, "ls: %v", err)
+	}
+
+	return exitOK
+}
+
+// walkExcluded calls fn for excluded entries under root in lexical order.
+//
+// Excluded directories are reported and not descended, mirroring
+// Provider.Walk pruning.
+func walkExcluded(root string, p *pathrules.Provider, fn pathrules.WalkFunc) error {
+	return filepath.WalkDir(root, func(full string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if full == root {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, full)
+		if err != nil {
+			return err
+		}
+
+		rel = filepath.ToSlash(rel)
+		included, err := p.Included(rel, d.IsDir())
+		if err != nil || included {
+			return err
+		}
+
+		if err := fn(rel, d); err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package main
+
+import "testing"
+
+func TestLs(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		".pathrules":       "*.log\nbuild/\n",
+		"a.txt":            "",
+		"a.log":            "",
+		"build/out.bin":    "",
+		"src/main.go":      "",
+		"src/.pathrules":   "/gen/\n",
+		"src/gen/x.go":     "",
+		"src/debug/z.log":  "",
+		"src/debug/z.keep": "",
+	})
+
+	cases := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "included",
+			args: []string{"ls", "-C", root},
+			want: ".pathrules\na.txt\nsrc/.pathrules\nsrc/debug/z.keep\nsrc/main.go\n",
+		},
+		{
+			name: "dirs",
+			args: []string{"ls", "-C", root, "-dirs", "-null"},
+			want: ".pathrules\x00a.txt\x00src/\x00src/.pathrules\x00src/debug/\x00src/debug/z.keep\x00src/main.go\x00",
+		},
+		{
+			name: "excluded",
+			args: []string{"ls", "-C", root, "-excluded"},
+			want: "a.log\nbuild/\nsrc/debug/z.log\nsrc/gen/\n",
+		},
+	}
+
+	for _, tc := range cases {
+		code, out, errOut := runCmd("", tc.args...)
+		if code != exitOK || out != tc.want {
+			t.Fatalf("%s: exit=%d stdout=%q stderr=%s, want %q", tc.name, code, out, errOut, tc.want)
+		}
+	}
+
+	if code, _, _ := runCmd("", "ls", "-C", root, "extra"); code != exitError {
+		t.Fatalf("ls with argument exit=%d, want %d", code, exitError)
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+// Command pathrules inspects path rules decisions for a directory tree.
+//
+// Usage:
+//
+//	pathrules <command> [flags] [args]
+//
+// Commands:
+//
+//	check   print the rule deciding each path, like "git check-ignore -v"
+//	ls      list included or excluded paths of a tree
+//	lint    validate rules files and report dead rules
+//	convert translate rules between gitignore, dockerignore, rsync and other formats
+//	diff    print paths whose decision differs between two rule sets
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Exit codes shared by commands.
+const (
+	// exitOK reports success.
+	exitOK = 0
+	// exitNoMatch reports a successful run with nothing to report.
+	exitNoMatch = 1
+	// exitError reports usage, I/O or rules errors.
+	exitError = 2
+)
+
+// command is one pathrules subcommand.
+type command struct {
+	// run executes the command with arguments after the command name.
+	run func(args []string, env *cmdEnv) int
+	// name is the command name.
+	name string
+	// summary is a one-line description for usage output.
+	summary string
+}
+
+// cmdEnv holds process streams passed to commands.
+type cmdEnv struct {
+	// stdin is the standard input.
+	stdin io.Reader
+	// stdout is the standard output.
+	stdout io.Writer
+	// stderr is the standard error.
+	stderr io.Writer
+}
+
+// commands lists subcommands in usage order.
+var commands = []command{
+	{name: "check", summary: "print the rule deciding each path", run: runCheck},
+	{name: "ls", summary: "list included or excluded paths of a tree", run: runLs},
+	{name: "lint", summary: "validate rules files and report dead rules", run: runLint},
+	{name: "convert", summary: "translate rules between formats", run: runConvert},
+	{name: "diff", summary: "print paths whose decision differs between two rule sets", run: runDiff},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], &cmdEnv{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}))
+}
+
+// run dispatches args to a subcommand and returns the process exit code.
+func run(args []string, env *cmdEnv) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" || args[0] == "help" {
+		usage(env.stderr)
+		if len(args) == 0 {
+			return exitError
+		}
+
+		return exitOK
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], env)
+		}
+	}
+
+	fmt.Fprintf(env.stderr, "pathrules: unknown command %q\n", args[0])
+	usage(env.stderr)
+	return exitError
+}
+
+// usage prints the command list.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: pathrules <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `run "pathrules <command> -h" for command flags`)
+}
+
+// failf prints an error message and returns exitError.
+func failf(env *cmdEnv, format string, args ...any) int {
+	fmt.Fprintf(env.stderr, "pathrules: "+format+"\n", args...)
+	return exitError
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/woozymasta/pathrules"
+)
+
+// dialectNames maps command line dialect names to dialects.
+var dialectNames = map[string]pathrules.Dialect{
+	"default":  pathrules.DialectDefault,
+	"git":      pathrules.DialectGit,
+	"rsync":    pathrules.DialectRsync,
+	"hg":       pathrules.DialectHg,
+	"eslint":   pathrules.DialectESLint,
+	"prettier": pathrules.DialectPrettier,
+}
+
+// formatNames maps command line rules format names to formats.
+var formatNames = map[string]pathrules.RulesFormat{
+	"pathrules":    pathrules.RulesFormatPathrules,
+	"rsync-filter": pathrules.RulesFormatRsyncFilter,
+	"hgignore":     pathrules.RulesFormatHgignore,
+}
+
+// providerFlags are flags shared by commands evaluating a tree.
+type providerFlags struct {
+	// root is the tree root directory.
+	root string
+	// rulesFile is the per-directory rules file name.
+	rulesFile string
+	// dialect is a dialectNames key.
+	dialect string
+	// format is a formatNames key.
+	format string
+	// defaultAction is "include" or "exclude".
+	defaultAction string
+	// caseInsensitive enables ASCII case-insensitive matching.
+	caseInsensitive bool
+	// smartCase matches lowercase-only patterns case-insensitively.
+	smartCase bool
+	// profile selects a rules file section, empty disables section syntax.
+	profile string
+}
+
+// register adds provider flags to the flag set.
+func (f *providerFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&f.root, "C", ".", "tree root `dir`")
+	flags.StringVar(&f.rulesFile, "rules-file", ".pathrules", "per-directory rules file `name`")
+	flags.StringVar(&f.dialect, "dialect", "default", "pattern dialect: default, git, rsync, hg, eslint, prettier")
+	flags.StringVar(&f.format, "rules-format", "pathrules", "rules file format: pathrules, rsync-filter, hgignore")
+	flags.StringVar(&f.defaultAction, "default", "include", "decision when no rule matched: include or exclude")
+	flags.BoolVar(&f.caseInsensitive, "i", false, "match case-insensitively")
+	flags.StringVar(&f.profile, "profile", "", "rules file section `name` to apply with common rules")
+	flags.BoolVar(&f.smartCase, "S", false, "match case-insensitively unless the pattern has uppercase letters")
+}
+
+// options returns provider options described by flags.
+func (f *providerFlags) options() (pathrules.ProviderOptions, error) {
+	dialect, ok := dialectNames[strings.ToLower(f.dialect)]
+	if !ok {
+		return pathrules.ProviderOptions{}, fmt.Errorf("unknown dialect %q", f.dialect)
+	}
+
+	format, ok := formatNames[strings.ToLower(f.format)]
+	if !ok {
+		return pathrules.ProviderOptions{}, fmt.Errorf("unknown rules format %q", f.format)
+	}
+
+	action, err := parseAction(f.defaultAction)
+	if err != nil {
+		return pathrules.ProviderOptions{}, err
+	}
+
+	return pathrules.ProviderOptions{
+		RulesFileName: f.rulesFile,
+		RulesFormat:   format,
+		MatcherOptions: pathrules.MatcherOptions{
+			CaseInsensitive: f.caseInsensitive,
+			SmartCase:       f.smartCase,
+			DefaultAction:   action,
+			Dialect:         dialect,
+			Profile:         f.profile,
+		},
+	}, nil
+}
+
+// parseAction parses "include" or "exclude".
+func parseAction(name string) (pathrules.Action, error) {
+	switch strings.ToLower(name) {
+	case "include":
+		return pathrules.ActionInclude, nil
+	case "exclude":
+		return pathrules.ActionExclude, nil
+	default:
+		return pathrules.ActionUnknown, fmt.Errorf("unknown action %q", name)
+	}
+}
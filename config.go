@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Config is a structured rule-set document.
+//
+// ParseConfig and LoadConfig read JSON; YAML documents decode into Config
+// with any YAML library through the same field names.
+type Config struct {
+	// Options are matcher options.
+	Options MatcherOptions `json:"options" yaml:"options"`
+	// RulesFileName is the per-directory rules file of ProviderOptions.
+	RulesFileName string `json:"rules_file_name,omitempty" yaml:"rules_file_name,omitempty"`
+	// Extensions are included file extensions, see ParseExtensions.
+	Extensions []string `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+	// Patterns are rules lines in pathrules syntax, after Extensions.
+	Patterns []string `json:"patterns,omitempty" yaml:"patterns,omitempty"`
+	// Groups are named rule groups after Patterns.
+	Groups []RuleGroup `json:"groups,omitempty" yaml:"groups,omitempty"`
+	// Rules are structured rules after Groups.
+	Rules []Rule `json:"rules,omitempty" yaml:"rules,omitempty"`
+	// Sections selects groups (and rules with a matching Rule.Section) to
+	// use; empty value uses every group.
+	Sections []string `json:"sections,omitempty" yaml:"sections,omitempty"`
+}
+
+// RuleGroup is a named group of rules lines in pathrules syntax.
+type RuleGroup struct {
+	// Name is recorded as Rule.Section of group rules.
+	Name string `json:"name" yaml:"name"`
+	// Patterns are rules lines in pathrules syntax.
+	Patterns []string `json:"patterns" yaml:"patterns"`
+}
+
+// ParseConfig decodes a JSON config document; unknown fields are rejected.
+func ParseConfig(r io.Reader) (*Config, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	var cfg Config
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("%w: decode config: %v", ErrInvalidOptions, err)
+	}
+
+	return &cfg, nil
+}
+
+// LoadConfig reads and decodes a JSON config file.
+func LoadConfig(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open config: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	cfg, err := ParseConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// RuleSet returns config rules in document order: Extensions, Patterns,
+// Groups and Rules, filtered by Sections.
+func (c *Config) RuleSet() ([]Rule, error) {
+	rules := ParseExtensions(c.Extensions)
+
+	patterns, err := ParseRulesNamed(strings.NewReader(strings.Join(c.Patterns, "\n")), "patterns")
+	if err != nil {
+		return nil, err
+	}
+
+	rules = append(rules, patterns...)
+	for _, group := range c.Groups {
+		if strings.TrimSpace(group.Name) == "" {
+			return nil, fmt.Errorf("%w: rule group without name", ErrInvalidOptions)
+		}
+
+		groupRules, err := ParseRulesNamed(strings.NewReader(strings.Join(group.Patterns, "\n")), "groups."+group.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range groupRules {
+			groupRules[i].Section = group.Name
+		}
+
+		rules = append(rules, groupRules...)
+	}
+
+	rules = append(rules, c.Rules...)
+	if len(c.Sections) > 0 {
+		rules = SelectSections(rules, c.Sections...)
+	}
+
+	return rules, nil
+}
+
+// NewMatcher compiles config rules with config options.
+func (c *Config) NewMatcher() (*Matcher, error) {
+	rules, err := c.RuleSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMatcher(rules, c.Options)
+}
+
+// ProviderOptions returns provider options with config rules as BaseRules.
+func (c *Config) ProviderOptions() (ProviderOptions, error) {
+	rules, err := c.RuleSet()
+	if err != nil {
+		return ProviderOptions{}, err
+	}
+
+	return ProviderOptions{
+		RulesFileName:  c.RulesFileName,
+		BaseRules:      rules,
+		MatcherOptions: c.Options,
+	}, nil
+}
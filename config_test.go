@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	t.Parallel()
+
+	doc := `{
+		"options": {"case_insensitive": true},
+		"rules_file_name": ".assetignore",
+		"extensions": ["tmp", ".bak"],
+		"patterns": ["*", "!*.tmp"],
+		"groups": [
+			{"name": "ci", "patterns": ["*.log"]},
+			{"name": "release", "patterns": ["debug/"]}
+		],
+		"rules": [{"action": "include", "pattern": "keep.bak"}, "!debug.log"],
+		"sections": ["ci"]
+	}`
+
+	cfg, err := ParseConfig(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("ParseConfig: %v", err)
+	}
+
+	rules, err := cfg.RuleSet()
+	if err != nil {
+		t.Fatalf("RuleSet: %v", err)
+	}
+
+	patterns := make([]string, len(rules))
+	for i := range rules {
+		patterns[i] = rules[i].Pattern
+	}
+
+	if got, want := strings.Join(patterns, " "), "*.tmp *.bak * *.tmp *.log keep.bak debug.log"; got != want {
+		t.Fatalf("patterns=%q, want %q", got, want)
+	}
+
+	if rules[4].Section != "ci" {
+		t.Fatalf("rules[4].Section=%q, want ci", rules[4].Section)
+	}
+
+	m, err := cfg.NewMatcher()
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if m.Included("A.LOG", false) || !m.Included("x.TMP", false) || m.Included("debug", true) {
+		t.Fatalf("matcher ignores config options or sections")
+	}
+
+	opts, err := cfg.ProviderOptions()
+	if err != nil || opts.RulesFileName != ".assetignore" || len(opts.BaseRules) != len(rules) || !opts.MatcherOptions.CaseInsensitive {
+		t.Fatalf("ProviderOptions=%+v err=%v", opts, err)
+	}
+}
+
+func TestParseConfigRejectsInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, doc := range []string{`{"bogus": 1}`, `{"groups": [{"patterns": ["x"]}]}`} {
+		cfg, err := ParseConfig(strings.NewReader(doc))
+		if err == nil {
+			_, err = cfg.RuleSet()
+		}
+
+		if !errors.Is(err, ErrInvalidOptions) {
+			t.Fatalf("doc %s: err=%v, want ErrInvalidOptions", doc, err)
+		}
+	}
+}
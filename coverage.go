@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "sync/atomic"
+
+// RuleCoverage is the lifetime hit count of one rule.
+type RuleCoverage struct {
+	// Rule is the user rule.
+	Rule Rule `json:"rule" yaml:"rule"`
+	// Index is the rule index in matcher input order.
+	Index int `json:"index" yaml:"index"`
+	// Won counts decisions the rule decided.
+	Won uint64 `json:"won" yaml:"won"`
+	// Matched counts evaluated paths the rule matched, won or not.
+	Matched uint64 `json:"matched" yaml:"matched"`
+}
+
+// ruleCoverage holds per-rule counters aligned with user rules.
+type ruleCoverage struct {
+	// won counts decisions won by each user rule.
+	won []atomic.Uint64
+	// matched counts paths matched by each user rule.
+	matched []atomic.Uint64
+}
+
+// newRuleCoverage returns counters for count user rules, nil when disabled.
+func newRuleCoverage(enabled bool, count int) *ruleCoverage {
+	if !enabled {
+		return nil
+	}
+
+	return &ruleCoverage{
+		won:     make([]atomic.Uint64, count),
+		matched: make([]atomic.Uint64, count),
+	}
+}
+
+// Coverage returns per-rule counters in rules order, nil unless
+// MatcherOptions.TrackCoverage is set.
+//
+// Rules with zero Matched never matched any evaluated path; rules with zero
+// Won never decided one. Decisions served by the memo (MatcherOptions.MemoSize)
+// count wins only. Counters are read atomically per rule while decisions
+// may run concurrently.
+func (m *Matcher) Coverage() []RuleCoverage {
+	if m.coverage == nil {
+		return nil
+	}
+
+	out := make([]RuleCoverage, m.ruleCount)
+	for i := range out {
+		out[i] = RuleCoverage{
+			Rule:    m.compiled[m.compiledIndex(i)].source,
+			Index:   i,
+			Won:     m.coverage.won[i].Load(),
+			Matched: m.coverage.matched[i].Load(),
+		}
+	}
+
+	return out
+}
+
+// ResetCoverage zeroes coverage counters.
+func (m *Matcher) ResetCoverage() {
+	if m.coverage == nil {
+		return
+	}
+
+	for i := range m.coverage.won {
+		m.coverage.won[i].Store(0)
+		m.coverage.matched[i].Store(0)
+	}
+}
+
+// recordWin counts the user rule deciding res.
+func (m *Matcher) recordWin(res MatchResult) {
+	if res.RuleIndex >= 0 {
+		m.coverage.won[res.RuleIndex].Add(1)
+	}
+}
+
+// recordMatches counts every user rule matching candidate.
+func (m *Matcher) recordMatches(candidate string, isDir bool) {
+	for i := range m.ruleCount {
+		if m.compiled[m.compiledIndex(i)].matches(candidate, isDir) {
+			m.coverage.matched[i].Add(1)
+		}
+	}
+}
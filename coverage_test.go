@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "testing"
+
+func TestMatcherCoverage(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionExclude, Pattern: "*.log"},
+		{Action: ActionInclude, Pattern: "keep.log"},
+		{Action: ActionExclude, Pattern: "*.never"},
+	}
+
+	for _, memo := range []int{0, 8} {
+		m, err := NewMatcher(rules, MatcherOptions{TrackCoverage: true, MemoSize: memo})
+		if err != nil {
+			t.Fatalf("NewMatcher: %v", err)
+		}
+
+		for _, path := range []string{"a.log", "keep.log", "b.txt"} {
+			m.Decide(path, false)
+		}
+
+		cov := m.Coverage()
+		want := []struct{ won, matched uint64 }{{1, 2}, {1, 1}, {0, 0}}
+		for i, w := range want {
+			if cov[i].Index != i || cov[i].Rule.Pattern != rules[i].Pattern || cov[i].Won != w.won || cov[i].Matched != w.matched {
+				t.Fatalf("memo=%d: Coverage[%d]=%+v, want won=%d matched=%d", memo, i, cov[i], w.won, w.matched)
+			}
+		}
+
+		m.ResetCoverage()
+		if cov := m.Coverage(); cov[0].Won != 0 || cov[0].Matched != 0 {
+			t.Fatalf("after reset Coverage[0]=%+v, want zero", cov[0])
+		}
+	}
+
+	m, err := NewMatcher(rules, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	m.Decide("a.log", false)
+	if cov := m.Coverage(); cov != nil {
+		t.Fatalf("Coverage=%+v, want nil when disabled", cov)
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "fmt"
+
+// ActionCustom is the first user-defined action. Rules with an action at or
+// above it are resolved by MatcherOptions.ActionHandlers at Decide time:
+//
+//	const (
+//		ActionWarn = pathrules.ActionCustom + iota
+//		ActionQuarantine
+//	)
+const ActionCustom Action = 16
+
+// ActionHandler resolves a custom action and reports whether path is
+// included. Rule is the winning rule; path is normalized and relative to
+// the matcher rules.
+//
+// Handlers run on every decision, including decisions served from the memo,
+// and must be safe for concurrent use when the matcher is shared.
+type ActionHandler func(path string, isDir bool, rule Rule) bool
+
+// custom reports whether action is user-defined.
+func (a Action) custom() bool {
+	return a >= ActionCustom
+}
+
+// compilable reports whether rules with action can be compiled.
+func (a Action) compilable() bool {
+	return a.valid() || a.custom()
+}
+
+// checkActionHandlers verifies that every custom action in rules has a handler.
+func checkActionHandlers(rules []Rule, handlers map[Action]ActionHandler) error {
+	for i := range rules {
+		if action := rules[i].Action; action.custom() && handlers[action] == nil {
+			return fmt.Errorf("%w: rule %d (%q) has custom action %d without handler",
+				ErrInvalidOptions, i, rules[i].Pattern, action)
+		}
+	}
+
+	return nil
+}
+
+// resolveAction applies the custom action handler of the winning rule and
+// sets a fresh copy of it as MatchResult.Rule when requested. It runs after
+// the decision memo, so memoized results never share a Rule.
+//
+// Matchers decoded by UnmarshalBinary have no handlers and apply the
+// default action to custom action matches.
+func (m *Matcher) resolveAction(candidate string, isDir bool, res MatchResult) MatchResult {
+	if res.RuleIndex < 0 {
+		return res
+	}
+
+	if m.resultRule {
+		rule := m.compiled[m.compiledIndex(res.RuleIndex)].source.clone()
+		res.Rule = &rule
+	}
+
+	if !res.Action.custom() {
+		return res
+	}
+
+	handler := m.actionHandlers[res.Action]
+	if handler == nil {
+		res.Included = m.defaultAction == ActionInclude
+		return res
+	}
+
+	res.Included = handler(candidate, isDir, m.compiled[m.compiledIndex(res.RuleIndex)].source)
+	return res
+}
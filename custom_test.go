@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+const (
+	testActionWarn = ActionCustom + iota
+	testActionQuarantine
+)
+
+func TestMatcherCustomActions(t *testing.T) {
+	t.Parallel()
+
+	rules, err := ParseRulesWithOptions(strings.NewReader("*.log\n@warn *.tmp\n@quarantine vendor/**\n!vendor/keep.go\n\\@literal\n"), ParseOptions{
+		Actions: map[string]Action{"warn": testActionWarn, "quarantine": testActionQuarantine},
+	})
+	if err != nil {
+		t.Fatalf("ParseRulesWithOptions: %v", err)
+	}
+
+	if rules[1].Action != testActionWarn || rules[1].Pattern != "*.tmp" || rules[4].Pattern != "@literal" {
+		t.Fatalf("rules=%+v, want custom action and escaped literal", rules)
+	}
+
+	var warned atomic.Int32
+	m, err := NewMatcher(rules, MatcherOptions{
+		Dialect:  DialectGit,
+		MemoSize: 8,
+		ActionHandlers: map[Action]ActionHandler{
+			testActionWarn: func(path string, isDir bool, rule Rule) bool {
+				warned.Add(1)
+				return true
+			},
+			testActionQuarantine: func(path string, isDir bool, rule Rule) bool {
+				return false
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	cases := []struct {
+		path     string
+		action   Action
+		included bool
+	}{
+		{path: "a.log", action: ActionExclude, included: false},
+		{path: "a.tmp", action: testActionWarn, included: true},
+		{path: "vendor/lib.go", action: testActionQuarantine, included: false},
+		{path: "vendor/keep.go", action: ActionInclude, included: true},
+		{path: "a.go", action: ActionUnknown, included: true},
+	}
+
+	for _, tc := range cases {
+		res := m.Decide(tc.path, false)
+		if res.Action != tc.action || res.Included != tc.included {
+			t.Fatalf("Decide(%q)=%+v, want action=%d included=%v", tc.path, res, tc.action, tc.included)
+		}
+	}
+
+	m.Decide("a.tmp", false)
+	if got := warned.Load(); got != 2 {
+		t.Fatalf("warn handler calls=%d, want 2 (memo hits resolve too)", got)
+	}
+}
+
+func TestMatcherCustomActionErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewMatcher([]Rule{{Action: testActionWarn, Pattern: "*.tmp"}}, MatcherOptions{})
+	if !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("err=%v, want ErrInvalidOptions for missing handler", err)
+	}
+
+	_, err = ParseRulesWithOptions(strings.NewReader("@unknown *.tmp\n"), ParseOptions{
+		Actions: map[string]Action{"warn": testActionWarn},
+	})
+	if !errors.Is(err, ErrInvalidRule) {
+		t.Fatalf("err=%v, want ErrInvalidRule for unknown action", err)
+	}
+}
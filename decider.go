@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+// Decider decides include/exclude for slash-separated paths.
+//
+// Provider and MultiProvider implement it directly. Matcher decide methods
+// do not return errors, except DecideErr; Matcher.Decider adapts one. Package
+// pathrulestest provides a configurable fake for unit tests.
+type Decider interface {
+	// Decide returns the decision for path.
+	Decide(path string, isDir bool) (MatchResult, error)
+	// Included reports whether path is included.
+	Included(path string, isDir bool) (bool, error)
+	// Excluded reports whether path is excluded.
+	Excluded(path string, isDir bool) (bool, error)
+}
+
+var (
+	_ Decider = (*Provider)(nil)
+	_ Decider = (*MultiProvider)(nil)
+	_ Decider = matcherDecider{}
+)
+
+// matcherDecider adapts Matcher to Decider.
+type matcherDecider struct {
+	// m decides every path.
+	m *Matcher
+}
+
+// Decider returns m as a Decider whose methods return errors only for
+// paths rejected by MatcherOptions.StrictPaths, see DecideErr.
+func (m *Matcher) Decider() Decider {
+	return matcherDecider{m: m}
+}
+
+// Decide implements Decider.
+func (d matcherDecider) Decide(path string, isDir bool) (MatchResult, error) {
+	return d.m.DecideErr(path, isDir)
+}
+
+// Included implements Decider.
+func (d matcherDecider) Included(path string, isDir bool) (bool, error) {
+	res, err := d.m.DecideErr(path, isDir)
+	return res.Included, err
+}
+
+// Excluded implements Decider.
+func (d matcherDecider) Excluded(path string, isDir bool) (bool, error) {
+	res, err := d.m.DecideErr(path, isDir)
+	return err == nil && !res.Included, err
+}
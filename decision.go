@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "fmt"
+
+// Decision is a tri-state rule decision.
+type Decision uint8
+
+const (
+	// DecisionDefault means no rule matched and the default action applied;
+	// layered callers treat it as "unspecified".
+	DecisionDefault Decision = iota
+	// DecisionInclude means a rule explicitly included the path.
+	DecisionInclude
+	// DecisionExclude means a rule explicitly excluded the path.
+	DecisionExclude
+)
+
+// String returns decision name.
+func (d Decision) String() string {
+	switch d {
+	case DecisionDefault:
+		return "default"
+	case DecisionInclude:
+		return "include"
+	case DecisionExclude:
+		return "exclude"
+	default:
+		return fmt.Sprintf("decision(%d)", uint8(d))
+	}
+}
+
+// MarshalText encodes decision as its name.
+func (d Decision) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// Decision returns the tri-state form of the result.
+func (r MatchResult) Decision() Decision {
+	switch {
+	case !r.Matched:
+		return DecisionDefault
+	case r.Included:
+		return DecisionInclude
+	default:
+		return DecisionExclude
+	}
+}
+
+// DecideStrict returns the decision for path without applying the default
+// action: DecisionDefault when no rule matched.
+func (m *Matcher) DecideStrict(path string, isDir bool) Decision {
+	return m.Decide(path, isDir).Decision()
+}
+
+// DecideStrict returns the decision for a path relative to provider root
+// without applying default actions: DecisionDefault when no rule matched at
+// any level.
+func (p *Provider) DecideStrict(relPath string, isDir bool) (Decision, error) {
+	res, err := p.Decide(relPath, isDir)
+	if err != nil {
+		return DecisionDefault, err
+	}
+
+	return res.Decision(), nil
+}
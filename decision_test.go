@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMatcherDecideStrict(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionExclude, Pattern: "*.tmp"},
+		{Action: ActionInclude, Pattern: "keep.tmp"},
+	}
+
+	for _, def := range []Action{ActionInclude, ActionExclude} {
+		m, err := NewMatcher(rules, MatcherOptions{DefaultAction: def})
+		if err != nil {
+			t.Fatalf("NewMatcher: %v", err)
+		}
+
+		cases := map[string]Decision{
+			"a.tmp":    DecisionExclude,
+			"keep.tmp": DecisionInclude,
+			"a.txt":    DecisionDefault,
+		}
+
+		for path, want := range cases {
+			if got := m.DecideStrict(path, false); got != want {
+				t.Fatalf("default=%d: DecideStrict(%q)=%s, want %s", def, path, got, want)
+			}
+		}
+	}
+
+	data, err := json.Marshal(DecisionExclude)
+	if err != nil || string(data) != `"exclude"` {
+		t.Fatalf("Marshal=%s err=%v, want \"exclude\"", data, err)
+	}
+}
+
+func TestProviderDecideStrict(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":     {Data: []byte("#pragma default=exclude\n*.log\n")},
+		"sub/.pathrules": {Data: []byte("!debug.log\n")},
+	}
+
+	p, err := NewProviderFS(fsys, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	cases := map[string]Decision{
+		"a.log":         DecisionExclude,
+		"sub/debug.log": DecisionInclude,
+		"a.txt":         DecisionDefault,
+	}
+
+	for path, want := range cases {
+		if got, err := p.DecideStrict(path, false); err != nil || got != want {
+			t.Fatalf("DecideStrict(%q)=%s err=%v, want %s", path, got, err, want)
+		}
+	}
+
+	var nilProvider *Provider
+	if _, err := nilProvider.DecideStrict("a", false); !errors.Is(err, ErrNilProvider) {
+		t.Fatalf("nil provider err=%v, want ErrNilProvider", err)
+	}
+}
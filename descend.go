@@ -0,0 +1,317 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "strings"
+
+// ShouldDescend reports whether some path below dirPath could be included,
+// so a walker must read the directory even when it is excluded itself, as
+// with "build/" followed by "!build/keep.txt".
+//
+// The answer is conservative: false means every path below dirPath is
+// excluded, true means an included path may exist. Rules with metadata
+// predicates are ignored, as in Decide.
+func (m *Matcher) ShouldDescend(dirPath string) bool {
+	candidate := m.normalizeCandidate(dirPath)
+	if candidate == "." {
+		candidate = ""
+	}
+
+	if candidate != "" {
+		if m.overPathLimit(candidate) {
+			return false
+		}
+
+		// Parent exclusion dialects exclude the contents of excluded directories.
+		res := m.decideCandidate(candidate, true)
+		if m.dialect.parentExclusion() && res.Matched && res.Action == ActionExclude {
+			return false
+		}
+	}
+
+	cover := m.descendCover(candidate)
+	if cover < 0 {
+		return m.defaultAction == ActionInclude || m.includesBelow(candidate, -1)
+	}
+
+	return m.compiled[cover].source.Action != ActionExclude || m.includesBelow(candidate, cover)
+}
+
+// ShouldDescend reports whether some path below relDir could be included,
+// see Matcher.ShouldDescend.
+//
+// BaseRules, FinalRules and rules files from root down to relDir itself are
+// considered; rules files deeper in the subtree are not read, as Walk never
+// reads them below a pruned directory.
+func (p *Provider) ShouldDescend(relDir string) (bool, error) {
+	if p == nil {
+		return false, ErrNilProvider
+	}
+
+	if err := p.checkNames(relDir); err != nil {
+		return false, err
+	}
+
+	normalizedDir, err := p.cleanRelDir(relDir)
+	if err != nil {
+		return false, err
+	}
+
+	normalizedDir = p.scopedPath(normalizedDir)
+	// Blocked contents can only be re-included by final rules.
+	if normalizedDir != "" && p.parentBlocks {
+		res, err := p.decideLevels(normalizedDir, true)
+		if err != nil {
+			return false, err
+		}
+
+		if !res.Included && res.Action == ActionExclude {
+			return p.finalIncludesBelow(normalizedDir), nil
+		}
+	}
+
+	matchers, err := p.prepareProviderDirMatchers(normalizedDir, normalizedDir)
+	if err != nil {
+		return false, err
+	}
+
+	// levels are ordered by precedence: base rules, rules files from the
+	// outermost, then final rules.
+	type level struct {
+		matcher   *Matcher
+		candidate string
+	}
+
+	levels := make([]level, 0, len(matchers)+2)
+	if p.baseMatcher != nil && !p.eval.skipsBase() {
+		levels = append(levels, level{matcher: p.baseMatcher, candidate: normalizedDir})
+	}
+
+	defaultIncluded := p.defaultIncluded
+	for i := range matchers {
+		dm := &matchers[i]
+		candidate, ok := descendCandidate(dm, normalizedDir)
+		if !ok {
+			continue
+		}
+
+		if dm.excluded {
+			// An excluded subtree outranks every earlier level.
+			levels = append(levels[:0], level{})
+			continue
+		}
+
+		if dm.matcher.explicitDefault {
+			defaultIncluded = dm.matcher.defaultAction == ActionInclude
+		}
+
+		levels = append(levels, level{matcher: dm.matcher, candidate: candidate})
+	}
+
+	if p.finalMatcher != nil && !p.eval.skipsFinal() {
+		levels = append(levels, level{matcher: p.finalMatcher, candidate: normalizedDir})
+	}
+
+	// The highest covering rule decides every path below unless a later
+	// include rule may match.
+	top, cover := -1, -1
+	for i := len(levels) - 1; i >= 0 && top < 0; i-- {
+		if levels[i].matcher == nil {
+			top, cover = i, -1
+			break
+		}
+
+		if c := levels[i].matcher.descendCover(levels[i].candidate); c >= 0 {
+			top, cover = i, c
+		}
+	}
+
+	if top >= 0 && cover >= 0 && levels[top].matcher.compiled[cover].source.Action != ActionExclude {
+		return true, nil
+	}
+
+	if top < 0 && defaultIncluded {
+		return true, nil
+	}
+
+	for i := max(top, 0); i < len(levels); i++ {
+		if levels[i].matcher == nil {
+			continue
+		}
+
+		over := -1
+		if i == top {
+			over = cover
+		}
+
+		if levels[i].matcher.includesBelow(levels[i].candidate, over) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// finalIncludesBelow reports whether a final rule may include a path below normalizedDir.
+func (p *Provider) finalIncludesBelow(normalizedDir string) bool {
+	return p.finalMatcher != nil && !p.eval.skipsFinal() && p.finalMatcher.includesBelow(normalizedDir, -1)
+}
+
+// descendCandidate returns relDir relative to the directory of dm, "" when
+// dm is relDir's own rules file.
+func descendCandidate(dm *providerDirMatcher, relDir string) (string, bool) {
+	switch {
+	case dm.outer != "" && relDir == "":
+		return dm.outer, true
+	case dm.outer != "":
+		return dm.outer + "/" + relDir, true
+	case dm.prefix == relDir:
+		return "", true
+	}
+
+	return dirCandidate(dm.prefix, relDir)
+}
+
+// descendCover returns the highest-ranked compiled rule matching every path
+// below candidate ("" for all paths), -1 when none does.
+func (m *Matcher) descendCover(candidate string) int {
+	cover := -1
+	for i := range m.compiled {
+		r := &m.compiled[i]
+		if r.source.Meta != nil || !r.coversBelow(candidate) {
+			continue
+		}
+
+		if cover < 0 || m.outranks(i, cover) {
+			cover = i
+		}
+	}
+
+	return cover
+}
+
+// includesBelow reports whether a non-exclude rule outranking compiled rule
+// over (any rule when over is -1) may match a path below candidate.
+func (m *Matcher) includesBelow(candidate string, over int) bool {
+	return m.decidesBelow(candidate, over, ActionExclude)
+}
+
+// excludesBelow reports whether a non-include rule outranking compiled rule
+// over (any rule when over is -1) may match a path below candidate.
+func (m *Matcher) excludesBelow(candidate string, over int) bool {
+	return m.decidesBelow(candidate, over, ActionInclude)
+}
+
+// decidesBelow reports whether a rule without action skip outranking
+// compiled rule over (any rule when over is -1) may match a path below candidate.
+func (m *Matcher) decidesBelow(candidate string, over int, skip Action) bool {
+	for i := range m.compiled {
+		r := &m.compiled[i]
+		if r.source.Meta != nil || r.source.Action == skip {
+			continue
+		}
+
+		if over >= 0 && !m.outranks(i, over) {
+			continue
+		}
+
+		if r.mayMatchBelow(candidate) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// coversBelow reports whether r matches every path below candidate ("" for all paths).
+func (r *compiledRule) coversBelow(candidate string) bool {
+	if r.requireDir {
+		return false
+	}
+
+	// A bare "*" matches every basename.
+	if !r.hasSlash && r.searchRE == nil && r.componentGlob.text == "*" && !r.dirOnly {
+		return true
+	}
+
+	if candidate == "" {
+		return false
+	}
+
+	switch {
+	case r.searchRE != nil:
+		// Search rules match paths whose ancestor matches.
+		return r.matches(candidate, true)
+	case r.hasSlash && len(r.pathPrefixSegments) > 0:
+		// "prefix/**" matches below candidate when prefix ends within it.
+		return r.matches(candidate+"/", false)
+	case r.dirOnly:
+		// Directory rules match the contents of matched directories.
+		return r.matches(candidate, true)
+	}
+
+	return false
+}
+
+// mayMatchBelow reports whether r may match some path below candidate
+// ("" for any path). Only anchored path rules are ever ruled out.
+func (r *compiledRule) mayMatchBelow(candidate string) bool {
+	if candidate == "" || r.searchRE != nil || !r.hasSlash || !r.anchored {
+		return true
+	}
+
+	if r.foldCase {
+		candidate = asciiLower(candidate)
+	}
+
+	dirSegments := strings.Split(candidate, "/")
+	switch {
+	case r.pathExact != "":
+		if r.dirOnly && (r.pathExact == candidate || strings.HasPrefix(candidate, r.pathExact+"/")) {
+			return true
+		}
+
+		return strings.HasPrefix(r.pathExact, candidate+"/")
+	case len(r.pathPrefixSegments) > 0:
+		return segmentsMayExtend(r.pathPrefixSegments, dirSegments, true)
+	case len(r.pathSegments) > 0:
+		return segmentsMayExtend(r.pathSegments, dirSegments, r.dirOnly)
+	}
+
+	// Other anchored patterns are ruled out by their leading literal segments.
+	pattern := strings.TrimPrefix(r.source.Pattern, "/")
+	if r.foldCase || r.source.Syntax != PatternGlob {
+		return true
+	}
+
+	for i, seg := range strings.Split(pattern, "/") {
+		if i == len(dirSegments) || patternHasGlobMeta(seg) || strings.Contains(seg, "\\") {
+			return true
+		}
+
+		if !strings.EqualFold(seg, dirSegments[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// segmentsMayExtend reports whether anchored pattern segments may match a
+// path below the directory of dirSegments; open reports that a full match
+// of the pattern also matches everything below it.
+func segmentsMayExtend(pattern []segmentPattern, dirSegments []string, open bool) bool {
+	for i, seg := range dirSegments {
+		if i == len(pattern) {
+			return open
+		}
+
+		if !matchSegmentPattern(pattern[i], seg) {
+			return false
+		}
+	}
+
+	return len(pattern) > len(dirSegments) || open
+}
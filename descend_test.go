@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestMatcherShouldDescend(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name  string
+		rules string
+		opts  MatcherOptions
+		dirs  map[string]bool
+	}{
+		{
+			name:  "excluded subtree",
+			rules: "build/\n/out/**\nlogs\n",
+			dirs:  map[string]bool{"build": false, "a/build/x": false, "out": false, "out/sub": false, "logs": true, "src": true},
+		},
+		{
+			name:  "negation below",
+			rules: "build/\n!build/keep.txt\n",
+			dirs:  map[string]bool{"build": true, "x/build": true},
+		},
+		{
+			name:  "anchored negation",
+			rules: "build/\n!/build/keep.txt\n",
+			dirs:  map[string]bool{"build": true, "x/build": false, "build/sub": false},
+		},
+		{
+			name:  "negation before exclusion",
+			rules: "!keep.txt\nbuild/\n",
+			dirs:  map[string]bool{"build": false},
+		},
+		{
+			name:  "allowlist",
+			rules: "*\n!/src/**\n!*/\n",
+			dirs:  map[string]bool{"src": true, "docs": true, "": true},
+		},
+		{
+			name:  "default exclude",
+			rules: "!/src/**/*.go\n",
+			opts:  MatcherOptions{DefaultAction: ActionExclude},
+			dirs:  map[string]bool{"src": true, "src/pkg": true, "vendor": false},
+		},
+		{
+			name:  "git dialect",
+			rules: "build/\n!build/keep.txt\n",
+			opts:  MatcherOptions{Dialect: DialectGit},
+			dirs:  map[string]bool{"build": false, "src": true},
+		},
+	} {
+		m, err := NewMatcher(MustParseRulesString(tc.rules), tc.opts)
+		if err != nil {
+			t.Fatalf("%s: NewMatcher: %v", tc.name, err)
+		}
+
+		for dir, want := range tc.dirs {
+			if got := m.ShouldDescend(dir); got != want {
+				t.Fatalf("%s: ShouldDescend(%q)=%v, want %v", tc.name, dir, got, want)
+			}
+		}
+	}
+}
+
+func TestMatcherShouldDescendPriority(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionInclude, Pattern: "keep.txt", Priority: 1},
+		{Action: ActionExclude, Pattern: "build/"},
+	}
+
+	m, err := NewMatcher(rules, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if !m.ShouldDescend("build") {
+		t.Fatal("ShouldDescend(build)=false, want true for higher priority negation")
+	}
+}
+
+func TestProviderShouldDescend(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":       {Data: []byte("build/\ndist/\n")},
+		"dist/.pathrules":  {Data: []byte("!*.js\n")},
+		"cache/.pathrules": {Data: []byte("*\n")},
+	}
+
+	p, err := NewProviderFS(fsys, ProviderOptions{
+		BaseRules:  MustParseRulesString("!/build/keep/**\n"),
+		FinalRules: MustParseRulesString("!/cache/LICENSE\n"),
+	})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	for dir, want := range map[string]bool{
+		"":           true,
+		"src":        true,
+		"build":      false,
+		"build/keep": false,
+		"dist":       true,
+		"dist/sub":   true,
+		"cache":      true,
+		"cache/sub":  false,
+	} {
+		got, err := p.ShouldDescend(dir)
+		if err != nil {
+			t.Fatalf("ShouldDescend(%q): %v", dir, err)
+		}
+
+		if got != want {
+			t.Fatalf("ShouldDescend(%q)=%v, want %v", dir, got, want)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Severity is a diagnostic severity level.
+type Severity uint8
+
+const (
+	// SeverityWarning marks a pattern that compiles but likely does not do what was intended.
+	SeverityWarning Severity = iota
+	// SeverityError marks a pattern that is rejected by NewMatcher.
+	SeverityError
+)
+
+// DiagnosticCode is a stable machine-readable diagnostic identifier.
+type DiagnosticCode string
+
+const (
+	// DiagUnclosedCharClass reports "[" without matching "]"; it is matched as a literal byte.
+	DiagUnclosedCharClass DiagnosticCode = "unclosed-char-class"
+	// DiagRedundantDoubleStar reports "**" usage that does not change matching.
+	DiagRedundantDoubleStar DiagnosticCode = "redundant-double-star"
+	// DiagTrailingWhitespace reports trailing whitespace removed during normalization.
+	DiagTrailingWhitespace DiagnosticCode = "trailing-whitespace"
+	// DiagLeadingWhitespace reports leading whitespace removed during normalization.
+	DiagLeadingWhitespace DiagnosticCode = "leading-whitespace"
+	// DiagEmptyPattern reports a pattern that normalizes to empty.
+	DiagEmptyPattern DiagnosticCode = "empty-pattern"
+	// DiagInvalidAction reports unsupported rule action.
+	DiagInvalidAction DiagnosticCode = "invalid-action"
+	// DiagInvalidRegexp reports regexp-syntax pattern that does not compile.
+	DiagInvalidRegexp DiagnosticCode = "invalid-regexp"
+	// DiagUnsupportedSyntax reports rule that target export format cannot represent.
+	DiagUnsupportedSyntax DiagnosticCode = "unsupported-syntax"
+	// DiagParentExclusion reports include rule that export target may not honor
+	// because its parent directory is excluded.
+	DiagParentExclusion DiagnosticCode = "parent-exclusion"
+	// DiagDirOnlyDropped reports directory-only pattern exported without the restriction.
+	DiagDirOnlyDropped DiagnosticCode = "dir-only-dropped"
+	// DiagDuplicateRule reports rule repeating an earlier rule with the same action.
+	DiagDuplicateRule DiagnosticCode = "duplicate-rule"
+	// DiagShadowedRule reports rule that never wins because a later rule always matches too.
+	DiagShadowedRule DiagnosticCode = "shadowed-rule"
+	// DiagConflictingRule reports rule overridden by a later identical pattern with the opposite action.
+	DiagConflictingRule DiagnosticCode = "conflicting-rule"
+	// DiagDeadNegation reports include rule always overridden by a later exclude rule.
+	DiagDeadNegation DiagnosticCode = "dead-negation"
+	// DiagRedundantRule reports rule whose paths an earlier same-action rule already decides.
+	DiagRedundantRule DiagnosticCode = "redundant-rule"
+)
+
+// Diagnostic is one structured problem found in a pattern or rule.
+type Diagnostic struct {
+	// Code is a stable diagnostic identifier.
+	Code DiagnosticCode `json:"code" yaml:"code"`
+	// Message is a human-readable description.
+	Message string `json:"message" yaml:"message"`
+	// Pattern is the source pattern the diagnostic refers to.
+	Pattern string `json:"pattern" yaml:"pattern"`
+	// RuleIndex is the rule index for CheckRules and LintRules, -1 for ValidatePattern.
+	RuleIndex int `json:"rule_index" yaml:"rule_index"`
+	// Offset is the byte offset inside Pattern, -1 when not applicable.
+	Offset int `json:"offset" yaml:"offset"`
+	// Severity is diagnostic severity.
+	Severity Severity `json:"severity" yaml:"severity"`
+}
+
+// String returns severity name.
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("severity(%d)", uint8(s))
+	}
+}
+
+// MarshalText encodes severity as its name.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// String formats diagnostic as "severity: message (offset N)".
+func (d Diagnostic) String() string {
+	if d.Offset < 0 {
+		return d.Severity.String() + ": " + d.Message
+	}
+
+	return fmt.Sprintf("%s: %s (offset %d)", d.Severity, d.Message, d.Offset)
+}
+
+// ValidatePattern reports problems in one pattern without compiling a matcher.
+//
+// An empty result means no problems were found.
+func ValidatePattern(pattern string) []Diagnostic {
+	return validatePattern(pattern, -1)
+}
+
+// CheckRules reports problems for every rule in order.
+//
+// Returned diagnostics carry RuleIndex of the offending rule. Regexp-syntax
+// rules are only checked to compile.
+func CheckRules(rules []Rule) []Diagnostic {
+	var out []Diagnostic
+	for i := range rules {
+		if !rules[i].Action.compilable() {
+			out = append(out, Diagnostic{
+				Code:      DiagInvalidAction,
+				Message:   fmt.Sprintf("unsupported action %d", rules[i].Action),
+				Pattern:   rules[i].Pattern,
+				RuleIndex: i,
+				Offset:    -1,
+				Severity:  SeverityError,
+			})
+		}
+
+		if rules[i].Syntax == PatternRegexp {
+			if _, err := regexp.Compile(rules[i].Pattern); err != nil {
+				out = append(out, Diagnostic{
+					Code:      DiagInvalidRegexp,
+					Message:   err.Error(),
+					Pattern:   rules[i].Pattern,
+					RuleIndex: i,
+					Offset:    -1,
+					Severity:  SeverityError,
+				})
+			}
+
+			continue
+		}
+
+		out = append(out, validatePattern(rules[i].Pattern, i)...)
+	}
+
+	return out
+}
+
+// validatePattern collects diagnostics for one raw pattern.
+func validatePattern(raw string, ruleIndex int) []Diagnostic {
+	var out []Diagnostic
+	add := func(code DiagnosticCode, severity Severity, offset int, msg string) {
+		out = append(out, Diagnostic{
+			Code:      code,
+			Message:   msg,
+			Pattern:   raw,
+			RuleIndex: ruleIndex,
+			Offset:    offset,
+			Severity:  severity,
+		})
+	}
+
+	trimmedLeft := strings.TrimLeft(raw, " \t\r\n")
+	if len(trimmedLeft) < len(raw) && trimmedLeft != "" {
+		add(DiagLeadingWhitespace, SeverityWarning, 0, "leading whitespace is removed during normalization")
+	}
+
+	trimmed := strings.TrimRight(trimmedLeft, " \t\r\n")
+	if trail := len(trimmedLeft) - len(trimmed); trail > 0 && trimmed != "" {
+		add(DiagTrailingWhitespace, SeverityWarning, len(raw)-trail, "trailing whitespace is removed during normalization")
+	}
+
+	// Offsets below are reported against raw input, so shift by removed leading bytes.
+	base := len(raw) - len(trimmedLeft)
+	pattern := strings.ReplaceAll(trimmed, `\`, `/`)
+	if strings.Trim(pattern, "/") == "" {
+		add(DiagEmptyPattern, SeverityError, -1, "pattern is empty after normalization")
+		return out
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] == '[' && findCharClassEnd(pattern, i) < 0 {
+			add(DiagUnclosedCharClass, SeverityWarning, base+i, `unclosed "[" is matched literally`)
+			break
+		}
+	}
+
+	for _, off := range redundantDoubleStarOffsets(pattern) {
+		add(DiagRedundantDoubleStar, SeverityWarning, base+off, `redundant "**"`)
+	}
+
+	return out
+}
+
+// redundantDoubleStarOffsets returns offsets of "**" runs that do not change matching.
+//
+// Reported forms:
+//   - three or more consecutive stars ("***" behaves like "**")
+//   - repeated "**/**" segments
+//   - leading "**/" before a single component ("**/name" behaves like "name");
+//     "**/name/" is kept, as it also matches a file "name" and "name/" does not
+func redundantDoubleStarOffsets(pattern string) []int {
+	var out []int
+
+	for i := 0; i+2 < len(pattern); i++ {
+		if pattern[i] == '*' && pattern[i+1] == '*' && pattern[i+2] == '*' {
+			out = append(out, i)
+			for i < len(pattern) && pattern[i] == '*' {
+				i++
+			}
+		}
+	}
+
+	segments := strings.Split(pattern, "/")
+	offset := 0
+	for i := range segments {
+		if i > 0 && segments[i] == "**" && segments[i-1] == "**" {
+			out = append(out, offset)
+		}
+
+		offset += len(segments[i]) + 1
+	}
+
+	if rest, ok := strings.CutPrefix(pattern, "**/"); ok && rest != "" &&
+		!strings.Contains(rest, "/") && rest != "**" {
+		out = append(out, 0)
+	}
+
+	return out
+}
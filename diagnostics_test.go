@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "testing"
+
+func TestValidatePattern(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		pattern string
+		code    DiagnosticCode
+		offset  int
+	}{
+		{pattern: "file[0-2.txt", code: DiagUnclosedCharClass, offset: 4},
+		{pattern: "a/***/b", code: DiagRedundantDoubleStar, offset: 2},
+		{pattern: "a/**/**/b", code: DiagRedundantDoubleStar, offset: 5},
+		{pattern: "**/name", code: DiagRedundantDoubleStar, offset: 0},
+		{pattern: "name  ", code: DiagTrailingWhitespace, offset: 4},
+		{pattern: "  name", code: DiagLeadingWhitespace, offset: 0},
+		{pattern: "//", code: DiagEmptyPattern, offset: -1},
+	}
+
+	for _, tc := range cases {
+		got := ValidatePattern(tc.pattern)
+		if len(got) != 1 {
+			t.Fatalf("ValidatePattern(%q)=%+v, want one diagnostic", tc.pattern, got)
+		}
+
+		if got[0].Code != tc.code || got[0].Offset != tc.offset || got[0].RuleIndex != -1 {
+			t.Fatalf("ValidatePattern(%q)=%+v, want code=%s offset=%d", tc.pattern, got[0], tc.code, tc.offset)
+		}
+	}
+
+	for _, pattern := range []string{"*.tmp", "build/", "**/build/", "a/**/b", "assets/**", "file[0-2].txt"} {
+		if got := ValidatePattern(pattern); len(got) != 0 {
+			t.Fatalf("ValidatePattern(%q)=%+v, want none", pattern, got)
+		}
+	}
+}
+
+func TestCheckRules(t *testing.T) {
+	t.Parallel()
+
+	got := CheckRules([]Rule{
+		{Action: ActionExclude, Pattern: "*.tmp"},
+		{Action: ActionUnknown, Pattern: "/"},
+	})
+
+	if len(got) != 2 {
+		t.Fatalf("len(got)=%d, want 2: %+v", len(got), got)
+	}
+
+	if got[0].Code != DiagInvalidAction || got[0].RuleIndex != 1 || got[0].Severity != SeverityError {
+		t.Fatalf("got[0]=%+v", got[0])
+	}
+
+	if got[1].Code != DiagEmptyPattern || got[1].RuleIndex != 1 {
+		t.Fatalf("got[1]=%+v", got[1])
+	}
+}
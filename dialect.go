@@ -0,0 +1,406 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Dialect selects pattern and decision semantics.
+type Dialect uint8
+
+const (
+	// DialectDefault is pathrules semantics: slash patterns match at any depth
+	// and negations can re-include paths under excluded directories.
+	DialectDefault Dialect = iota
+	// DialectGit mirrors gitignore semantics:
+	//   - patterns with a leading or middle "/" are anchored to the rules root
+	//   - trailing "/" patterns match the directory itself, not its contents
+	//   - "\" escapes the next byte ("\*", "\[", "\ ")
+	//   - "**" is special only as a whole segment, otherwise it is "*"
+	//   - "[!...]", "[^...]" and POSIX "[[:class:]]" char classes
+	//   - a path cannot be re-included if a parent directory is excluded
+	DialectGit
+	// DialectRsync mirrors rsync filter pattern semantics:
+	//   - slash patterns match the trailing part of a path (as in DialectDefault)
+	//   - trailing "/" patterns match the directory itself, not its contents
+	//   - "dir/***" matches the directory and everything inside it
+	//   - a path cannot be re-included if a parent directory is excluded
+	//
+	// Rule order is still last-match-wins; ParseRsyncFilter reverses
+	// first-match-wins filter files accordingly.
+	DialectRsync
+	// DialectHg mirrors Mercurial hgignore semantics:
+	//   - glob patterns match at any depth (as in DialectDefault)
+	//   - a path is excluded when it or any parent directory matches
+	DialectHg
+	// DialectESLint mirrors legacy ".eslintignore" semantics: DialectGit
+	// patterns after implicit "node_modules/" and dot-file ignores, which
+	// user rules can re-include ("!.storybook").
+	DialectESLint
+	// DialectPrettier mirrors ".prettierignore" semantics: DialectGit patterns
+	// followed by implicit VCS directory and "node_modules" ignores that user
+	// rules cannot re-include.
+	DialectPrettier
+)
+
+// eslintImplicitRules are evaluated before user rules with DialectESLint.
+var eslintImplicitRules = []Rule{
+	{Action: ActionExclude, Pattern: "node_modules/"},
+	{Action: ActionExclude, Pattern: ".*"},
+	{Action: ActionInclude, Pattern: ".eslintrc.*"},
+}
+
+// prettierImplicitRules are evaluated after user rules with DialectPrettier.
+var prettierImplicitRules = []Rule{
+	{Action: ActionExclude, Pattern: ".git/"},
+	{Action: ActionExclude, Pattern: ".hg/"},
+	{Action: ActionExclude, Pattern: ".jj/"},
+	{Action: ActionExclude, Pattern: ".sl/"},
+	{Action: ActionExclude, Pattern: ".svn/"},
+	{Action: ActionExclude, Pattern: "node_modules/"},
+}
+
+// String returns dialect name.
+func (d Dialect) String() string {
+	switch d {
+	case DialectDefault:
+		return "default"
+	case DialectGit:
+		return "git"
+	case DialectRsync:
+		return "rsync"
+	case DialectHg:
+		return "hg"
+	case DialectESLint:
+		return "eslint"
+	case DialectPrettier:
+		return "prettier"
+	default:
+		return fmt.Sprintf("dialect(%d)", uint8(d))
+	}
+}
+
+// valid reports whether dialect value is supported.
+func (d Dialect) valid() bool {
+	return d <= DialectPrettier
+}
+
+// parentExclusion reports whether excluded parent directories block re-inclusion.
+func (d Dialect) parentExclusion() bool {
+	return d != DialectDefault
+}
+
+// implicitRules returns dialect default rules evaluated before and after user rules.
+func (d Dialect) implicitRules() ([]Rule, []Rule) {
+	switch d {
+	case DialectESLint:
+		return eslintImplicitRules, nil
+	case DialectPrettier:
+		return nil, prettierImplicitRules
+	default:
+		return nil, nil
+	}
+}
+
+// compileRuleWithOptions compiles one rule using dialect-specific pattern semantics.
+func compileRuleWithOptions(rule Rule, opts *MatcherOptions) (*compiledRule, error) {
+	if err := checkPatternLimits(rule, opts); err != nil {
+		return nil, err
+	}
+
+	// Smart case folds rules without uppercase letters; matches lowers candidates for them.
+	foldCase := opts.SmartCase && !opts.CaseInsensitive && !hasASCIIUpper(rule.Pattern)
+	cr, err := compileDialectRule(rule, opts.Dialect, opts.CaseInsensitive || foldCase, opts.PathSeparators == PathSeparatorPOSIX, opts.MaxRegexpSize)
+	if err != nil {
+		return nil, err
+	}
+
+	cr.foldCase = foldCase
+	return cr, nil
+}
+
+// compileDialectRule compiles one rule with dialect pattern semantics.
+//
+// With backslashEscapes "\" escapes the next pattern byte instead of being a
+// separator; the git dialect always treats it so. Generated regexps longer
+// than maxRegexpSize (0 disables) are rejected before they are compiled.
+func compileDialectRule(rule Rule, dialect Dialect, caseInsensitive, backslashEscapes bool, maxRegexpSize int) (*compiledRule, error) {
+	if rule.Syntax != PatternGlob {
+		return compileSyntaxRule(rule, caseInsensitive, maxRegexpSize)
+	}
+
+	switch dialect {
+	case DialectGit, DialectESLint, DialectPrettier:
+		return compileGitRule(rule, caseInsensitive, maxRegexpSize)
+	case DialectRsync:
+		return compileRsyncRule(rule, caseInsensitive, backslashEscapes, maxRegexpSize)
+	default:
+		return compileRule(rule, caseInsensitive, backslashEscapes, maxRegexpSize)
+	}
+}
+
+// compileRsyncRule compiles one rule with rsync filter pattern semantics.
+func compileRsyncRule(rule Rule, caseInsensitive, backslashEscapes bool, maxRegexpSize int) (*compiledRule, error) {
+	pattern := strings.TrimSpace(rule.Pattern)
+	if !backslashEscapes {
+		pattern = normalizePattern(pattern)
+	}
+
+	requireDir := false
+	if prefix, ok := strings.CutSuffix(pattern, "/***"); ok && prefix != "" && prefix != "/" {
+		// Pathrules directory rules already cover the directory and its contents.
+		pattern = prefix + "/"
+	} else if trimmed, ok := strings.CutSuffix(pattern, "/"); ok {
+		pattern = trimmed
+		requireDir = true
+	}
+
+	source := rule
+	source.Pattern = pattern
+	cr, err := compileRule(source, caseInsensitive, backslashEscapes, maxRegexpSize)
+	if err != nil {
+		return nil, err
+	}
+
+	cr.source = rule
+	cr.requireDir = requireDir
+	return cr, nil
+}
+
+// compileGitRule compiles one rule with gitignore pattern semantics.
+//
+// Plain patterns are rewritten to pathrules form (explicit anchoring) to keep
+// fast matching strategies; escapes and git-only constructs use regexp.
+func compileGitRule(rule Rule, caseInsensitive bool, maxRegexpSize int) (*compiledRule, error) {
+	if !rule.Action.compilable() {
+		return nil, fmt.Errorf("%w: unsupported action %d", ErrInvalidRule, rule.Action)
+	}
+
+	pattern := rule.Pattern
+	if caseInsensitive {
+		pattern = asciiLower(pattern)
+	}
+
+	dirOnly := strings.HasSuffix(pattern, "/") && !strings.HasSuffix(pattern, `\/`)
+	body := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(body, "/")
+	body = strings.TrimPrefix(body, "/")
+	if body == "" {
+		return nil, fmt.Errorf("%w: empty after normalization (%q)", ErrInvalidPattern, rule.Pattern)
+	}
+
+	if !gitPatternNeedsRegexp(body) {
+		rewritten := body
+		if anchored {
+			rewritten = "/" + rewritten
+		}
+
+		source := rule
+		source.Pattern = rewritten
+		cr, err := compileRule(source, false, false, maxRegexpSize)
+		if err != nil {
+			return nil, err
+		}
+
+		cr.source = rule
+		cr.requireDir = dirOnly
+		return cr, nil
+	}
+
+	// Git directory patterns match the directory itself only; its contents
+	// are excluded through parent exclusion.
+	cr := &compiledRule{
+		source:     rule,
+		anchored:   anchored,
+		hasSlash:   anchored,
+		requireDir: dirOnly,
+	}
+
+	expr := "^" + gitGlobToRegex(body) + "$"
+	if err := checkRegexpSize(rule.Pattern, expr, maxRegexpSize); err != nil {
+		return nil, err
+	}
+
+	if !cr.hasSlash {
+		re, err := compileRegexp(expr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: compile component %q: %v", ErrInvalidPattern, rule.Pattern, err)
+		}
+
+		cr.componentRE = re
+		return cr, nil
+	}
+
+	re, err := compileRegexp(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: compile path pattern %q: %v", ErrInvalidPattern, rule.Pattern, err)
+	}
+
+	cr.pathRE = re
+	return cr, nil
+}
+
+// gitPatternNeedsRegexp reports whether git pattern semantics differ from
+// pathrules semantics for this anchoring-normalized body.
+func gitPatternNeedsRegexp(body string) bool {
+	if strings.ContainsAny(body, "\\ \t") {
+		return true
+	}
+
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '[':
+			end := findCharClassEnd(body, i)
+			if end < 0 {
+				continue
+			}
+
+			class := body[i:end]
+			if strings.HasPrefix(class, "[^") || strings.Contains(class, "[:") {
+				return true
+			}
+
+			i = end
+		case '*':
+			if i+1 >= len(body) || body[i+1] != '*' {
+				continue
+			}
+
+			// "**" keeps pathrules meaning only as a whole segment.
+			start := i
+			for i < len(body) && body[i] == '*' {
+				i++
+			}
+
+			if i-start != 2 ||
+				(start > 0 && body[start-1] != '/') ||
+				(i < len(body) && body[i] != '/') {
+				return true
+			}
+
+			i--
+		}
+	}
+
+	return false
+}
+
+// gitGlobToRegex converts a gitignore pattern body (without anchoring and
+// trailing "/") to regexp source.
+func gitGlobToRegex(pat string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(pat); i++ {
+		c := pat[i]
+		switch c {
+		case '\\':
+			if i+1 < len(pat) {
+				i++
+				b.WriteString(regexp.QuoteMeta(pat[i : i+1]))
+				continue
+			}
+
+			b.WriteString(`\\`)
+		case '*':
+			start := i
+			for i+1 < len(pat) && pat[i+1] == '*' {
+				i++
+			}
+
+			segStart := start == 0 || pat[start-1] == '/'
+			segEnd := i+1 == len(pat) || pat[i+1] == '/'
+			if i-start == 1 && segStart && segEnd {
+				if i+1 < len(pat) {
+					// "**/" matches zero or more directories.
+					b.WriteString(`(?:.*/)?`)
+					i++
+					continue
+				}
+
+				b.WriteString(`.*`)
+				continue
+			}
+
+			b.WriteString(`[^/]*`)
+		case '?':
+			b.WriteString(`[^/]`)
+		case '[':
+			if next, ok := appendGitCharClass(pat, i, &b); ok {
+				i = next
+				continue
+			}
+
+			b.WriteString(`\[`)
+		default:
+			b.WriteString(regexp.QuoteMeta(pat[i : i+1]))
+		}
+	}
+
+	return b.String()
+}
+
+// appendGitCharClass appends git char class starting at pat[start] == '['.
+//
+// Supports "!"/"^" negation, leading "]", ranges, "\" escapes and POSIX
+// "[:name:]" classes. Negated classes never match "/".
+func appendGitCharClass(pat string, start int, b *strings.Builder) (int, bool) {
+	idx := start + 1
+	negate := false
+	if idx < len(pat) && (pat[idx] == '!' || pat[idx] == '^') {
+		negate = true
+		idx++
+	}
+
+	var body strings.Builder
+	first := true
+	for ; idx < len(pat); idx++ {
+		c := pat[idx]
+		if c == ']' && !first {
+			b.WriteByte('[')
+			if negate {
+				b.WriteString(`^/`)
+			}
+
+			b.WriteString(body.String())
+			b.WriteByte(']')
+			return idx, true
+		}
+
+		first = false
+		switch {
+		case c == '[' && idx+1 < len(pat) && pat[idx+1] == ':':
+			end := strings.Index(pat[idx+2:], ":]")
+			if end < 0 {
+				body.WriteString(`\[`)
+				continue
+			}
+
+			body.WriteString(pat[idx : idx+2+end+2])
+			idx += 2 + end + 1
+		case c == '\\' && idx+1 < len(pat):
+			idx++
+			body.WriteString(escapeClassByte(pat[idx]))
+		case c == '-' && body.Len() > 0 && idx+1 < len(pat) && pat[idx+1] != ']':
+			body.WriteByte('-')
+		default:
+			body.WriteString(escapeClassByte(c))
+		}
+	}
+
+	return start, false
+}
+
+// escapeClassByte escapes one byte for literal use inside regexp char class.
+func escapeClassByte(c byte) string {
+	switch c {
+	case '\\', ']', '[', '^', '-':
+		return `\` + string(c)
+	default:
+		return string(c)
+	}
+}
@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"testing"
+)
+
+// gitConformanceCase is one expectation verified against `git check-ignore` semantics.
+type gitConformanceCase struct {
+	rules   string
+	path    string
+	isDir   bool
+	ignored bool
+}
+
+func TestDialectGitConformance(t *testing.T) {
+	t.Parallel()
+
+	cases := []gitConformanceCase{
+		// Basename patterns match at any depth and exclude directory contents.
+		{rules: "foo", path: "foo", ignored: true},
+		{rules: "foo", path: "a/foo", ignored: true},
+		{rules: "foo", path: "foo/bar", ignored: true},
+		{rules: "foo", path: "foobar", ignored: false},
+		{rules: "/foo", path: "foo", ignored: true},
+		{rules: "/foo", path: "a/foo", ignored: false},
+
+		// Trailing slash matches directories only.
+		{rules: "foo/", path: "foo", isDir: true, ignored: true},
+		{rules: "foo/", path: "foo", ignored: false},
+		{rules: "foo/", path: "a/foo", isDir: true, ignored: true},
+		{rules: "foo/", path: "foo/x", ignored: true},
+
+		// Middle slash anchors the pattern to the rules root.
+		{rules: "doc/frotz", path: "doc/frotz", ignored: true},
+		{rules: "doc/frotz", path: "a/doc/frotz", ignored: false},
+		{rules: "doc/frotz/", path: "doc/frotz", isDir: true, ignored: true},
+		{rules: "doc/frotz/", path: "a/doc/frotz", isDir: true, ignored: false},
+		{rules: "d/*.txt", path: "d/a.txt", ignored: true},
+		{rules: "d/*.txt", path: "d/e/a.txt", ignored: false},
+		{rules: "d/*.txt", path: "x/d/a.txt", ignored: false},
+		{rules: "*.txt", path: "d/e/a.txt", ignored: true},
+
+		// "**" forms.
+		{rules: "**/foo", path: "foo", ignored: true},
+		{rules: "**/foo", path: "a/b/foo", ignored: true},
+		{rules: "**/foo/bar", path: "a/foo/bar", ignored: true},
+		{rules: "abc/**", path: "abc/x", ignored: true},
+		{rules: "abc/**", path: "abc/x/y", ignored: true},
+		{rules: "abc/**", path: "abc", isDir: true, ignored: false},
+		{rules: "a/**/b", path: "a/b", ignored: true},
+		{rules: "a/**/b", path: "a/x/y/b", ignored: true},
+		{rules: "a/**/b", path: "x/a/b", ignored: false},
+		{rules: "a/foo**bar", path: "a/fooXbar", ignored: true},
+		{rules: "a/foo**bar", path: "a/foo/bar", ignored: false},
+		{rules: "foo***", path: "foobar", ignored: true},
+
+		// Escapes and char classes.
+		{rules: `\*.txt`, path: "*.txt", ignored: true},
+		{rules: `\*.txt`, path: "a.txt", ignored: false},
+		{rules: `a\?`, path: "a?", ignored: true},
+		{rules: `a\?`, path: "ab", ignored: false},
+		{rules: `\[x]`, path: "[x]", ignored: true},
+		{rules: `\#foo`, path: "#foo", ignored: true},
+		{rules: "[!a]*.txt", path: "b.txt", ignored: true},
+		{rules: "[!a]*.txt", path: "a.txt", ignored: false},
+		{rules: "[^a]*.txt", path: "b.txt", ignored: true},
+		{rules: "[^a]*.txt", path: "a.txt", ignored: false},
+		{rules: "file[[:digit:]].txt", path: "file1.txt", ignored: true},
+		{rules: "file[[:digit:]].txt", path: "filex.txt", ignored: false},
+		{rules: "d/[^x]", path: "d/y", ignored: true},
+		{rules: "a?c", path: "abc", ignored: true},
+		{rules: "a?c", path: "a/c", ignored: false},
+
+		// Parent directory exclusion blocks re-inclusion.
+		{rules: "build/\n!build/keep.txt", path: "build/keep.txt", ignored: true},
+		{rules: "build\n!build/keep.txt", path: "build/keep.txt", ignored: true},
+		{rules: "build/*\n!build/keep.txt", path: "build/keep.txt", ignored: false},
+		{rules: "*\n!*/\n!*.c", path: "src/main.c", ignored: false},
+		{rules: "*\n!*/\n!*.c", path: "src/readme.md", ignored: true},
+		{rules: "*\n!*.c", path: "src/main.c", ignored: true},
+		{rules: "/*\n!/foo\n/foo/*\n!/foo/bar", path: "foo/bar", ignored: false},
+		{rules: "/*\n!/foo\n/foo/*\n!/foo/bar", path: "foo/baz", ignored: true},
+		{rules: "/*\n!/foo\n/foo/*\n!/foo/bar", path: "x", ignored: true},
+		{rules: "/*\n!/foo\n/foo/*\n!/foo/bar", path: "foo", isDir: true, ignored: false},
+	}
+
+	for _, tc := range cases {
+		rules, err := ParseRulesString(tc.rules)
+		if err != nil {
+			t.Fatalf("ParseRulesString(%q): %v", tc.rules, err)
+		}
+
+		m, err := NewMatcher(rules, MatcherOptions{Dialect: DialectGit})
+		if err != nil {
+			t.Fatalf("NewMatcher(%q): %v", tc.rules, err)
+		}
+
+		if got := m.Excluded(tc.path, tc.isDir); got != tc.ignored {
+			t.Errorf("rules %q: Excluded(%q, dir=%v)=%v, want %v", tc.rules, tc.path, tc.isDir, got, tc.ignored)
+		}
+	}
+}
+
+func TestDialectGitRuleIndexFromParent(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher([]Rule{
+		{Action: ActionExclude, Pattern: "build/"},
+		{Action: ActionInclude, Pattern: "*.txt"},
+	}, MatcherOptions{Dialect: DialectGit})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	got := m.Decide("build/keep.txt", false)
+	if got.Included || !got.Matched || got.RuleIndex != 0 {
+		t.Fatalf("Decide=%+v, want excluded by rule 0", got)
+	}
+
+	// Default dialect keeps pathrules re-inclusion semantics.
+	def, err := NewMatcher([]Rule{
+		{Action: ActionExclude, Pattern: "build/"},
+		{Action: ActionInclude, Pattern: "*.txt"},
+	}, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if !def.Included("build/keep.txt", false) {
+		t.Fatalf("default dialect must re-include build/keep.txt")
+	}
+}
+
+func TestNewMatcherRejectsUnknownDialect(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewMatcher(nil, MatcherOptions{Dialect: Dialect(200)})
+	if !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("err=%v, want ErrInvalidOptions", err)
+	}
+}
+
+func TestDialectESLintImplicitRules(t *testing.T) {
+	t.Parallel()
+
+	rules, err := ParseRulesString("dist\n!.storybook\n")
+	if err != nil {
+		t.Fatalf("ParseRulesString: %v", err)
+	}
+
+	m, err := NewMatcher(rules, MatcherOptions{Dialect: DialectESLint})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	cases := map[string]bool{
+		"src/index.js":          false,
+		"node_modules/a/b.js":   true,
+		"pkg/node_modules/x.js": true,
+		".cache/x.js":           true,
+		"src/.hidden.js":        true,
+		".eslintrc.js":          false,
+		".storybook/main.js":    false,
+		"dist/app.js":           true,
+	}
+
+	for path, ignored := range cases {
+		if got := m.Excluded(path, false); got != ignored {
+			t.Errorf("Excluded(%q)=%v, want %v", path, got, ignored)
+		}
+	}
+
+	if res := m.Decide("node_modules/a.js", false); !res.Matched || res.RuleIndex != -1 {
+		t.Fatalf("implicit match result=%+v, want Matched with RuleIndex -1", res)
+	}
+
+	if res := m.Decide("dist/app.js", false); res.RuleIndex != 0 {
+		t.Fatalf("user match result=%+v, want RuleIndex 0", res)
+	}
+}
+
+func TestDialectPrettierImplicitRules(t *testing.T) {
+	t.Parallel()
+
+	rules, err := ParseRulesString("*.min.js\n!node_modules/keep.js\n")
+	if err != nil {
+		t.Fatalf("ParseRulesString: %v", err)
+	}
+
+	m, err := NewMatcher(rules, MatcherOptions{Dialect: DialectPrettier})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	cases := map[string]bool{
+		"src/a.js":             false,
+		"src/a.min.js":         true,
+		".git/config":          true,
+		"a/.svn/entries":       true,
+		"node_modules/keep.js": true,
+		".prettierrc":          false,
+	}
+
+	for path, ignored := range cases {
+		if got := m.Excluded(path, false); got != ignored {
+			t.Errorf("Excluded(%q)=%v, want %v", path, got, ignored)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"strings"
+)
+
+const pragmaPrefix = "#pragma"
+
+// Directives are matcher option overrides declared in a rules file header.
+//
+// Supported header lines (several directives may share one line):
+//   - "#pragma case-insensitive" / "#pragma case-sensitive"
+//   - "#pragma default=include" / "#pragma default=exclude"
+type Directives struct {
+	// CaseInsensitive overrides MatcherOptions.CaseInsensitive when non-nil.
+	CaseInsensitive *bool `json:"case_insensitive,omitempty" yaml:"case_insensitive,omitempty"`
+	// DefaultAction overrides MatcherOptions.DefaultAction when set.
+	DefaultAction Action `json:"default_action,omitempty" yaml:"default_action,omitempty"`
+}
+
+// RuleSet is parsed rules source content with its header directives.
+type RuleSet struct {
+	// Rules are parsed rules in source order.
+	Rules []Rule `json:"rules" yaml:"rules"`
+	// Directives are header overrides for matcher options.
+	Directives Directives `json:"directives" yaml:"directives"`
+}
+
+// IsZero reports whether no directive was declared.
+func (d Directives) IsZero() bool {
+	return d.CaseInsensitive == nil && !d.DefaultAction.valid()
+}
+
+// Apply returns opts with declared directives applied on top.
+func (d Directives) Apply(opts MatcherOptions) MatcherOptions {
+	if d.CaseInsensitive != nil {
+		opts.CaseInsensitive = *d.CaseInsensitive
+	}
+
+	if d.DefaultAction.valid() {
+		opts.DefaultAction = d.DefaultAction
+	}
+
+	return opts
+}
+
+// NewMatcher compiles rule set rules with directives applied over opts.
+func (rs *RuleSet) NewMatcher(opts MatcherOptions) (*Matcher, error) {
+	return NewMatcher(rs.Rules, rs.Directives.Apply(opts))
+}
+
+// parsePragma applies one "#pragma" comment line; other comments are ignored.
+//
+// Unknown pragma names (such as "#pragma once" meant for other tools) are
+// comments unless strict is set. Known names with invalid values always fail.
+func (d *Directives) parsePragma(line string, strict bool) error {
+	rest, ok := strings.CutPrefix(line, pragmaPrefix)
+	if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+		return nil
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		if !strict {
+			return nil
+		}
+
+		return fmt.Errorf("%w: empty pragma", ErrInvalidDirective)
+	}
+
+	for _, field := range fields {
+		name, value, hasValue := strings.Cut(asciiLower(field), "=")
+		switch {
+		case name == "case-insensitive" && !hasValue:
+			v := true
+			d.CaseInsensitive = &v
+		case name == "case-sensitive" && !hasValue:
+			v := false
+			d.CaseInsensitive = &v
+		case name == "default" && value == "include":
+			d.DefaultAction = ActionInclude
+		case name == "default" && value == "exclude":
+			d.DefaultAction = ActionExclude
+		case name == "case-insensitive" || name == "case-sensitive" || name == "default":
+			return fmt.Errorf("%w: invalid pragma %q", ErrInvalidDirective, field)
+		case strict:
+			return fmt.Errorf("%w: unsupported pragma %q", ErrInvalidDirective, field)
+		}
+	}
+
+	return nil
+}
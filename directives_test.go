@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseRuleSetDirectives(t *testing.T) {
+	t.Parallel()
+
+	rs, err := ParseRuleSet(strings.NewReader(`# generated
+#pragma case-insensitive
+#pragma default=exclude
+!*.CPP
+#pragma case-sensitive
+`), ParseOptions{})
+	if err != nil {
+		t.Fatalf("ParseRuleSet: %v", err)
+	}
+
+	if rs.Directives.CaseInsensitive == nil || !*rs.Directives.CaseInsensitive {
+		t.Fatalf("CaseInsensitive=%v, want true (late pragma is a comment)", rs.Directives.CaseInsensitive)
+	}
+
+	if rs.Directives.DefaultAction != ActionExclude {
+		t.Fatalf("DefaultAction=%d, want ActionExclude", rs.Directives.DefaultAction)
+	}
+
+	m, err := rs.NewMatcher(MatcherOptions{DefaultAction: ActionInclude})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if !m.Included("src/main.cpp", false) {
+		t.Fatalf("src/main.cpp must be included case-insensitively")
+	}
+
+	if m.Included("README.md", false) {
+		t.Fatalf("README.md must be excluded by directive default")
+	}
+}
+
+func TestParseRuleSetRejectsInvalidPragma(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseRuleSet(strings.NewReader("#pragma case-insensitive default=maybe\n*.tmp\n"), ParseOptions{})
+	if !errors.Is(err, ErrInvalidDirective) {
+		t.Fatalf("err=%v, want ErrInvalidDirective", err)
+	}
+
+	// Unknown pragmas are comments for other tools unless parsing is strict.
+	rs, err := ParseRuleSet(strings.NewReader("#pragma once case-insensitive\n#pragma\n*.tmp\n"), ParseOptions{})
+	if err != nil || len(rs.Rules) != 1 || rs.Directives.CaseInsensitive == nil {
+		t.Fatalf("lenient rs=%+v err=%v, want one rule and case-insensitive", rs, err)
+	}
+
+	if _, err := ParseRuleSet(strings.NewReader("#pragma once\n*.tmp\n"), ParseOptions{Strict: true}); !errors.Is(err, ErrInvalidDirective) {
+		t.Fatalf("strict err=%v, want ErrInvalidDirective", err)
+	}
+
+	// "#pragmatic" is not a directive, only a comment.
+	if _, err := ParseRuleSet(strings.NewReader("#pragmatic\n"), ParseOptions{}); err != nil {
+		t.Fatalf("err=%v, want nil", err)
+	}
+}
@@ -4,7 +4,11 @@
 
 package pathrules
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // Sentinel errors for pathrules operations.
 var (
@@ -12,6 +16,12 @@ var (
 	ErrInvalidRule = errors.New("invalid rule")
 	// ErrInvalidPattern indicates malformed or unsupported rule pattern.
 	ErrInvalidPattern = errors.New("invalid pattern")
+	// ErrPatternTooComplex indicates a pattern exceeding MatcherOptions complexity limits.
+	ErrPatternTooComplex = errors.New("pattern too complex")
+	// ErrInvalidOptions indicates unsupported matcher or provider option values.
+	ErrInvalidOptions = errors.New("invalid options")
+	// ErrInvalidDirective indicates malformed or unknown "#pragma" directive.
+	ErrInvalidDirective = errors.New("invalid directive")
 	// ErrInvalidRulesFileName indicates invalid provider rules file name.
 	ErrInvalidRulesFileName = errors.New("invalid rules file name")
 	// ErrInvalidEntryName indicates invalid directory entry input for batch APIs.
@@ -20,6 +30,121 @@ var (
 	ErrNilProvider = errors.New("provider is nil")
 	// ErrPathOutsideRoot indicates path traversal or non-relative input path.
 	ErrPathOutsideRoot = errors.New("path is outside provider root")
+	// ErrInvalidPath indicates a candidate path rejected by MatcherOptions.StrictPaths.
+	ErrInvalidPath = errors.New("invalid candidate path")
+	// ErrInvalidMatcherData indicates malformed serialized matcher data.
+	ErrInvalidMatcherData = errors.New("invalid matcher data")
+	// ErrUnsafeName indicates a path component Windows cannot store as given.
+	ErrUnsafeName = errors.New("unsafe path name")
+	// ErrRulesFileTooLarge indicates a rules file above ProviderOptions.MaxRulesFileSize.
+	ErrRulesFileTooLarge = errors.New("rules file too large")
+	// ErrTooManyRules indicates a rules file above ProviderOptions.MaxRulesPerFile.
+	ErrTooManyRules = errors.New("too many rules in file")
+	// ErrHierarchyTooDeep indicates a directory below ProviderOptions.MaxHierarchyDepth.
+	ErrHierarchyTooDeep = errors.New("directory hierarchy too deep")
+	// ErrRemoteRulesStale indicates RemoteRules served a cached or fallback copy.
+	ErrRemoteRulesStale = errors.New("remote rules are stale")
 	// ErrRulesPathOutsideRoot indicates resolved rules file path escaped provider root.
 	ErrRulesPathOutsideRoot = errors.New("rules file path is outside provider root")
+	// ErrUnknownMediaType indicates a media type or category without known extensions.
+	ErrUnknownMediaType = errors.New("unknown media type")
 )
+
+// RuleError describes one invalid rule with its source location.
+//
+// It unwraps to the underlying cause, so errors.Is works against sentinels
+// such as ErrInvalidPattern.
+type RuleError struct {
+	// Err is the underlying cause.
+	Err error
+	// File is the rules source name, empty for in-memory input.
+	File string
+	// Pattern is the offending pattern text.
+	Pattern string
+	// Line is the 1-based source line, 0 when unknown.
+	Line int
+}
+
+// LoadError describes a rules file (or ProviderOptions.RulesSource) load
+// failure of one Provider directory.
+//
+// It unwraps to the underlying cause, so errors.Is works against sentinels
+// such as ErrInvalidDirective and errors.As finds a *RuleError or
+// *ParseError with the offending line.
+type LoadError struct {
+	// Err is the underlying cause; its message already names the file.
+	Err error
+	// Dir is the directory relative to provider root, "" for root;
+	// ancestors above root (ProviderOptions.ScanAncestors) use "../" elements.
+	Dir string
+	// Path is the rules file path as used in Rule.Source, empty for
+	// ProviderOptions.RulesSource.
+	Path string
+}
+
+// Error returns the cause message.
+func (e *LoadError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying cause.
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// ParseError aggregates every invalid line found while parsing one source.
+type ParseError struct {
+	// Errors lists invalid rules in source order.
+	Errors []*RuleError
+}
+
+// Error formats rule error as "file:line: pattern: cause".
+func (e *RuleError) Error() string {
+	var b strings.Builder
+	if e.File != "" {
+		b.WriteString(e.File)
+		b.WriteByte(':')
+	}
+
+	if e.Line > 0 {
+		fmt.Fprintf(&b, "%d:", e.Line)
+	}
+
+	if b.Len() > 0 {
+		b.WriteByte(' ')
+	}
+
+	fmt.Fprintf(&b, "%q: %v", e.Pattern, e.Err)
+	return b.String()
+}
+
+// Unwrap returns the underlying cause.
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
+
+// Error formats all aggregated line errors, one per line.
+func (e *ParseError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d invalid rules:", len(e.Errors))
+	for _, err := range e.Errors {
+		b.WriteString("\n\t")
+		b.WriteString(err.Error())
+	}
+
+	return b.String()
+}
+
+// Unwrap returns aggregated line errors for errors.Is/errors.As traversal.
+func (e *ParseError) Unwrap() []error {
+	out := make([]error, len(e.Errors))
+	for i := range e.Errors {
+		out[i] = e.Errors[i]
+	}
+
+	return out
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestNewMatcherRuleError(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewMatcher([]Rule{
+		{Action: ActionExclude, Pattern: "*.tmp", Line: 1},
+		{Action: ActionExclude, Pattern: "/", Source: "policy.rules", Line: 2},
+	}, MatcherOptions{})
+
+	var ruleErr *RuleError
+	if !errors.As(err, &ruleErr) || !errors.Is(err, ErrInvalidPattern) {
+		t.Fatalf("err=%v, want *RuleError wrapping ErrInvalidPattern", err)
+	}
+
+	if ruleErr.File != "policy.rules" || ruleErr.Line != 2 || ruleErr.Pattern != "/" {
+		t.Fatalf("RuleError=%+v, want policy.rules:2 \"/\"", ruleErr)
+	}
+}
+
+func TestProviderLoadError(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, "src", ".pathrules"), "*.tmp\n/\n")
+
+	p, err := NewProvider(root, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	_, err = p.Decide("src/a.txt", false)
+
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) || !errors.Is(err, ErrInvalidPattern) {
+		t.Fatalf("err=%v, want *LoadError wrapping ErrInvalidPattern", err)
+	}
+
+	if want := filepath.Join(root, "src", ".pathrules"); loadErr.Dir != "src" || loadErr.Path != want {
+		t.Fatalf("LoadError=%+v, want dir src and path %s", loadErr, want)
+	}
+
+	var ruleErr *RuleError
+	if !errors.As(err, &ruleErr) || ruleErr.Line != 2 || ruleErr.Pattern != "/" {
+		t.Fatalf("RuleError=%+v, want line 2 pattern \"/\"", ruleErr)
+	}
+}
+
+func TestProviderLoadErrorRulesSource(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewProviderFS(fstest.MapFS{}, ProviderOptions{
+		RulesSource: MapRulesSource{"": {{Action: ActionExclude, Pattern: "/"}}},
+	})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	_, err = p.Decide("a.txt", false)
+
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) || loadErr.Dir != "" || loadErr.Path != "" {
+		t.Fatalf("err=%v LoadError=%+v, want root source load error without path", err, loadErr)
+	}
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "strings"
+
+// EvalOptions narrows one Provider decision, mostly for diagnostics such as
+// "would directory rules alone exclude this?".
+type EvalOptions struct {
+	// SkipBaseRules ignores ProviderOptions.BaseRules.
+	SkipBaseRules bool `json:"skip_base_rules,omitempty" yaml:"skip_base_rules,omitempty"`
+	// SkipFinalRules ignores ProviderOptions.FinalRules.
+	SkipFinalRules bool `json:"skip_final_rules,omitempty" yaml:"skip_final_rules,omitempty"`
+	// OnlyDirRules evaluates only the rules file of the path's own
+	// directory, ignoring BaseRules, FinalRules and rules files above it.
+	OnlyDirRules bool `json:"only_dir_rules,omitempty" yaml:"only_dir_rules,omitempty"`
+	// StopAtDepth, when positive, evaluates rules files of only that many
+	// directory levels from root down: 1 is the root rules file alone.
+	// Rules files above root (ScanAncestors) count as root level.
+	StopAtDepth int `json:"stop_at_depth,omitempty" yaml:"stop_at_depth,omitempty"`
+}
+
+// DecideWith is Decide narrowed by opts. Rules files skipped by opts are
+// not loaded, so their load errors do not surface either.
+func (p *Provider) DecideWith(relPath string, isDir bool, opts EvalOptions) (MatchResult, error) {
+	if p == nil {
+		return MatchResult{}, ErrNilProvider
+	}
+
+	view := *p
+	view.eval = &opts
+	return view.Decide(relPath, isDir)
+}
+
+// skipsBase reports whether BaseRules are ignored.
+func (o *EvalOptions) skipsBase() bool {
+	return o != nil && (o.SkipBaseRules || o.OnlyDirRules)
+}
+
+// skipsFinal reports whether FinalRules are ignored.
+func (o *EvalOptions) skipsFinal() bool {
+	return o != nil && (o.SkipFinalRules || o.OnlyDirRules)
+}
+
+// skipsDir reports whether the rules file of relDir is ignored for normalized.
+func (o *EvalOptions) skipsDir(relDir, normalized string) bool {
+	return o != nil && o.skipsLevel(relDir, pathDir(normalized, false))
+}
+
+// skipsLevel reports whether the rules file of relDir is ignored for paths
+// in directory dir.
+func (o *EvalOptions) skipsLevel(relDir, dir string) bool {
+	if o == nil {
+		return false
+	}
+
+	if relDir == ".." || strings.HasPrefix(relDir, "../") {
+		return o.OnlyDirRules
+	}
+
+	if o.OnlyDirRules && relDir != dir {
+		return true
+	}
+
+	depth := 0
+	if relDir != "" {
+		depth = strings.Count(relDir, "/") + 1
+	}
+
+	return o.StopAtDepth > 0 && depth >= o.StopAtDepth
+}
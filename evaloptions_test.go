@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestProviderDecideWith(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":     {Data: []byte("*.log\n")},
+		"a/.pathrules":   {Data: []byte("*.tmp\n")},
+		"a/b/.pathrules": {Data: []byte("!debug.log\n")},
+		"c/.pathrules":   {Data: []byte("#pragma default=bogus\n")},
+		"c/d/.pathrules": {Data: []byte("*.txt\n")},
+	}
+
+	for _, precedence := range []DirPrecedence{DirPrecedenceAllLevels, DirPrecedenceNearest} {
+		p, err := NewProviderFS(fsys, ProviderOptions{
+			BaseRules:     []Rule{{Action: ActionExclude, Pattern: "*.bak"}},
+			DirPrecedence: precedence,
+		})
+		if err != nil {
+			t.Fatalf("NewProviderFS: %v", err)
+		}
+
+		cases := []struct {
+			path string
+			opts EvalOptions
+			want bool
+		}{
+			{path: "a.bak", opts: EvalOptions{}, want: false},
+			{path: "a.bak", opts: EvalOptions{SkipBaseRules: true}, want: true},
+			{path: "a/b/x.log", opts: EvalOptions{}, want: false},
+			{path: "a/b/x.log", opts: EvalOptions{OnlyDirRules: true}, want: true},
+			{path: "a/b/debug.log", opts: EvalOptions{StopAtDepth: 2}, want: false},
+			{path: "a/b/x.tmp", opts: EvalOptions{StopAtDepth: 1}, want: true},
+			{path: "a/b/x.tmp", opts: EvalOptions{StopAtDepth: 2}, want: false},
+			{path: "a/b/x.tmp", opts: EvalOptions{OnlyDirRules: true}, want: true},
+			{path: "c/d/x.txt", opts: EvalOptions{OnlyDirRules: true}, want: false},
+		}
+
+		for _, tc := range cases {
+			res, err := p.DecideWith(tc.path, false, tc.opts)
+			if err != nil || res.Included != tc.want {
+				t.Fatalf("%s: DecideWith(%s, %+v)=%+v err=%v, want included=%v", precedence, tc.path, tc.opts, res, err, tc.want)
+			}
+		}
+
+		if _, err := p.Decide("c/d/x.txt", false); err == nil {
+			t.Fatalf("%s: Decide(c/d/x.txt) must surface the broken rules file", precedence)
+		}
+	}
+}
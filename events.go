@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+// RuleFileLoadedHook is told that the rules file of relDir ("" for root)
+// was loaded; file is its path as used in Rule.Source, "" for rules from
+// ProviderOptions.RulesSource.
+type RuleFileLoadedHook func(relDir, file string)
+
+// RuleFileMissingHook is told that relDir ("" for root) has no rules file.
+type RuleFileMissingHook func(relDir string)
+
+// CacheEvictHook is told that the cached rules of relDir were dropped.
+type CacheEvictHook func(relDir string)
+
+// DecisionHook receives one provider decision: path as given by the
+// caller (joined with the directory for DecideInDir entries), its result
+// and error.
+type DecisionHook func(path string, isDir bool, res MatchResult, err error)
+
+// reportRuleFileEvent passes a fresh load of relDir to OnRuleFileLoaded or
+// OnRuleFileMissing; errors go to RuleFileErrorHandler instead.
+func (p *Provider) reportRuleFileEvent(relDir string, matcher *Matcher, err error) {
+	switch {
+	case err != nil:
+	case matcher != nil && p.onRuleFileLoaded != nil:
+		file := ""
+		if p.source == nil {
+			file = p.rulesFilePath(relDir)
+		}
+
+		p.onRuleFileLoaded(relDir, file)
+	case matcher == nil && p.onRuleFileMissing != nil:
+		p.onRuleFileMissing(relDir)
+	}
+}
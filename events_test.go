@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestProviderEventCallbacks(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, ".pathrules"), "*.tmp\n")
+	writeRulesFile(t, filepath.Join(root, "src", "main.go"), "x")
+
+	var (
+		mu        sync.Mutex
+		loaded    []string
+		missing   []string
+		evicted   []string
+		decisions []string
+	)
+
+	p, err := NewProvider(root, ProviderOptions{
+		OnRuleFileLoaded: func(relDir, file string) {
+			mu.Lock()
+			defer mu.Unlock()
+			loaded = append(loaded, relDir+"="+file)
+		},
+		OnRuleFileMissing: func(relDir string) {
+			mu.Lock()
+			defer mu.Unlock()
+			missing = append(missing, relDir)
+		},
+		OnCacheEvict: func(relDir string) {
+			mu.Lock()
+			defer mu.Unlock()
+			evicted = append(evicted, relDir)
+		},
+		OnDecision: func(path string, isDir bool, res MatchResult, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				decisions = append(decisions, path+":error")
+				return
+			}
+
+			decisions = append(decisions, path+":"+res.Decision().String())
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if _, err := p.Decide("src/a.tmp", false); err != nil {
+		t.Fatalf("Decide: %v", err)
+	}
+
+	if _, err := p.DecideInDir("src", []DirEntry{{Name: "main.go"}, {Name: "b.tmp"}}); err != nil {
+		t.Fatalf("DecideInDir: %v", err)
+	}
+
+	if _, err := p.Decide("../x", false); err == nil {
+		t.Fatal("Decide(../x) succeeded")
+	}
+
+	writeRulesFile(t, filepath.Join(root, ".pathrules"), "*.tmp\n*.log\n")
+	if _, err := p.Refresh(); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if want := []string{"=" + filepath.Join(root, ".pathrules")}; !slices.Equal(loaded, want) {
+		t.Fatalf("loaded=%q, want %q", loaded, want)
+	}
+
+	if want := []string{"src"}; !slices.Equal(missing, want) {
+		t.Fatalf("missing=%q, want %q", missing, want)
+	}
+
+	if want := []string{""}; !slices.Equal(evicted, want) {
+		t.Fatalf("evicted=%q, want %q", evicted, want)
+	}
+
+	want := []string{"src/a.tmp:exclude", "src/main.go:default", "src/b.tmp:exclude", "../x:error"}
+	if !slices.Equal(decisions, want) {
+		t.Fatalf("decisions=%q, want %q", decisions, want)
+	}
+}
@@ -0,0 +1,262 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ExportFormat selects target syntax for ExportRules.
+type ExportFormat uint8
+
+const (
+	// ExportPathrules emits canonical pathrules text (same as FormatRules).
+	ExportPathrules ExportFormat = iota
+	// ExportGitignore emits ".gitignore" text.
+	ExportGitignore
+	// ExportDockerignore emits ".dockerignore" text.
+	ExportDockerignore
+	// ExportRsyncFilter emits rsync filter rules (first match wins).
+	ExportRsyncFilter
+)
+
+// String returns export format name.
+func (f ExportFormat) String() string {
+	switch f {
+	case ExportPathrules:
+		return "pathrules"
+	case ExportGitignore:
+		return "gitignore"
+	case ExportDockerignore:
+		return "dockerignore"
+	case ExportRsyncFilter:
+		return "rsync-filter"
+	default:
+		return fmt.Sprintf("export-format(%d)", uint8(f))
+	}
+}
+
+// ExportRules translates rules with DialectDefault semantics into format text.
+//
+// Rules that cannot be represented are skipped and reported with
+// SeverityError diagnostics; rules whose meaning may change are kept and
+// reported with SeverityWarning:
+//   - DiagParentExclusion: git and rsync never re-include paths under an
+//     excluded directory, pathrules does; reported for an include under an
+//     earlier directory-only exclude or below an exclude's literal prefix
+//   - DiagDirOnlyDropped: dockerignore has no directory-only patterns
+//
+// Slash patterns that match at any depth get "**/" for gitignore and
+// dockerignore; rsync output is reversed into first-match-wins order.
+// Rules with Priority are emitted in equivalent priority order.
+// MatcherOptions such as DefaultAction are not exported.
+// Unsupported format fails with ErrInvalidOptions.
+func ExportRules(rules []Rule, format ExportFormat) (string, []Diagnostic, error) {
+	if format > ExportRsyncFilter {
+		return "", nil, fmt.Errorf("%w: unsupported export format %s", ErrInvalidOptions, format)
+	}
+
+	order := priorityOrder(rules)
+	if format == ExportPathrules {
+		var diags []Diagnostic
+		ordered := make([]Rule, len(rules))
+		for j, i := range order {
+			ordered[j] = rules[i]
+			if _, err := formatRuleLine(rules[i], false); err != nil {
+				diags = append(diags, exportDiagnostic(rules[i], i, DiagUnsupportedSyntax, SeverityError, err.Error()))
+			}
+		}
+
+		return FormatRules(ordered), diags, nil
+	}
+
+	lines := make([]string, 0, len(rules))
+	var diags []Diagnostic
+	var dirExcludes []exportExclude
+	for _, i := range order {
+		rule := rules[i]
+		if !rule.Action.valid() {
+			diags = append(diags, exportDiagnostic(rule, i, DiagInvalidAction, SeverityError,
+				fmt.Sprintf("unsupported action %d", rule.Action)))
+			continue
+		}
+
+		if rule.Syntax != PatternGlob {
+			diags = append(diags, exportDiagnostic(rule, i, DiagUnsupportedSyntax, SeverityError,
+				fmt.Sprintf("%s has no regexp patterns", format)))
+			continue
+		}
+
+		if rule.Meta != nil {
+			diags = append(diags, exportDiagnostic(rule, i, DiagUnsupportedSyntax, SeverityError,
+				fmt.Sprintf("%s has no metadata predicates", format)))
+			continue
+		}
+
+		pattern := normalizePattern(rule.Pattern)
+		anchored := strings.HasPrefix(pattern, "/")
+		dirOnly := strings.HasSuffix(pattern, "/")
+		body := strings.Trim(pattern, "/")
+		if body == "" {
+			diags = append(diags, exportDiagnostic(rule, i, DiagEmptyPattern, SeverityError, "pattern is empty after normalization"))
+			continue
+		}
+
+		if format != ExportDockerignore {
+			if rule.Action == ActionInclude && slices.ContainsFunc(dirExcludes, func(e exportExclude) bool { return e.blocks(body) }) {
+				diags = append(diags, exportDiagnostic(rule, i, DiagParentExclusion, SeverityWarning,
+					fmt.Sprintf("%s cannot re-include paths under an excluded directory", format)))
+			}
+
+			if rule.Action == ActionExclude && !strings.HasSuffix(body, "/**") && !strings.HasSuffix(body, "/*") {
+				dirExcludes = append(dirExcludes, exportExclude{body: body, anchored: anchored, dirOnly: dirOnly})
+			}
+		}
+
+		var line string
+		switch format {
+		case ExportGitignore:
+			line = exportGitignoreLine(rule.Action, body, anchored, dirOnly)
+		case ExportDockerignore:
+			if dirOnly {
+				diags = append(diags, exportDiagnostic(rule, i, DiagDirOnlyDropped, SeverityWarning,
+					"dockerignore has no directory-only patterns, files match too"))
+			}
+
+			line = exportDockerignoreLine(rule.Action, body, anchored)
+		case ExportRsyncFilter:
+			line = exportRsyncLine(rule.Action, body, anchored, dirOnly)
+		}
+
+		lines = append(lines, line)
+	}
+
+	if format == ExportRsyncFilter {
+		slices.Reverse(lines)
+	}
+
+	if len(lines) == 0 {
+		return "", diags, nil
+	}
+
+	return strings.Join(lines, "\n") + "\n", diags, nil
+}
+
+// exportExclude is an earlier exclude rule that may match a directory.
+type exportExclude struct {
+	// body is the normalized pattern without leading and trailing "/".
+	body string
+	// anchored reports a leading "/" in the pattern.
+	anchored bool
+	// dirOnly reports a trailing "/" in the pattern.
+	dirOnly bool
+}
+
+// blocks reports whether e may exclude a parent directory of paths matched
+// by include pattern body, so git and rsync would not re-include them.
+// File globs such as "*.log" never block "!keep.log".
+func (e exportExclude) blocks(body string) bool {
+	if e.dirOnly {
+		return true
+	}
+
+	literal := e.body
+	if i := strings.IndexAny(literal, "*?[\\"); i >= 0 {
+		literal = literal[:i]
+	}
+
+	if literal == "" {
+		return false
+	}
+
+	if !e.anchored && !strings.Contains(e.body, "/") {
+		// Component patterns match a directory at any depth.
+		dirs := strings.Split(body, "/")
+		return slices.ContainsFunc(dirs[:len(dirs)-1], func(dir string) bool {
+			return strings.HasPrefix(dir, literal)
+		})
+	}
+
+	under := strings.HasPrefix(body, literal) || !e.anchored && strings.Contains(body, "/"+literal)
+	return under && strings.Count(body, "/") > strings.Count(e.body, "/")
+}
+
+// exportGitignoreLine formats one normalized rule as a gitignore line.
+func exportGitignoreLine(action Action, body string, anchored bool, dirOnly bool) string {
+	pattern := body
+	switch {
+	case anchored:
+		pattern = "/" + body
+	case strings.Contains(body, "/") && !strings.HasPrefix(body, "**/"):
+		// Git anchors slash patterns; pathrules matches them at any depth.
+		pattern = "**/" + body
+	}
+
+	if dirOnly {
+		pattern += "/"
+	}
+
+	if action == ActionInclude {
+		return "!" + pattern
+	}
+
+	if strings.HasPrefix(pattern, "#") || strings.HasPrefix(pattern, "!") {
+		return `\` + pattern
+	}
+
+	return pattern
+}
+
+// exportDockerignoreLine formats one normalized rule as a dockerignore line.
+func exportDockerignoreLine(action Action, body string, anchored bool) string {
+	pattern := body
+	if !anchored && !strings.HasPrefix(body, "**/") && body != "**" {
+		// Docker matches every pattern from context root.
+		pattern = "**/" + body
+	}
+
+	if action == ActionInclude {
+		return "!" + pattern
+	}
+
+	// Docker has no escapes; single-byte classes keep leading "#" and "!" literal.
+	if strings.HasPrefix(pattern, "#") || strings.HasPrefix(pattern, "!") {
+		return "[" + pattern[:1] + "]" + pattern[1:]
+	}
+
+	return pattern
+}
+
+// exportRsyncLine formats one normalized rule as an rsync filter line.
+func exportRsyncLine(action Action, body string, anchored bool, dirOnly bool) string {
+	pattern := body
+	if anchored {
+		pattern = "/" + pattern
+	}
+
+	if dirOnly {
+		pattern += "/"
+	}
+
+	if action == ActionInclude {
+		return "+ " + pattern
+	}
+
+	return "- " + pattern
+}
+
+// exportDiagnostic builds one ExportRules diagnostic for rule at index.
+func exportDiagnostic(rule Rule, index int, code DiagnosticCode, severity Severity, msg string) Diagnostic {
+	return Diagnostic{
+		Code:      code,
+		Message:   msg,
+		Pattern:   rule.Pattern,
+		RuleIndex: index,
+		Offset:    -1,
+		Severity:  severity,
+	}
+}
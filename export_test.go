@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestExportRules(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionExclude, Pattern: "*.tmp"},
+		{Action: ActionExclude, Pattern: "build/"},
+		{Action: ActionExclude, Pattern: "docs/drafts/**"},
+		{Action: ActionExclude, Pattern: "/#notes"},
+		{Action: ActionInclude, Pattern: "keep.tmp"},
+		{Action: ActionExclude, Pattern: `^gen/`, Syntax: PatternRegexp},
+	}
+
+	cases := []struct {
+		want   string
+		codes  []DiagnosticCode
+		format ExportFormat
+	}{
+		{
+			format: ExportGitignore,
+			want:   "*.tmp\nbuild/\n**/docs/drafts/**\n/#notes\n!keep.tmp\n",
+			codes:  []DiagnosticCode{DiagParentExclusion, DiagUnsupportedSyntax},
+		},
+		{
+			format: ExportDockerignore,
+			want:   "**/*.tmp\n**/build\n**/docs/drafts/**\n[#]notes\n!**/keep.tmp\n",
+			codes:  []DiagnosticCode{DiagDirOnlyDropped, DiagUnsupportedSyntax},
+		},
+		{
+			format: ExportRsyncFilter,
+			want:   "+ keep.tmp\n- /#notes\n- docs/drafts/**\n- build/\n- *.tmp\n",
+			codes:  []DiagnosticCode{DiagParentExclusion, DiagUnsupportedSyntax},
+		},
+		{
+			format: ExportPathrules,
+			want:   "*.tmp\nbuild/\ndocs/drafts/**\n/#notes\n!keep.tmp\n",
+			codes:  []DiagnosticCode{DiagUnsupportedSyntax},
+		},
+	}
+
+	for _, tc := range cases {
+		got, diags, err := ExportRules(rules, tc.format)
+		if err != nil {
+			t.Fatalf("%s: ExportRules: %v", tc.format, err)
+		}
+
+		if got != tc.want {
+			t.Fatalf("%s: got\n%s\nwant\n%s", tc.format, got, tc.want)
+		}
+
+		if len(diags) != len(tc.codes) {
+			t.Fatalf("%s: diagnostics=%+v, want codes %v", tc.format, diags, tc.codes)
+		}
+
+		for i := range diags {
+			if diags[i].Code != tc.codes[i] {
+				t.Fatalf("%s: diag[%d]=%+v, want %s", tc.format, i, diags[i], tc.codes[i])
+			}
+		}
+	}
+}
+
+func TestExportRulesGitignoreRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionExclude, Pattern: "src/*.o"},
+		{Action: ActionExclude, Pattern: "/out"},
+		{Action: ActionExclude, Pattern: "*.log"},
+	}
+
+	text, diags, err := ExportRules(rules, ExportGitignore)
+	if err != nil || len(diags) != 0 {
+		t.Fatalf("ExportRules: err=%v diags=%+v", err, diags)
+	}
+
+	parsed, err := ParseRulesString(text)
+	if err != nil {
+		t.Fatalf("ParseRulesString: %v", err)
+	}
+
+	def, err := NewMatcher(rules, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	git, err := NewMatcher(parsed, MatcherOptions{Dialect: DialectGit})
+	if err != nil {
+		t.Fatalf("NewMatcher(git): %v", err)
+	}
+
+	for _, path := range []string{"src/a.o", "x/src/a.o", "out", "x/out", "a/b.log", "src/a.c"} {
+		if def.Excluded(path, false) != git.Excluded(path, false) {
+			t.Fatalf("%q: pathrules excluded=%v, git excluded=%v\n%s", path, def.Excluded(path, false), git.Excluded(path, false), text)
+		}
+	}
+}
+
+func TestExportRulesParentExclusion(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		rules string
+		warn  bool
+	}{
+		{rules: "*.log\n!keep.log\n", warn: false},
+		{rules: "logs/*.log\n!logs/keep.log\n", warn: false},
+		{rules: "build/*\n!build/keep.txt\n", warn: false},
+		{rules: "build/\n!keep.txt\n", warn: true},
+		{rules: "/build\n!build/keep.txt\n", warn: true},
+		{rules: "cache\n!src/cache/keep.txt\n", warn: true},
+		{rules: "src/gen\n!x/src/gen/keep.go\n", warn: true},
+		{rules: "/src/gen\n!src/keep.go\n", warn: false},
+	}
+
+	for _, tc := range cases {
+		_, diags, err := ExportRules(MustParseRulesString(tc.rules), ExportGitignore)
+		if err != nil {
+			t.Fatalf("%q: ExportRules: %v", tc.rules, err)
+		}
+
+		warned := slices.ContainsFunc(diags, func(d Diagnostic) bool { return d.Code == DiagParentExclusion })
+		if warned != tc.warn {
+			t.Fatalf("%q: parent exclusion warning=%v, want %v (%+v)", tc.rules, warned, tc.warn, diags)
+		}
+	}
+}
+
+func TestExportRulesRejectsUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	if _, _, err := ExportRules(nil, ExportFormat(99)); !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("err=%v, want ErrInvalidOptions", err)
+	}
+
+	if got, _, err := ExportRules(nil, ExportGitignore); err != nil || strings.TrimSpace(got) != "" {
+		t.Fatalf("empty export=%q err=%v", got, err)
+	}
+}
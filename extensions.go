@@ -16,6 +16,17 @@ import "strings"
 // Empty values are skipped. Returned patterns are normalized to lower-case
 // "*.ext" form and preserve input order.
 func ParseExtensions(exts []string) []Rule {
+	return extensionRules(exts, ActionInclude)
+}
+
+// ParseExtensionsExclude converts extension list to exclude rules, accepting
+// the same forms as ParseExtensions.
+func ParseExtensionsExclude(exts []string) []Rule {
+	return extensionRules(exts, ActionExclude)
+}
+
+// extensionRules converts extension list to rules with action.
+func extensionRules(exts []string, action Action) []Rule {
 	rules := make([]Rule, 0, len(exts))
 	for _, ext := range exts {
 		ext = strings.TrimSpace(ext)
@@ -27,7 +38,7 @@ func ParseExtensions(exts []string) []Rule {
 		}
 
 		rules = append(rules, Rule{
-			Action:  ActionInclude,
+			Action:  action,
 			Pattern: "*." + ext,
 		})
 	}
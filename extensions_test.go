@@ -1,6 +1,9 @@
 package pathrules
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestParseExtensions(t *testing.T) {
 	t.Parallel()
@@ -26,7 +29,7 @@ func TestParseExtensions(t *testing.T) {
 	}
 
 	for i := range want {
-		if got[i] != want[i] {
+		if !reflect.DeepEqual(got[i], want[i]) {
 			t.Fatalf("rule[%d]=%+v, want %+v", i, got[i], want[i])
 		}
 	}
@@ -40,3 +43,17 @@ func TestParseExtensions_Empty(t *testing.T) {
 		t.Fatalf("len(got)=%d, want 0", len(got))
 	}
 }
+
+func TestParseExtensionsExclude(t *testing.T) {
+	t.Parallel()
+
+	got := ParseExtensionsExclude([]string{".TMP", "", "*.bak"})
+	want := []Rule{
+		{Action: ActionExclude, Pattern: "*.tmp"},
+		{Action: ActionExclude, Pattern: "*.bak"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got=%+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// FilterFS returns fsys with paths excluded by d hidden: opening them fails
+// with fs.ErrNotExist and directory listings omit them.
+//
+// A path is visible only when every parent directory is included too, as
+// in a pruned walk, so a re-included file below an excluded directory stays
+// hidden. Use it with http.FileServerFS to never serve excluded files:
+//
+//	http.Handle("/", http.FileServerFS(pathrules.FilterFS(os.DirFS(root), p)))
+func FilterFS(fsys fs.FS, d Decider) fs.FS {
+	return &filterFS{fsys: fsys, decider: d}
+}
+
+// FilterHTTPFileSystem is FilterFS for http.FileSystem implementations such
+// as http.Dir; hidden paths make http.FileServer answer 404.
+func FilterHTTPFileSystem(hfs http.FileSystem, d Decider) http.FileSystem {
+	return &filterHTTPFileSystem{fsys: hfs, decider: d}
+}
+
+// filterFS hides excluded paths of an fs.FS.
+type filterFS struct {
+	// fsys is the wrapped file system.
+	fsys fs.FS
+	// decider decides visibility.
+	decider Decider
+}
+
+// filterFile filters directory entries of an opened fs.FS directory.
+type filterFile struct {
+	fs.File
+	// decider decides visibility of entries.
+	decider Decider
+	// name is the directory path in fs.FS form.
+	name string
+}
+
+// filterHTTPFileSystem hides excluded paths of an http.FileSystem.
+type filterHTTPFileSystem struct {
+	// fsys is the wrapped file system.
+	fsys http.FileSystem
+	// decider decides visibility.
+	decider Decider
+}
+
+// filterHTTPFile filters directory entries of an opened http.File directory.
+type filterHTTPFile struct {
+	http.File
+	// decider decides visibility of entries.
+	decider Decider
+	// name is the directory path without leading "/".
+	name string
+}
+
+// Open implements fs.FS.
+func (f *filterFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	file, err := f.fsys.Open(name)
+	if err != nil || name == "." {
+		return wrapFilterFile(file, err, f.decider, name)
+	}
+
+	info, err := file.Stat()
+	if err == nil {
+		err = visible(f.decider, name, info.IsDir())
+	}
+
+	if err != nil {
+		_ = file.Close()
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return wrapFilterFile(file, nil, f.decider, name)
+}
+
+// wrapFilterFile wraps directories so their listings are filtered.
+func wrapFilterFile(file fs.File, err error, d Decider, name string) (fs.File, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := file.(fs.ReadDirFile); !ok {
+		return file, nil
+	}
+
+	return &filterFile{File: file, decider: d, name: name}, nil
+}
+
+// ReadDir implements fs.ReadDirFile, skipping hidden entries.
+func (f *filterFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	dir, ok := f.File.(fs.ReadDirFile)
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: f.name, Err: errors.New("not implemented")}
+	}
+
+	var out []fs.DirEntry
+	for {
+		entries, err := dir.ReadDir(n)
+		for _, entry := range entries {
+			ok, decideErr := entryVisible(f.decider, f.name, entry.Name(), entry.IsDir())
+			if decideErr != nil {
+				return out, decideErr
+			}
+
+			if ok {
+				out = append(out, entry)
+			}
+		}
+
+		// Keep reading until n visible entries are collected or the directory ends.
+		if n <= 0 || len(out) > 0 || err != nil {
+			return out, err
+		}
+	}
+}
+
+// Open implements http.FileSystem.
+func (f *filterHTTPFileSystem) Open(name string) (http.File, error) {
+	rel := strings.TrimPrefix(path.Clean("/"+name), "/")
+	file, err := f.fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if rel != "" {
+		info, err := file.Stat()
+		if err == nil {
+			err = visible(f.decider, rel, info.IsDir())
+		}
+
+		if err != nil {
+			_ = file.Close()
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+	}
+
+	return &filterHTTPFile{File: file, decider: f.decider, name: rel}, nil
+}
+
+// Readdir implements http.File, skipping hidden entries.
+func (f *filterHTTPFile) Readdir(count int) ([]fs.FileInfo, error) {
+	var out []fs.FileInfo
+	for {
+		infos, err := f.File.Readdir(count)
+		for _, info := range infos {
+			ok, decideErr := entryVisible(f.decider, f.name, info.Name(), info.IsDir())
+			if decideErr != nil {
+				return out, decideErr
+			}
+
+			if ok {
+				out = append(out, info)
+			}
+		}
+
+		if count <= 0 || len(out) > 0 || err != nil {
+			return out, err
+		}
+	}
+}
+
+// visible returns fs.ErrNotExist when name or one of its parent directories
+// is excluded by d.
+func visible(d Decider, name string, isDir bool) error {
+	for i := 0; i < len(name); i++ {
+		if name[i] != '/' {
+			continue
+		}
+
+		if excluded, err := d.Excluded(name[:i], true); err != nil || excluded {
+			return hiddenErr(err)
+		}
+	}
+
+	excluded, err := d.Excluded(name, isDir)
+	if err != nil || excluded {
+		return hiddenErr(err)
+	}
+
+	return nil
+}
+
+// entryVisible reports whether an entry of an already visible directory is visible.
+func entryVisible(d Decider, dir, name string, isDir bool) (bool, error) {
+	if dir != "" && dir != "." {
+		name = dir + "/" + name
+	}
+
+	excluded, err := d.Excluded(name, isDir)
+	return !excluded, err
+}
+
+// hiddenErr returns err or fs.ErrNotExist for an excluded path.
+func hiddenErr(err error) error {
+	if err != nil {
+		return err
+	}
+
+	return fs.ErrNotExist
+}
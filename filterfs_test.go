@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// filterTestRules hide secrets everywhere, even when re-included below.
+const filterTestRules = "*.key\nsecret/\n!secret/public.txt\n"
+
+func TestFilterFS(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"index.html":        {Data: []byte("home")},
+		"app.key":           {Data: []byte("k")},
+		"sub/a.txt":         {Data: []byte("a")},
+		"sub/b.key":         {Data: []byte("b")},
+		"secret/public.txt": {Data: []byte("p")},
+	}
+
+	m, err := NewMatcher(MustParseRulesString(filterTestRules), MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	filtered := FilterFS(fsys, m.Decider())
+	for name, want := range map[string]bool{
+		"index.html":        true,
+		"app.key":           false,
+		"sub/a.txt":         true,
+		"sub/b.key":         false,
+		"secret":            false,
+		"secret/public.txt": false,
+	} {
+		_, err := fs.Stat(filtered, name)
+		if got := err == nil; got != want {
+			t.Fatalf("Stat(%s) err=%v, want visible=%v", name, err, want)
+		}
+
+		if !want && !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("Stat(%s) err=%v, want fs.ErrNotExist", name, err)
+		}
+	}
+
+	var names []string
+	err = fs.WalkDir(filtered, ".", func(name string, _ fs.DirEntry, err error) error {
+		names = append(names, name)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WalkDir: %v", err)
+	}
+
+	if want := []string{".", "index.html", "sub", "sub/a.txt"}; !slices.Equal(names, want) {
+		t.Fatalf("WalkDir=%v, want %v", names, want)
+	}
+
+	if err := fstest.TestFS(filtered, "index.html", "sub/a.txt"); err != nil {
+		t.Fatalf("TestFS: %v", err)
+	}
+}
+
+func TestFilterHTTPFileSystem(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for name, data := range map[string]string{
+		"index.txt":         "home",
+		"app.key":           "k",
+		"secret/public.txt": "p",
+	} {
+		full := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+
+		if err := os.WriteFile(full, []byte(data), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+
+	p, err := NewProvider(root, ProviderOptions{BaseRules: MustParseRulesString(filterTestRules)})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	for name, handler := range map[string]http.Handler{
+		"FileServer":   http.FileServer(FilterHTTPFileSystem(http.Dir(root), p)),
+		"FileServerFS": http.FileServerFS(FilterFS(os.DirFS(root), p)),
+	} {
+		for url, want := range map[string]int{
+			"/index.txt":         http.StatusOK,
+			"/app.key":           http.StatusNotFound,
+			"/secret/public.txt": http.StatusNotFound,
+		} {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+			if rec.Code != want {
+				t.Fatalf("%s GET %s=%d, want %d", name, url, rec.Code, want)
+			}
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		if body := rec.Body.String(); !strings.Contains(body, "index.txt") || strings.Contains(body, "app.key") || strings.Contains(body, "secret") {
+			t.Fatalf("%s listing=%q", name, body)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "io/fs"
+
+// IncludeFunc returns p.Included shaped for walkers and filters that pass
+// fs.DirEntry values, e.g. inside an fs.WalkDir callback over an fs.FS
+// rooted at the provider root. Paths are relative to that root; "." and ""
+// (the root itself) are always included, and a nil d counts as a file.
+//
+// Unlike WalkFilter it does not prune: callers return fs.SkipDir themselves.
+func (p *Provider) IncludeFunc() func(path string, d fs.DirEntry) (bool, error) {
+	return func(path string, d fs.DirEntry) (bool, error) {
+		if path == "." || path == "" {
+			return true, nil
+		}
+
+		return p.Included(path, d != nil && d.IsDir())
+	}
+}
+
+// PathFilter returns m.Included as a closure for filter callbacks taking a
+// path and a directory flag. "." and "" are always included.
+func (m *Matcher) PathFilter() func(path string, isDir bool) bool {
+	return func(path string, isDir bool) bool {
+		if path == "." || path == "" {
+			return true
+		}
+
+		return m.Included(path, isDir)
+	}
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"io/fs"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func TestProviderIncludeFunc(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":    {Data: []byte("*.log\nbuild/\n")},
+		"a.go":          {},
+		"x.log":         {},
+		"build/out.bin": {},
+	}
+
+	p, err := NewProviderFS(fsys, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	include := p.IncludeFunc()
+	var names []string
+	err = fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		ok, err := include(path, d)
+		if err != nil || !ok {
+			if err == nil && d.IsDir() {
+				return fs.SkipDir
+			}
+
+			return err
+		}
+
+		names = append(names, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkDir: %v", err)
+	}
+
+	if want := []string{".", ".pathrules", "a.go"}; !slices.Equal(names, want) {
+		t.Fatalf("names=%v, want %v", names, want)
+	}
+}
+
+func TestMatcherPathFilter(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher(MustParseRulesString("*\n!*.go\n"), MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	keep := m.PathFilter()
+	got := slices.DeleteFunc([]string{".", "a.go", "b.txt"}, func(path string) bool { return !keep(path, false) })
+	if want := []string{".", "a.go"}; !slices.Equal(got, want) {
+		t.Fatalf("filtered=%v, want %v", got, want)
+	}
+}
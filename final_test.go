@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestProviderFinalRules(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":        {Data: []byte("*.signature\n")},
+		"addons/.pathrules": {Data: []byte("*\n")},
+		"broken/.pathrules": {Data: []byte("#pragma default=bogus\n")},
+	}
+
+	p, err := NewProviderFS(fsys, ProviderOptions{
+		FinalRules:      []Rule{{Action: ActionInclude, Pattern: "*.pbo.signature"}},
+		OnRuleFileError: RuleFileErrorExcludeSubtree,
+	})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	for path, want := range map[string]bool{
+		"a.signature":            false,
+		"a.pbo.signature":        true,
+		"addons/a.pbo":           false,
+		"addons/a.pbo.signature": true,
+		"broken/a.pbo.signature": true,
+		"broken/a.pbo":           false,
+	} {
+		if got, err := p.Included(path, false); err != nil || got != want {
+			t.Fatalf("Included(%s)=%v err=%v, want %v", path, got, err, want)
+		}
+
+		got, err := p.IncludedInDir(pathDir(path, false), []DirEntry{{Name: pathBase(path)}})
+		if err != nil || got[0] != want {
+			t.Fatalf("IncludedInDir(%s)=%v err=%v, want %v", path, got, err, want)
+		}
+	}
+
+	v, err := p.DecideVerbose("addons/a.pbo.signature", false)
+	if err != nil || v.Source != DecisionSourceFinal || v.Rule.Pattern != "*.pbo.signature" {
+		t.Fatalf("DecideVerbose=%+v err=%v, want final rule", v, err)
+	}
+
+	if res, err := p.DecideWith("addons/a.pbo.signature", false, EvalOptions{SkipFinalRules: true}); err != nil || res.Included {
+		t.Fatalf("DecideWith(SkipFinalRules)=%+v err=%v, want excluded", res, err)
+	}
+}
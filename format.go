@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// FormatRules returns canonical rules text accepted by ParseRules.
+//
+// Rules rejected by WriteRules are skipped.
+func FormatRules(rules []Rule) string {
+	var b strings.Builder
+	_ = writeRules(&b, rules, true)
+	return b.String()
+}
+
+// WriteRules writes canonical rules text accepted by ParseRules.
+//
+// Output rules:
+//   - include rules get "!" prefix
+//   - leading "#" and "!" of exclude patterns are escaped with "\"
+//   - trailing whitespace is protected with "\" before its last byte
+//   - rules with Section emit "[name]" headers (parse with ParseOptions.Sections)
+//
+// Rules with invalid action, empty pattern or line breaks fail with ErrInvalidRule.
+func WriteRules(w io.Writer, rules []Rule) error {
+	bw := bufio.NewWriter(w)
+	if err := writeRules(bw, rules, false); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// writeRules formats rules into w, optionally skipping rules that cannot be represented.
+func writeRules(w io.StringWriter, rules []Rule, skipInvalid bool) error {
+	sectioned := false
+	for i := range rules {
+		if rules[i].Section != "" {
+			sectioned = true
+			break
+		}
+	}
+
+	section := ""
+	for i := range rules {
+		line, err := formatRuleLine(rules[i], sectioned)
+		if err == nil && rules[i].Section != section {
+			if rules[i].Section == "" {
+				err = fmt.Errorf("%w: common rule after section %q", ErrInvalidRule, section)
+			} else if _, ok := parseSectionHeader("[" + rules[i].Section + "]"); !ok {
+				err = fmt.Errorf("%w: invalid section name %q", ErrInvalidRule, rules[i].Section)
+			}
+		}
+
+		if err != nil {
+			if skipInvalid {
+				continue
+			}
+
+			return fmt.Errorf("rule %d: %w", i, err)
+		}
+
+		if rules[i].Section != section {
+			section = rules[i].Section
+			if _, err := w.WriteString("[" + section + "]\n"); err != nil {
+				return err
+			}
+		}
+
+		if _, err := w.WriteString(line + "\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// formatRuleLine formats one rule as a single rules file line.
+func formatRuleLine(rule Rule, sectioned bool) (string, error) {
+	if !rule.Action.valid() {
+		return "", fmt.Errorf("%w: unsupported action %d", ErrInvalidRule, rule.Action)
+	}
+
+	if rule.Meta != nil {
+		return "", fmt.Errorf("%w: metadata predicates have no rules text form", ErrInvalidRule)
+	}
+
+	if rule.Syntax != PatternGlob {
+		return "", fmt.Errorf("%w: pattern syntax %d has no rules text form", ErrInvalidRule, rule.Syntax)
+	}
+
+	pattern := rule.Pattern
+	if strings.TrimRight(pattern, " \t") == "" {
+		return "", fmt.Errorf("%w: empty pattern", ErrInvalidRule)
+	}
+
+	if strings.ContainsAny(pattern, "\r\n") {
+		return "", fmt.Errorf("%w: line break in pattern %q", ErrInvalidRule, pattern)
+	}
+
+	if _, ok := parseSectionHeader(pattern); ok && sectioned {
+		return "", fmt.Errorf("%w: pattern %q is ambiguous with section header", ErrInvalidRule, pattern)
+	}
+
+	// Protect trailing whitespace: the parser keeps one escaped trailing byte
+	// together with all whitespace before it.
+	if trimmed := strings.TrimRight(pattern, " \t"); len(trimmed) < len(pattern) {
+		last := len(pattern) - 1
+		pattern = pattern[:last] + `\` + pattern[last:]
+	}
+
+	if rule.Action == ActionInclude {
+		return "!" + pattern, nil
+	}
+
+	if strings.HasPrefix(pattern, "#") || strings.HasPrefix(pattern, "!") {
+		return `\` + pattern, nil
+	}
+
+	return pattern, nil
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFormatRulesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionExclude, Pattern: "*.tmp"},
+		{Action: ActionInclude, Pattern: "keep.tmp"},
+		{Action: ActionExclude, Pattern: "#literal"},
+		{Action: ActionExclude, Pattern: "!bang"},
+		{Action: ActionInclude, Pattern: "!bang-include"},
+		{Action: ActionExclude, Pattern: "name  "},
+		{Action: ActionExclude, Pattern: "tab\t"},
+	}
+
+	text := FormatRules(rules)
+	got, err := ParseRulesString(text)
+	if err != nil {
+		t.Fatalf("ParseRulesString: %v", err)
+	}
+
+	if len(got) != len(rules) {
+		t.Fatalf("len(got)=%d, want %d\n%s", len(got), len(rules), text)
+	}
+
+	for i := range rules {
+		if got[i].Action != rules[i].Action || got[i].Pattern != rules[i].Pattern {
+			t.Fatalf("rule[%d]=%+v, want %+v\n%s", i, got[i], rules[i], text)
+		}
+	}
+}
+
+func TestFormatRulesSections(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionExclude, Pattern: "*"},
+		{Action: ActionInclude, Pattern: "*.paa", Section: "textures"},
+		{Action: ActionInclude, Pattern: "*.c", Section: "scripts"},
+	}
+
+	text := FormatRules(rules)
+	if text != "*\n[textures]\n!*.paa\n[scripts]\n!*.c\n" {
+		t.Fatalf("FormatRules=%q", text)
+	}
+
+	got, err := ParseRulesWithOptions(strings.NewReader(text), ParseOptions{Sections: true})
+	if err != nil {
+		t.Fatalf("ParseRulesWithOptions: %v", err)
+	}
+
+	if len(got) != 3 || got[1].Section != "textures" || got[2].Section != "scripts" {
+		t.Fatalf("unexpected parsed sections: %+v", got)
+	}
+}
+
+func TestWriteRulesRejectsInvalid(t *testing.T) {
+	t.Parallel()
+
+	cases := [][]Rule{
+		{{Action: ActionUnknown, Pattern: "a"}},
+		{{Action: ActionExclude, Pattern: "  "}},
+		{{Action: ActionExclude, Pattern: "a\nb"}},
+		{{Action: ActionExclude, Pattern: "a", Section: "s"}, {Action: ActionExclude, Pattern: "b"}},
+		{{Action: ActionExclude, Pattern: "a", Syntax: PatternRegexp}},
+	}
+
+	for i, rules := range cases {
+		var b strings.Builder
+		if err := WriteRules(&b, rules); !errors.Is(err, ErrInvalidRule) {
+			t.Fatalf("case %d: err=%v, want ErrInvalidRule", i, err)
+		}
+	}
+
+	if got := FormatRules(cases[0]); got != "" {
+		t.Fatalf("FormatRules must skip invalid rules, got %q", got)
+	}
+}
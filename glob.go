@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+)
+
+// Glob returns files in fsys matched by include rules, sorted.
+//
+// A file is listed when its decision is included and comes from a matching
+// rule; files included only by DefaultAction are not listed. Anchored
+// include rules with a literal leading path ("/assets/textures/*.paa") limit
+// the scan to that subtree, any other include rule scans the whole fsys.
+// Case-insensitive matchers always scan the whole fsys. Symlinks to
+// directories are not followed.
+func (m *Matcher) Glob(fsys fs.FS) ([]string, error) {
+	starts := m.includeStarts(-1)
+	if len(starts) == 0 {
+		return nil, nil
+	}
+
+	var files []string
+	for _, start := range starts {
+		walkRoot := start
+		if walkRoot == "" {
+			walkRoot = "."
+		}
+
+		err := fs.WalkDir(fsys, walkRoot, func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if name == walkRoot && errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+
+				return err
+			}
+
+			if name == "." {
+				return nil
+			}
+
+			res := m.Decide(name, d.IsDir())
+			if d.IsDir() {
+				// Nothing below a directory excluded by a rule is included.
+				if m.dialect.parentExclusion() && res.Matched && !res.Included {
+					return fs.SkipDir
+				}
+
+				return nil
+			}
+
+			if res.Matched && res.Included {
+				files = append(files, name)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("glob %s: %w", walkRoot, err)
+		}
+	}
+
+	slices.Sort(files)
+	return files, nil
+}
+
+// IncludePrefixes returns the minimal sorted set of literal paths below
+// which (or at which) include rules may match, so allow-list walkers can
+// start at those subtrees instead of the whole root.
+//
+// Prefixes come from anchored include rules with a literal leading path
+// ("/docs/**" gives "docs"); a prefix may name a file. The result is [""]
+// (scan from root) when some include rule has no such prefix or paths
+// matching no rule are included by DefaultAction, and empty when nothing
+// can be included. Include rules outranked by an exclude rule matching
+// every path ("*") are skipped. Case-insensitive matchers always return [""].
+func (m *Matcher) IncludePrefixes() []string {
+	cover := m.descendCover("")
+	switch {
+	case cover >= 0 && m.compiled[cover].source.Action != ActionExclude,
+		cover < 0 && m.defaultAction == ActionInclude:
+		return []string{""}
+	}
+
+	return m.includeStarts(cover)
+}
+
+// includeStarts returns minimal set of directories or files to scan for
+// include rules outranking compiled rule over (all when over is -1).
+func (m *Matcher) includeStarts(over int) []string {
+	var starts []string
+	for i := range m.compiled {
+		rule := &m.compiled[i]
+		// Custom action handlers may include paths too; metadata rules never
+		// match in Decide.
+		if rule.source.Action == ActionExclude || rule.source.Meta != nil || (over >= 0 && !m.outranks(i, over)) {
+			continue
+		}
+
+		prefix := ""
+		if rule.anchored && !m.caseInsensitive && !rule.foldCase {
+			prefix = literalPatternPrefix(rule.source.Pattern, m.dialect == DialectGit || m.posixSeparators)
+		}
+
+		if prefix == "" {
+			return []string{""}
+		}
+
+		starts = append(starts, prefix)
+	}
+
+	slices.Sort(starts)
+	starts = slices.Compact(starts)
+
+	// Drop starts nested in an earlier start; sorting puts parents first.
+	minimal := starts[:0]
+	for _, start := range starts {
+		if len(minimal) > 0 {
+			last := minimal[len(minimal)-1]
+			if strings.HasPrefix(start, last+"/") {
+				continue
+			}
+		}
+
+		minimal = append(minimal, start)
+	}
+
+	return minimal
+}
+
+// literalPatternPrefix returns leading pattern path components without glob meta.
+func literalPatternPrefix(pattern string, backslashEscapes bool) string {
+	if !backslashEscapes {
+		pattern = normalizePattern(pattern)
+	}
+
+	pattern = strings.Trim(strings.TrimSpace(pattern), "/")
+	end := 0
+	for part := range strings.SplitSeq(pattern, "/") {
+		if part == "" || part == "." || part == ".." || strings.ContainsAny(part, `*?[\`) {
+			break
+		}
+
+		if end > 0 {
+			end++
+		}
+
+		end += len(part)
+	}
+
+	return pattern[:end]
+}
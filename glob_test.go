@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"io/fs"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+// openCountFS records directories read through fs.ReadDir.
+type openCountFS struct {
+	fstest.MapFS
+	read map[string]bool
+}
+
+func (f *openCountFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	f.read[name] = true
+	return f.MapFS.ReadDir(name)
+}
+
+func TestMatcherGlob(t *testing.T) {
+	t.Parallel()
+
+	fsys := &openCountFS{
+		MapFS: fstest.MapFS{
+			"assets/textures/a.paa":     {},
+			"assets/textures/b.png":     {},
+			"assets/textures/sub/c.paa": {},
+			"assets/models/m.p3d":       {},
+			"docs/readme.md":            {},
+			"scripts/main.c":            {},
+		},
+		read: make(map[string]bool),
+	}
+
+	m, err := NewMatcher([]Rule{
+		{Action: ActionInclude, Pattern: "/assets/textures/**/*.paa"},
+		{Action: ActionInclude, Pattern: "/docs/readme.md"},
+		{Action: ActionExclude, Pattern: "sub/"},
+		{Action: ActionInclude, Pattern: "/missing/*"},
+	}, MatcherOptions{DefaultAction: ActionInclude})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	got, err := m.Glob(fsys)
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+
+	if want := []string{"assets/textures/a.paa", "docs/readme.md"}; !slices.Equal(got, want) {
+		t.Fatalf("Glob=%v, want %v", got, want)
+	}
+
+	for _, dir := range []string{".", "assets", "scripts", "assets/models"} {
+		if fsys.read[dir] {
+			t.Fatalf("Glob read unrelated dir %q: %v", dir, fsys.read)
+		}
+	}
+
+	m, err = NewMatcher([]Rule{{Action: ActionInclude, Pattern: "*.c"}}, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	got, err = m.Glob(fsys.MapFS)
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+
+	if want := []string{"scripts/main.c"}; !slices.Equal(got, want) {
+		t.Fatalf("Glob=%v, want %v", got, want)
+	}
+}
+
+func TestLiteralPatternPrefix(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"/a/b/*.c":   "a/b",
+		"/a/b/c.txt": "a/b/c.txt",
+		"/a/b/":      "a/b",
+		"/*.c":       "",
+		"/a/[bc]/d":  "a",
+		"/a/../b":    "a",
+	}
+
+	for pattern, want := range cases {
+		if got := literalPatternPrefix(pattern, false); got != want {
+			t.Fatalf("literalPatternPrefix(%q)=%q, want %q", pattern, got, want)
+		}
+	}
+}
+
+func TestMatcherIncludePrefixes(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name  string
+		rules string
+		opts  MatcherOptions
+		want  []string
+	}{
+		{
+			name:  "allowlist by default action",
+			rules: "!/docs/**\n!/src/app/*.go\n!/src/app/sub/\n!/README.md\n",
+			opts:  MatcherOptions{DefaultAction: ActionExclude},
+			want:  []string{"README.md", "docs", "src/app"},
+		},
+		{
+			name:  "allowlist by star",
+			rules: "*\n!/assets/textures/**\n",
+			want:  []string{"assets/textures"},
+		},
+		{
+			name:  "negation outranked by star",
+			rules: "!/docs/**\n*\n",
+			want:  nil,
+		},
+		{
+			name:  "unanchored include",
+			rules: "*\n!/docs/**\n!*.md\n",
+			want:  []string{""},
+		},
+		{
+			name:  "default include",
+			rules: "!/docs/**\n",
+			want:  []string{""},
+		},
+		{
+			name:  "case insensitive",
+			rules: "*\n!/docs/**\n",
+			opts:  MatcherOptions{CaseInsensitive: true},
+			want:  []string{""},
+		},
+	} {
+		m, err := NewMatcher(MustParseRulesString(tc.rules), tc.opts)
+		if err != nil {
+			t.Fatalf("%s: NewMatcher: %v", tc.name, err)
+		}
+
+		if got := m.IncludePrefixes(); !slices.Equal(got, tc.want) {
+			t.Fatalf("%s: IncludePrefixes=%q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"maps"
+	"slices"
+)
+
+// hashVersion prefixes hashed policies; change it when the encoding changes.
+const hashVersion = "pathrules-policy-v1"
+
+// policyHeader is the decision-relevant matcher state covered by hashes.
+type policyHeader struct {
+	defaultAction   Action
+	dialect         Dialect
+	caseInsensitive bool
+	firstMatch      bool
+	posixSeparators bool
+	explicitDefault bool
+	strictPaths     bool
+	maxPathLength   int
+}
+
+// HashRules returns a stable hex SHA-256 fingerprint of the policy that
+// rules compiled with opts apply.
+//
+// Only decision-relevant data is covered: rule patterns, actions, syntax,
+// priorities, tags and metadata predicates in order, and matcher options
+// changing decisions. Rule.Source, Rule.Line, memo, trace, coverage and
+// action handlers are ignored. Equal hashes mean equal decisions for
+// Decide, DecideTagged and DecideMeta.
+func HashRules(rules []Rule, opts MatcherOptions) string {
+	opts.applyDefaults()
+	if opts.Profile != "" {
+		rules = SelectSections(rules, opts.Profile)
+	}
+
+	header := policyHeader{
+		defaultAction:   opts.DefaultAction,
+		dialect:         opts.Dialect,
+		caseInsensitive: opts.CaseInsensitive,
+		firstMatch:      opts.Policy == PolicyFirstMatchWins,
+		posixSeparators: opts.PathSeparators == PathSeparatorPOSIX,
+		strictPaths:     opts.StrictPaths,
+		maxPathLength:   max(opts.MaxPathLength, 0),
+	}
+
+	buf := appendPolicyHeader([]byte(hashVersion), header, len(rules))
+	for i := range rules {
+		foldCase := opts.SmartCase && !opts.CaseInsensitive && !hasASCIIUpper(rules[i].Pattern)
+		buf = appendPolicyRule(buf, &rules[i], foldCase)
+	}
+
+	return hashHex(buf)
+}
+
+// HashRules returns a stable fingerprint of BaseRules, FinalRules and every
+// rules file loaded so far (ancestors above root included), keyed by
+// directory. Call Warmup first to cover the whole tree; directories failing
+// to load are not covered.
+func (p *Provider) HashRules() (string, error) {
+	if p == nil {
+		return "", ErrNilProvider
+	}
+
+	p.cache.mu.Lock()
+	dirs := make(map[string]*Matcher, len(p.cache.entries))
+	for relDir, cached := range p.cache.entries {
+		if !cached.loading && cached.matcher != nil {
+			dirs[relDir] = cached.matcher
+		}
+	}
+	p.cache.mu.Unlock()
+
+	for _, ancestor := range p.ancestors {
+		if ancestor.matcher != nil {
+			dirs[ancestor.prefix] = ancestor.matcher
+		}
+	}
+
+	buf := appendBinaryString([]byte(hashVersion), p.rulesFileName)
+	buf = append(buf, byte(p.rulesFormat))
+	buf = p.baseMatcher.appendPolicy(buf)
+	buf = p.finalMatcher.appendPolicy(buf)
+	for _, relDir := range slices.Sorted(maps.Keys(dirs)) {
+		buf = appendBinaryString(buf, relDir)
+		buf = dirs[relDir].appendPolicy(buf)
+	}
+
+	return hashHex(buf), nil
+}
+
+// appendPolicy appends the decision-relevant state of m.
+func (m *Matcher) appendPolicy(buf []byte) []byte {
+	if m == nil {
+		return append(buf, 0)
+	}
+
+	buf = appendPolicyHeader(append(buf, 1), policyHeader{
+		defaultAction:   m.defaultAction,
+		dialect:         m.dialect,
+		caseInsensitive: m.caseInsensitive,
+		firstMatch:      m.firstMatch,
+		posixSeparators: m.posixSeparators,
+		explicitDefault: m.explicitDefault,
+		strictPaths:     m.strictPaths,
+		maxPathLength:   m.maxPathLength,
+	}, m.ruleCount)
+
+	for i := range m.ruleCount {
+		cr := &m.compiled[m.compiledIndex(i)]
+		buf = appendPolicyRule(buf, &cr.source, cr.foldCase)
+	}
+
+	return buf
+}
+
+// appendPolicyHeader appends matcher state and user rule count.
+func appendPolicyHeader(buf []byte, h policyHeader, rules int) []byte {
+	var flags byte
+	for i, set := range []bool{h.caseInsensitive, h.firstMatch, h.posixSeparators, h.explicitDefault, h.strictPaths} {
+		if set {
+			flags |= 1 << i
+		}
+	}
+
+	buf = append(buf, byte(h.defaultAction), byte(h.dialect), flags)
+	buf = binary.AppendUvarint(buf, uint64(h.maxPathLength))
+	return binary.AppendUvarint(buf, uint64(rules))
+}
+
+// appendPolicyRule appends the decision-relevant fields of one rule.
+func appendPolicyRule(buf []byte, r *Rule, foldCase bool) []byte {
+	buf = appendBinaryString(buf, r.Pattern)
+	buf = append(buf, byte(r.Action), byte(r.Syntax))
+	if foldCase {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+
+	buf = binary.AppendVarint(buf, int64(r.Priority))
+	buf = appendBinaryStrings(buf, r.Tags)
+	return appendBinaryMeta(buf, r.Meta)
+}
+
+// hashHex returns hex SHA-256 of data.
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+)
+
+func TestHashRules(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionExclude, Pattern: "*.tmp", Source: "a", Line: 1},
+		{Action: ActionInclude, Pattern: "keep.tmp", Tags: []string{"ci"}},
+	}
+
+	base := HashRules(rules, MatcherOptions{})
+	if len(base) != 64 {
+		t.Fatalf("HashRules=%q, want 64 hex digits", base)
+	}
+
+	same := []Rule{
+		{Action: ActionExclude, Pattern: "*.tmp"},
+		{Action: ActionInclude, Pattern: "keep.tmp", Tags: []string{"ci"}},
+	}
+	if got := HashRules(same, MatcherOptions{MemoSize: 64, DefaultAction: ActionInclude}); got != base {
+		t.Fatalf("hash changed by Source/Line or non-decision options")
+	}
+
+	changed := map[string]string{
+		"order":    HashRules([]Rule{rules[1], rules[0]}, MatcherOptions{}),
+		"action":   HashRules([]Rule{rules[0], {Action: ActionExclude, Pattern: "keep.tmp", Tags: []string{"ci"}}}, MatcherOptions{}),
+		"case":     HashRules(rules, MatcherOptions{CaseInsensitive: true}),
+		"dialect":  HashRules(rules, MatcherOptions{Dialect: DialectGit}),
+		"strict":   HashRules(rules, MatcherOptions{StrictPaths: true}),
+		"default":  HashRules(rules, MatcherOptions{DefaultAction: ActionExclude}),
+		"priority": HashRules([]Rule{rules[0], {Action: ActionInclude, Pattern: "keep.tmp", Tags: []string{"ci"}, Priority: 1}}, MatcherOptions{}),
+	}
+
+	for name, got := range changed {
+		if got == base {
+			t.Fatalf("%s change kept hash %s", name, base)
+		}
+	}
+}
+
+func TestProviderHashRules(t *testing.T) {
+	t.Parallel()
+
+	newProvider := func(sub string) *Provider {
+		p, err := NewProviderFS(fstest.MapFS{
+			".pathrules":   {Data: []byte("*.tmp\n")},
+			"a/.pathrules": {Data: []byte(sub)},
+			"a/b/file.txt": {Data: []byte("x")},
+		}, ProviderOptions{})
+		if err != nil {
+			t.Fatalf("NewProviderFS: %v", err)
+		}
+
+		if err := p.Warmup(context.Background(), -1); err != nil {
+			t.Fatalf("Warmup: %v", err)
+		}
+
+		return p
+	}
+
+	first, err := newProvider("!keep.tmp\n").HashRules()
+	if err != nil {
+		t.Fatalf("HashRules: %v", err)
+	}
+
+	second, _ := newProvider("!keep.tmp\n").HashRules()
+	other, _ := newProvider("!other.tmp\n").HashRules()
+	if first != second || first == other {
+		t.Fatalf("hashes first=%s second=%s other=%s, want stable and content-sensitive", first, second, other)
+	}
+}
@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ParseHgignore parses Mercurial ".hgignore" rules from reader.
+//
+// Semantics:
+//   - "syntax: regexp" (or "re", "relre"), "syntax: glob" (or "relglob"),
+//     "syntax: rootglob" and "syntax: path" switch syntax for following
+//     lines; regexp is the default
+//   - the same names followed by ":" override syntax for one line
+//   - "#" starts a comment, "\#" is a literal "#"
+//   - every rule is an exclude rule; rootglob rules are anchored with "/"
+//   - "path:dir" excludes the root-relative path dir and everything below it
+//
+// Regexp and path rules get Syntax PatternRegexp. "include:" and
+// "subinclude:" lines are rejected with ErrInvalidRule, "relpath:" and
+// "rootfilesin:" lines with ErrInvalidPattern. Match the rules with
+// DialectHg. All invalid lines are reported as *ParseError.
+func ParseHgignore(r io.Reader, sourceName string) ([]Rule, error) {
+	s := bufio.NewScanner(r)
+	rules := make([]Rule, 0, 16)
+	syntax := "regexp"
+	lineNo := 0
+
+	var lineErrs []*RuleError
+	for s.Scan() {
+		lineNo++
+		line := strings.TrimRight(stripHgComment(s.Text()), " \t\r")
+		if line == "" {
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(line, "syntax:"); ok {
+			name = strings.TrimSpace(name)
+			if _, known := hgSyntaxName(name); !known {
+				lineErrs = append(lineErrs, &RuleError{
+					File:    sourceName,
+					Line:    lineNo,
+					Pattern: line,
+					Err:     fmt.Errorf("%w: unknown hgignore syntax %q", ErrInvalidRule, name),
+				})
+				continue
+			}
+
+			syntax = name
+			continue
+		}
+
+		lineSyntax := syntax
+		if prefix, rest, ok := strings.Cut(line, ":"); ok {
+			if _, known := hgSyntaxName(prefix); known {
+				lineSyntax, line = prefix, rest
+			} else if prefix == "include" || prefix == "subinclude" {
+				lineErrs = append(lineErrs, &RuleError{
+					File:    sourceName,
+					Line:    lineNo,
+					Pattern: line,
+					Err:     fmt.Errorf("%w: unsupported hgignore %q", ErrInvalidRule, prefix),
+				})
+				continue
+			} else if prefix == "relpath" || prefix == "rootfilesin" {
+				lineErrs = append(lineErrs, &RuleError{
+					File:    sourceName,
+					Line:    lineNo,
+					Pattern: line,
+					Err:     fmt.Errorf("%w: unsupported hgignore prefix %q", ErrInvalidPattern, prefix+":"),
+				})
+				continue
+			}
+		}
+
+		if line == "" {
+			continue
+		}
+
+		canonical, _ := hgSyntaxName(lineSyntax)
+		rule := Rule{
+			Action:  ActionExclude,
+			Pattern: line,
+			Source:  sourceName,
+			Line:    lineNo,
+		}
+
+		switch canonical {
+		case "regexp":
+			rule.Syntax = PatternRegexp
+		case "rootglob":
+			rule.Pattern = "/" + strings.TrimPrefix(line, "/")
+		case "path":
+			rule.Syntax = PatternRegexp
+			rule.Pattern = hgPathRegexp(line)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("scan hgignore: %w", err)
+	}
+
+	if len(lineErrs) > 0 {
+		return nil, &ParseError{Errors: lineErrs}
+	}
+
+	return rules, nil
+}
+
+// hgSyntaxName returns canonical hgignore syntax name. In hgignore files
+// glob and regexp patterns are already unrooted, so "relglob" and "relre"
+// are their aliases.
+func hgSyntaxName(name string) (string, bool) {
+	switch name {
+	case "re", "regexp", "relre":
+		return "regexp", true
+	case "glob", "relglob":
+		return "glob", true
+	case "rootglob", "path":
+		return name, true
+	default:
+		return "", false
+	}
+}
+
+// hgPathRegexp returns a regexp matching root-relative path p and its contents;
+// "." and "" match everything.
+func hgPathRegexp(p string) string {
+	p = cleanSlashPath(p)
+	if p == "" {
+		return "^"
+	}
+
+	return "^" + regexp.QuoteMeta(p) + "(?:/|$)"
+}
+
+// stripHgComment removes unescaped "#" comment and unescapes "\#".
+func stripHgComment(line string) string {
+	if !strings.Contains(line, "#") {
+		return line
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' && i+1 < len(line) && line[i+1] == '#' {
+			b.WriteByte('#')
+			i++
+			continue
+		}
+
+		if line[i] == '#' {
+			break
+		}
+
+		b.WriteByte(line[i])
+	}
+
+	return b.String()
+}
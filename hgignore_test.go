@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseHgignore(t *testing.T) {
+	t.Parallel()
+
+	src := strings.Join([]string{
+		`\.orig$  # default regexp syntax`,
+		"syntax: glob",
+		"*.pyc",
+		`issue\#1`,
+		"re:^build/",
+		"rootglob:dist",
+		"syntax: rootglob",
+		"out",
+	}, "\n")
+
+	rules, err := ParseHgignore(strings.NewReader(src), ".hgignore")
+	if err != nil {
+		t.Fatalf("ParseHgignore: %v", err)
+	}
+
+	want := []Rule{
+		{Action: ActionExclude, Pattern: `\.orig$`, Syntax: PatternRegexp, Source: ".hgignore", Line: 1},
+		{Action: ActionExclude, Pattern: "*.pyc", Source: ".hgignore", Line: 3},
+		{Action: ActionExclude, Pattern: "issue#1", Source: ".hgignore", Line: 4},
+		{Action: ActionExclude, Pattern: "^build/", Syntax: PatternRegexp, Source: ".hgignore", Line: 5},
+		{Action: ActionExclude, Pattern: "/dist", Source: ".hgignore", Line: 6},
+		{Action: ActionExclude, Pattern: "/out", Source: ".hgignore", Line: 8},
+	}
+
+	if len(rules) != len(want) {
+		t.Fatalf("rules=%+v, want %+v", rules, want)
+	}
+
+	for i := range want {
+		if !reflect.DeepEqual(rules[i], want[i]) {
+			t.Fatalf("rules[%d]=%+v, want %+v", i, rules[i], want[i])
+		}
+	}
+}
+
+func TestParseHgignoreRejectsUnsupported(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseHgignore(strings.NewReader("syntax: pcre\ninclude:other\nsubinclude:sub/.hgignore\n"), "")
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || len(parseErr.Errors) != 3 || !errors.Is(err, ErrInvalidRule) {
+		t.Fatalf("err=%v, want three ErrInvalidRule line errors", err)
+	}
+
+	_, err = ParseHgignore(strings.NewReader("relpath:a\nrootfilesin:b\n"), "")
+	if !errors.As(err, &parseErr) || len(parseErr.Errors) != 2 || !errors.Is(err, ErrInvalidPattern) ||
+		!strings.Contains(err.Error(), `"rootfilesin:"`) {
+		t.Fatalf("err=%v, want two ErrInvalidPattern line errors naming the prefix", err)
+	}
+}
+
+func TestDialectHgPrefixes(t *testing.T) {
+	t.Parallel()
+
+	rules, err := ParseHgignore(strings.NewReader("relglob:*.log\nrelre:\\.bak$\npath:build\nsyntax: path\ndocs/gen\n"), "")
+	if err != nil {
+		t.Fatalf("ParseHgignore: %v", err)
+	}
+
+	m, err := NewMatcher(rules, MatcherOptions{Dialect: DialectHg})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	cases := map[string]bool{
+		"sub/x.log":     true,
+		"a/b.bak":       true,
+		"build":         true,
+		"build/y":       true,
+		"buildx/y":      false,
+		"src/build/y":   false,
+		"docs/gen/a.md": true,
+		"docs/general":  false,
+	}
+
+	for path, want := range cases {
+		if got := m.Excluded(path, false); got != want {
+			t.Errorf("Excluded(%q)=%v, want %v", path, got, want)
+		}
+	}
+
+	if rules, err := ParseHgignore(strings.NewReader("path:.\n"), ""); err != nil {
+		t.Fatalf("ParseHgignore: %v", err)
+	} else if m, _ := NewMatcher(rules, MatcherOptions{Dialect: DialectHg}); !m.Excluded("any/file", false) {
+		t.Fatal("path:. must exclude everything")
+	}
+}
+
+func TestDialectHgMatching(t *testing.T) {
+	t.Parallel()
+
+	rules, err := ParseHgignore(strings.NewReader("\\.o$\n^tmp/cache\nsyntax: glob\n*.pyc\nnode_modules\nrootglob:dist\n"), "")
+	if err != nil {
+		t.Fatalf("ParseHgignore: %v", err)
+	}
+
+	m, err := NewMatcher(rules, MatcherOptions{Dialect: DialectHg})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	cases := map[string]bool{
+		"a/b.o":              true,
+		"a/b.obj":            false,
+		"tmp/cache/x":        true,
+		"tmp/cachex":         true,
+		"x/tmp/cache":        false,
+		"src/mod.pyc":        true,
+		"web/node_modules/a": true,
+		"dist/app.js":        true,
+		"src/dist/app.js":    false,
+		"README":             false,
+	}
+
+	for path, want := range cases {
+		if got := m.Excluded(path, false); got != want {
+			t.Errorf("Excluded(%q)=%v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestPatternRegexpSyntax(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher([]Rule{
+		{Action: ActionExclude, Pattern: `^Build/`, Syntax: PatternRegexp},
+	}, MatcherOptions{CaseInsensitive: true})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if !m.Excluded("build/out/a.txt", false) || m.Excluded("src/build/a.txt", false) {
+		t.Fatalf("unexpected regexp syntax decisions")
+	}
+
+	if _, err := NewMatcher([]Rule{{Action: ActionExclude, Pattern: "(", Syntax: PatternRegexp}}, MatcherOptions{}); !errors.Is(err, ErrInvalidPattern) {
+		t.Fatalf("err=%v, want ErrInvalidPattern", err)
+	}
+
+	if got := CheckRules([]Rule{{Action: ActionExclude, Pattern: "[", Syntax: PatternRegexp}}); len(got) != 1 || got[0].Code != DiagInvalidRegexp {
+		t.Fatalf("CheckRules=%+v, want one invalid-regexp diagnostic", got)
+	}
+}
+
+func TestProviderHgignoreFormat(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, ".hgignore"), "syntax: glob\n*.log\n")
+	if err := os.MkdirAll(filepath.Join(root, "sub"), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	writeRulesFile(t, filepath.Join(root, "sub", ".hgignore"), "^gen/\n")
+
+	p, err := NewProvider(root, ProviderOptions{
+		RulesFileName:  ".hgignore",
+		RulesFormat:    RulesFormatHgignore,
+		MatcherOptions: MatcherOptions{Dialect: DialectHg},
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	cases := map[string]bool{
+		"a.log":        false,
+		"sub/gen/a.go": false,
+		"gen/a.go":     true,
+		"sub/a.go":     true,
+	}
+
+	for path, want := range cases {
+		if got, err := p.Included(path, false); err != nil || got != want {
+			t.Fatalf("Included(%q)=%v err=%v, want %v", path, got, err, want)
+		}
+	}
+}
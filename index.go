@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "strings"
+
+// literalIndexMinRules is the number of anchored literal rules worth indexing.
+const literalIndexMinRules = 16
+
+// literalIndex indexes anchored literal rules ("/a/b", "/a/b/", "/a/b/**")
+// in a path component trie so Decide does not test them one by one.
+type literalIndex struct {
+	// root is the trie node of the empty path.
+	root *literalNode
+	// scan lists compiled rule indexes not served by the trie, ascending.
+	scan []int
+}
+
+// literalNode is one path component of indexed rules.
+type literalNode struct {
+	// children maps next path component to its node.
+	children map[string]*literalNode
+	// exact is the last rule matching this path only, -1 when none.
+	exact int
+	// self is the last rule matching this path and its descendants, -1 when none.
+	self int
+	// below is the last rule matching descendants of this path only, -1 when none.
+	below int
+}
+
+// newLiteralIndex builds the index for compiled rules, nil when too few rules are literal.
+func newLiteralIndex(compiled []compiledRule) *literalIndex {
+	literal := 0
+	for i := range compiled {
+		if _, _, ok := literalRulePath(&compiled[i]); ok {
+			literal++
+		}
+	}
+
+	if literal < literalIndexMinRules {
+		return nil
+	}
+
+	idx := &literalIndex{
+		root: newLiteralNode(),
+		scan: make([]int, 0, len(compiled)-literal),
+	}
+
+	for i := range compiled {
+		rulePath, kind, ok := literalRulePath(&compiled[i])
+		if !ok {
+			idx.scan = append(idx.scan, i)
+			continue
+		}
+
+		node := idx.root
+		for part := range strings.SplitSeq(rulePath, "/") {
+			child := node.children[part]
+			if child == nil {
+				child = newLiteralNode()
+				node.children[part] = child
+			}
+
+			node = child
+		}
+
+		// Rules are added in order, so the latest index is the last matching rule.
+		switch kind {
+		case literalExact:
+			node.exact = i
+		case literalSelf:
+			node.self = i
+		case literalBelow:
+			node.below = i
+		}
+	}
+
+	return idx
+}
+
+// newLiteralNode returns an empty trie node.
+func newLiteralNode() *literalNode {
+	return &literalNode{
+		children: make(map[string]*literalNode),
+		exact:    -1,
+		self:     -1,
+		below:    -1,
+	}
+}
+
+// lookup returns the last indexed rule matching candidate, -1 when none.
+func (idx *literalIndex) lookup(candidate string) int {
+	best := -1
+	node := idx.root
+	for start := 0; start < len(candidate); {
+		end := strings.IndexByte(candidate[start:], '/')
+		last := end < 0
+		if last {
+			end = len(candidate)
+		} else {
+			end += start
+		}
+
+		node = node.children[candidate[start:end]]
+		if node == nil {
+			break
+		}
+
+		best = max(best, node.self)
+		if last {
+			best = max(best, node.exact)
+			break
+		}
+
+		best = max(best, node.below)
+		start = end + 1
+	}
+
+	return best
+}
+
+// literalRuleKind is how an indexed rule relates to its trie node.
+type literalRuleKind uint8
+
+const (
+	// literalExact matches the node path only ("/a/b").
+	literalExact literalRuleKind = iota
+	// literalSelf matches the node path and descendants ("/a/b/").
+	literalSelf
+	// literalBelow matches descendants only ("/a/b/**").
+	literalBelow
+)
+
+// literalRulePath returns indexed path and kind of an anchored literal rule.
+func literalRulePath(r *compiledRule) (string, literalRuleKind, bool) {
+	if !r.anchored || r.requireDir || r.searchRE != nil {
+		return "", 0, false
+	}
+
+	if r.pathExact != "" {
+		if r.dirOnly {
+			return r.pathExact, literalSelf, true
+		}
+
+		return r.pathExact, literalExact, true
+	}
+
+	if len(r.pathPrefixSegments) == 0 {
+		return "", 0, false
+	}
+
+	parts := make([]string, len(r.pathPrefixSegments))
+	for i, segment := range r.pathPrefixSegments {
+		if segment.wildcard {
+			return "", 0, false
+		}
+
+		parts[i] = segment.text
+	}
+
+	return strings.Join(parts, "/"), literalBelow, true
+}
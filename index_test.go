@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLiteralIndexMatchesLinearScan(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{{Action: ActionExclude, Pattern: "*.tmp"}}
+	for i := range 40 {
+		action := ActionExclude
+		if i%3 == 0 {
+			action = ActionInclude
+		}
+
+		switch i % 4 {
+		case 0:
+			rules = append(rules, Rule{Action: action, Pattern: fmt.Sprintf("/gen/m%02d/out.bin", i%10)})
+		case 1:
+			rules = append(rules, Rule{Action: action, Pattern: fmt.Sprintf("/gen/m%02d/", i%10)})
+		case 2:
+			rules = append(rules, Rule{Action: action, Pattern: fmt.Sprintf("/gen/m%02d/**", i%10)})
+		default:
+			rules = append(rules, Rule{Action: action, Pattern: fmt.Sprintf("m%02d/*.bin", i%10)})
+		}
+	}
+	rules = append(rules, Rule{Action: ActionInclude, Pattern: "/gen/M01/keep.tmp"})
+
+	paths := []string{"gen", "gen/x.tmp", "other/out.bin", "gen/m01/keep.tmp"}
+	for i := range 10 {
+		paths = append(paths,
+			fmt.Sprintf("gen/m%02d", i),
+			fmt.Sprintf("gen/m%02d/out.bin", i),
+			fmt.Sprintf("gen/m%02d/a/b.tmp", i),
+			fmt.Sprintf("gen/m%02d/c.bin", i),
+		)
+	}
+
+	for _, opts := range []MatcherOptions{{}, {CaseInsensitive: true}, {Dialect: DialectGit}} {
+		m, err := NewMatcher(rules, opts)
+		if err != nil {
+			t.Fatalf("NewMatcher: %v", err)
+		}
+
+		if m.index == nil {
+			t.Fatalf("index not built for %+v", opts)
+		}
+
+		linear := *m
+		linear.index = nil
+		for _, path := range paths {
+			for _, isDir := range []bool{false, true} {
+				got, want := m.Decide(path, isDir), linear.Decide(path, isDir)
+				if got != want {
+					t.Fatalf("opts %+v: Decide(%q, %v)=%+v, want %+v", opts, path, isDir, got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"context"
+	"fmt"
+)
+
+// OperationKind names an instrumented Provider operation.
+type OperationKind uint8
+
+const (
+	// OperationDecide is one Provider.Decide call.
+	OperationDecide OperationKind = iota
+	// OperationDecideInDir is one Provider.DecideInDir call.
+	OperationDecideInDir
+	// OperationLoadRules is one rules file (or RulesSource) load, started
+	// with the context of the operation that needed it.
+	OperationLoadRules
+)
+
+// String returns operation name.
+func (k OperationKind) String() string {
+	switch k {
+	case OperationDecide:
+		return "decide"
+	case OperationDecideInDir:
+		return "decide-in-dir"
+	case OperationLoadRules:
+		return "load-rules"
+	default:
+		return fmt.Sprintf("operation(%d)", uint8(k))
+	}
+}
+
+// Operation describes one instrumented Provider operation.
+type Operation struct {
+	// Path is the path argument of Decide or DecideInDir as given, or the
+	// root-relative directory of OperationLoadRules, "" for root.
+	Path string
+	// Entries is the entry count of OperationDecideInDir.
+	Entries int
+	// Kind is the operation.
+	Kind OperationKind
+}
+
+// OperationEnd is the outcome of one instrumented operation.
+type OperationEnd struct {
+	// Err is the operation error, nil on success.
+	Err error
+	// Rules is the number of rules loaded by OperationLoadRules, 0 when the
+	// directory has no rules file.
+	Rules int
+	// Included is the OperationDecide decision.
+	Included bool
+}
+
+// Instrumentation observes Provider operations, for tracing and metrics.
+// It must be safe for concurrent use.
+type Instrumentation interface {
+	// Start is called when op begins, with the context set by
+	// Provider.WithContext or returned by Start of the enclosing operation.
+	// It returns the context for operations nested in op, and a function
+	// that, when not nil, is called once with the outcome when op ends.
+	Start(ctx context.Context, op Operation) (context.Context, func(OperationEnd))
+}
+
+// WithContext returns a view of p passing ctx to ProviderOptions.Instrumentation,
+// so spans of its operations join the caller's trace. Compiled matchers are
+// shared with p; the view is meant for one request, do not keep it.
+func (p *Provider) WithContext(ctx context.Context) *Provider {
+	if p == nil {
+		return nil
+	}
+
+	view := *p
+	view.ctx = ctx
+	return &view
+}
+
+// instrument starts op with the configured instrumentation and returns the
+// view to run op on, carrying the context for nested operations, and its end
+// function, nil when there is no instrumentation.
+func (p *Provider) instrument(op Operation) (*Provider, func(OperationEnd)) {
+	if p.instrumentation == nil {
+		return p, nil
+	}
+
+	ctx := p.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	nested, end := p.instrumentation.Start(ctx, op)
+	if nested == nil {
+		return p, end
+	}
+
+	return p.WithContext(nested), end
+}
+
+// loadedRules returns the rule count of a loaded matcher, 0 for nil.
+func loadedRules(m *Matcher) int {
+	if m == nil {
+		return 0
+	}
+
+	return m.ruleCount
+}
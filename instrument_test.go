@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+// recordedOperation is one operation seen by recordingInstrumentation.
+type recordedOperation struct {
+	ctx context.Context
+	op  Operation
+	end OperationEnd
+}
+
+// recordingInstrumentation records every finished operation.
+type recordingInstrumentation struct {
+	ops []recordedOperation
+	mu  sync.Mutex
+}
+
+func (r *recordingInstrumentation) Start(ctx context.Context, op Operation) (context.Context, func(OperationEnd)) {
+	nested := context.WithValue(ctx, parentKey{}, op.Kind)
+	return nested, func(end OperationEnd) {
+		r.mu.Lock()
+		r.ops = append(r.ops, recordedOperation{ctx: ctx, op: op, end: end})
+		r.mu.Unlock()
+	}
+}
+
+type (
+	ctxKey    struct{}
+	parentKey struct{}
+)
+
+func TestProviderInstrumentation(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":     {Data: []byte("*.tmp\n*.log\n")},
+		"bad/.pathrules": {Data: []byte("#pragma default=bogus\n")},
+	}
+
+	rec := &recordingInstrumentation{}
+	p, err := NewProviderFS(fsys, ProviderOptions{Instrumentation: rec})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+	view := p.WithContext(ctx)
+	if res, err := view.Decide("src/a.tmp", false); err != nil || res.Included {
+		t.Fatalf("Decide=%+v err=%v, want excluded", res, err)
+	}
+
+	if _, err := view.DecideInDir("docs/guide", []DirEntry{{Name: "a.md"}, {Name: "b.tmp"}}); err != nil {
+		t.Fatalf("DecideInDir: %v", err)
+	}
+
+	if _, err := p.Decide("bad/a.txt", false); !errors.Is(err, ErrInvalidDirective) {
+		t.Fatalf("Decide err=%v, want ErrInvalidDirective", err)
+	}
+
+	byKind := make(map[OperationKind][]recordedOperation)
+	for _, r := range rec.ops {
+		byKind[r.op.Kind] = append(byKind[r.op.Kind], r)
+	}
+
+	decides := byKind[OperationDecide]
+	if len(decides) != 2 || decides[0].op.Path != "src/a.tmp" || decides[0].end.Included || decides[0].ctx.Value(ctxKey{}) != "request" {
+		t.Fatalf("decide operations=%+v, want src/a.tmp excluded with request context", decides)
+	}
+
+	if !errors.Is(decides[1].end.Err, ErrInvalidDirective) || decides[1].ctx.Value(ctxKey{}) != nil {
+		t.Fatalf("decide operation=%+v, want error with background context", decides[1])
+	}
+
+	inDir := byKind[OperationDecideInDir]
+	if len(inDir) != 1 || inDir[0].op.Path != "docs/guide" || inDir[0].op.Entries != 2 || inDir[0].end.Err != nil {
+		t.Fatalf("decide-in-dir operations=%+v, want docs/guide with 2 entries", inDir)
+	}
+
+	loads := make(map[string]OperationEnd)
+	for _, r := range byKind[OperationLoadRules] {
+		loads[r.op.Path] = r.end
+	}
+
+	if end, ok := loads[""]; !ok || end.Rules != 2 || end.Err != nil {
+		t.Fatalf("root load=%+v ok=%v, want 2 rules", end, ok)
+	}
+
+	for _, r := range byKind[OperationLoadRules] {
+		if r.ctx.Value(parentKey{}) == nil {
+			t.Fatalf("load %q context=%v, want the context of the enclosing operation", r.op.Path, r.ctx)
+		}
+	}
+
+	if end, ok := loads["bad"]; !ok || !errors.Is(end.Err, ErrInvalidDirective) {
+		t.Fatalf("bad load=%+v ok=%v, want ErrInvalidDirective", end, ok)
+	}
+
+	if got := OperationLoadRules.String(); got != "load-rules" {
+		t.Fatalf("String()=%q, want load-rules", got)
+	}
+}
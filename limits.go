@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// checkPatternLimits verifies glob pattern complexity against matcher options
+// before the rule is compiled.
+func checkPatternLimits(rule Rule, opts *MatcherOptions) error {
+	if rule.Syntax != PatternGlob {
+		return nil
+	}
+
+	if limit := opts.MaxDoubleStars; limit > 0 {
+		if n := strings.Count(rule.Pattern, "**"); n > limit {
+			return fmt.Errorf("%w: %q has %d \"**\" segments, limit %d", ErrPatternTooComplex, rule.Pattern, n, limit)
+		}
+	}
+
+	if limit := opts.MaxCharClasses; limit > 0 {
+		if n := countCharClasses(rule.Pattern); n > limit {
+			return fmt.Errorf("%w: %q has %d char classes, limit %d", ErrPatternTooComplex, rule.Pattern, n, limit)
+		}
+	}
+
+	return nil
+}
+
+// checkRegexpSize verifies the size of regexp expr generated for pattern
+// against limit before it is compiled, 0 disables.
+func checkRegexpSize(pattern string, expr string, limit int) error {
+	if limit > 0 && len(expr) > limit {
+		return fmt.Errorf("%w: %q generates a %d byte regexp, limit %d",
+			ErrPatternTooComplex, pattern, len(expr), limit)
+	}
+
+	return nil
+}
+
+// countCharClasses returns the number of well-formed char classes in pattern.
+func countCharClasses(pattern string) int {
+	n := 0
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '[' {
+			continue
+		}
+
+		if end := findCharClassEnd(pattern, i); end >= 0 {
+			n++
+			i = end
+		}
+	}
+
+	return n
+}
+
+// overPathLimit reports whether candidate exceeds MatcherOptions.MaxPathLength.
+func (m *Matcher) overPathLimit(candidate string) bool {
+	return m.maxPathLength > 0 && len(candidate) > m.maxPathLength
+}
+
+// readLimited reads r whose stat size is size, failing with
+// ErrRulesFileTooLarge above limit; 0 disables the limit.
+//
+// The stat size is checked first and reading stops after limit+1 bytes, so
+// files growing while read are caught too.
+func readLimited(r io.Reader, size, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+
+	if size > limit {
+		return nil, fmt.Errorf("%w: %d bytes, limit %d", ErrRulesFileTooLarge, size, limit)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(content)) > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrRulesFileTooLarge, limit)
+	}
+
+	return content, nil
+}
+
+// checkRulesCount verifies rules count of one rules file against MaxRulesPerFile.
+func (p *Provider) checkRulesCount(n int) error {
+	if p.maxRulesPerFile > 0 && n > p.maxRulesPerFile {
+		return fmt.Errorf("%w: %d rules, limit %d", ErrTooManyRules, n, p.maxRulesPerFile)
+	}
+
+	return nil
+}
+
+// checkHierarchyDepth verifies that rules of relDir may be loaded under MaxHierarchyDepth.
+func (p *Provider) checkHierarchyDepth(relDir string) error {
+	if p.maxHierarchyDepth <= 0 || relDir == "" {
+		return nil
+	}
+
+	if depth := strings.Count(relDir, "/") + 1; depth > p.maxHierarchyDepth {
+		return fmt.Errorf("%w: %q is %d levels deep, limit %d", ErrHierarchyTooDeep, relDir, depth, p.maxHierarchyDepth)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMatcherPatternLimits(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		rule Rule
+		opts MatcherOptions
+	}{
+		{rule: Rule{Action: ActionExclude, Pattern: "a/**/b/**/c/**/d"}, opts: MatcherOptions{MaxDoubleStars: 2}},
+		{rule: Rule{Action: ActionExclude, Pattern: "[a][b][c]*.go"}, opts: MatcherOptions{MaxCharClasses: 2}},
+		{rule: Rule{Action: ActionExclude, Pattern: "src/[a-z]*/" + strings.Repeat("x", 64)}, opts: MatcherOptions{MaxRegexpSize: 32}},
+		{rule: Rule{Action: ActionExclude, Pattern: strings.Repeat("(a|b)", 16), Syntax: PatternRegexp}, opts: MatcherOptions{MaxRegexpSize: 32}},
+	}
+
+	for _, tc := range cases {
+		if _, err := NewMatcher([]Rule{tc.rule}, tc.opts); !errors.Is(err, ErrPatternTooComplex) {
+			t.Fatalf("NewMatcher(%q)=%v, want ErrPatternTooComplex", tc.rule.Pattern, err)
+		}
+	}
+
+	// Oversized regexps are rejected before they are compiled and interned.
+	expr := strings.Repeat("(c|d)", 16)
+	if _, err := NewMatcher([]Rule{{Action: ActionExclude, Pattern: expr, Syntax: PatternRegexp}}, MatcherOptions{MaxRegexpSize: 32}); !errors.Is(err, ErrPatternTooComplex) {
+		t.Fatalf("NewMatcher(%q)=%v, want ErrPatternTooComplex", expr, err)
+	}
+
+	sharedRegexps.mu.Lock()
+	_, interned := sharedRegexps.entries[expr]
+	sharedRegexps.mu.Unlock()
+	if interned {
+		t.Fatalf("rejected regexp %q was compiled and interned", expr)
+	}
+
+	opts := MatcherOptions{MaxDoubleStars: 2, MaxCharClasses: 2, MaxRegexpSize: 64}
+	if _, err := NewMatcher([]Rule{{Action: ActionExclude, Pattern: "a/**/[bc]/*.go"}}, opts); err != nil {
+		t.Fatalf("NewMatcher within limits: %v", err)
+	}
+}
+
+func TestMatcherMaxPathLength(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher([]Rule{{Action: ActionInclude, Pattern: "**/*.go"}}, MatcherOptions{
+		DefaultAction: ActionInclude,
+		MaxPathLength: 16,
+	})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if res := m.Decide("src/main.go", false); !res.Included || !res.Matched {
+		t.Fatalf("Decide(short)=%+v, want included match", res)
+	}
+
+	long := strings.Repeat("d/", 16) + "main.go"
+	if res := m.Decide(long, false); res.Included || res.Matched || res.RuleIndex != -1 {
+		t.Fatalf("Decide(long)=%+v, want excluded without match", res)
+	}
+
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	var decoded Matcher
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	if decoded.Included(long, false) {
+		t.Fatalf("decoded matcher lost MaxPathLength")
+	}
+}
+
+func TestProviderLoadLimits(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":       {Data: []byte("*.tmp\n")},
+		"big/.pathrules":   {Data: []byte("# " + strings.Repeat("x", 64) + "\n")},
+		"many/.pathrules":  {Data: []byte("a\nb\nc\n")},
+		"a/b/c/.pathrules": {Data: []byte("!*.tmp\n")},
+		"a/b/.pathrules":   {Data: []byte("*.log\n")},
+		"ok/.pathrules":    {Data: []byte("!keep.tmp\n")},
+	}
+
+	p, err := NewProviderFS(fsys, ProviderOptions{
+		MaxRulesFileSize:  32,
+		MaxRulesPerFile:   2,
+		MaxHierarchyDepth: 2,
+	})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	cases := []struct {
+		path string
+		err  error
+	}{
+		{path: "big/a.txt", err: ErrRulesFileTooLarge},
+		{path: "many/a.txt", err: ErrTooManyRules},
+		{path: "a/b/c/a.tmp", err: ErrHierarchyTooDeep},
+	}
+
+	for _, tc := range cases {
+		if _, err := p.Included(tc.path, false); !errors.Is(err, tc.err) {
+			t.Fatalf("Included(%q) err=%v, want %v", tc.path, err, tc.err)
+		}
+	}
+
+	if included, err := p.Included("ok/keep.tmp", false); err != nil || !included {
+		t.Fatalf("Included(ok/keep.tmp)=%v err=%v, want included", included, err)
+	}
+
+	if included, err := p.Included("a/b/x.log", false); err != nil || included {
+		t.Fatalf("Included(a/b/x.log)=%v err=%v, want excluded", included, err)
+	}
+}
@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ruleShape is a normalized glob rule used by static rule analysis.
+type ruleShape struct {
+	// body is the normalized pattern without leading and trailing "/".
+	body string
+	// parts are body path components.
+	parts []string
+	// anchored means pattern starts with "/".
+	anchored bool
+	// dirOnly means pattern ends with "/".
+	dirOnly bool
+	// regexp marks regexp-syntax rules, compared only for identity.
+	regexp bool
+}
+
+// newRuleShape returns normalized shape of rule, false for rules that cannot be analyzed.
+func newRuleShape(rule Rule) (ruleShape, bool) {
+	// Rules with metadata predicates apply conditionally.
+	if !rule.Action.valid() || rule.Meta != nil {
+		return ruleShape{}, false
+	}
+
+	if rule.Syntax != PatternGlob {
+		return ruleShape{body: rule.Pattern, regexp: true}, true
+	}
+
+	pattern := normalizePattern(rule.Pattern)
+	shape := ruleShape{
+		anchored: strings.HasPrefix(pattern, "/"),
+		dirOnly:  strings.HasSuffix(pattern, "/"),
+		body:     strings.Trim(pattern, "/"),
+	}
+
+	if shape.body == "" {
+		return ruleShape{}, false
+	}
+
+	shape.parts = strings.Split(shape.body, "/")
+	return shape, true
+}
+
+// same reports whether both shapes match exactly the same paths.
+func (s *ruleShape) same(other *ruleShape) bool {
+	return s.body == other.body && s.anchored == other.anchored &&
+		s.dirOnly == other.dirOnly && s.regexp == other.regexp
+}
+
+// covers reports whether every path matched by inner is also matched by s
+// under DialectDefault semantics. False negatives are allowed, false
+// positives are not.
+func (s *ruleShape) covers(inner *ruleShape) bool {
+	if s.same(inner) {
+		return true
+	}
+
+	if s.regexp || inner.regexp {
+		return false
+	}
+
+	// "*", "**" and "/**" match every path.
+	if !s.dirOnly && (s.body == "**" || (s.body == "*" && !s.anchored)) {
+		return true
+	}
+
+	if len(s.parts) == 1 && !s.anchored {
+		return s.coversByComponent(inner)
+	}
+
+	if s.anchored && inner.anchored {
+		// "/dir/" and "/dir/**" cover anchored patterns below the literal "dir".
+		prefix, ok := strings.CutSuffix(s.body, "/**")
+		if !ok && !s.dirOnly {
+			return false
+		}
+
+		if !ok {
+			prefix = s.body
+		}
+
+		if patternHasGlobMeta(prefix) {
+			return false
+		}
+
+		if s.dirOnly && inner.body == prefix {
+			return true
+		}
+
+		return strings.HasPrefix(inner.body, prefix+"/")
+	}
+
+	return false
+}
+
+// coversByComponent reports whether unanchored single-component s covers inner.
+func (s *ruleShape) coversByComponent(inner *ruleShape) bool {
+	if patternHasCharClass(s.body) || strings.Contains(s.body, "**") {
+		return false
+	}
+
+	segment := newSegmentPattern(s.body)
+	last := inner.parts[len(inner.parts)-1]
+	if !s.dirOnly {
+		// "*.log" covers patterns whose last component is a literal "*.log" name.
+		return !inner.dirOnly && !patternHasGlobMeta(last) && matchSegmentPattern(segment, last)
+	}
+
+	// "node_modules/" covers patterns with a literal "node_modules" ancestor.
+	if segment.wildcard {
+		return false
+	}
+
+	for i, part := range inner.parts {
+		if part == s.body && (i < len(inner.parts)-1 || inner.dirOnly) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LintRules reports rules that can never decide a path.
+//
+// Analysis uses DialectDefault semantics and is conservative: only rules
+// provably covered by a later rule are reported.
+//   - DiagDuplicateRule: rule repeats an earlier rule with the same action
+//   - DiagShadowedRule: a later rule matches every path this rule matches,
+//     so this rule never wins
+func LintRules(rules []Rule) []Diagnostic {
+	var out []Diagnostic
+	for _, c := range findShadowedRules(rules) {
+		if c.same && rules[c.later].Action == rules[c.rule].Action {
+			out = append(out, lintDiagnostic(rules[c.later], c.later, DiagDuplicateRule,
+				fmt.Sprintf("duplicates rule %d", c.rule)))
+			continue
+		}
+
+		out = append(out, lintDiagnostic(rules[c.rule], c.rule, DiagShadowedRule,
+			fmt.Sprintf("never wins: rule %d (%q) matches every path it matches", c.later, rules[c.later].Pattern)))
+	}
+
+	return out
+}
+
+// shadowedRule is a rule covered by the first later rule matching all its paths.
+type shadowedRule struct {
+	// rule is the shadowed rule index.
+	rule int
+	// later is the covering rule index.
+	later int
+	// same reports whether both rules have identical patterns.
+	same bool
+}
+
+// findShadowedRules returns every rule covered by a later rule with at least
+// its priority, in rule order.
+func findShadowedRules(rules []Rule) []shadowedRule {
+	shapes := make([]ruleShape, len(rules))
+	valid := make([]bool, len(rules))
+	for i := range rules {
+		shapes[i], valid[i] = newRuleShape(rules[i])
+	}
+
+	var out []shadowedRule
+	for i := range rules {
+		if !valid[i] {
+			continue
+		}
+
+		for j := i + 1; j < len(rules); j++ {
+			// A later rule with lower priority never beats rule i.
+			if valid[j] && rules[j].Priority >= rules[i].Priority && shapes[j].covers(&shapes[i]) {
+				out = append(out, shadowedRule{rule: i, later: j, same: shapes[j].same(&shapes[i])})
+				break
+			}
+		}
+	}
+
+	return out
+}
+
+// lintDiagnostic builds one LintRules warning for rule at index.
+func lintDiagnostic(rule Rule, index int, code DiagnosticCode, msg string) Diagnostic {
+	return Diagnostic{
+		Code:      code,
+		Message:   msg,
+		Pattern:   rule.Pattern,
+		RuleIndex: index,
+		Offset:    -1,
+		Severity:  SeverityWarning,
+	}
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "testing"
+
+func TestLintRules(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionExclude, Pattern: "logs/debug.log"},      // 0 shadowed by 2
+		{Action: ActionInclude, Pattern: "/build/keep.txt"},     // 1 shadowed by 3
+		{Action: ActionExclude, Pattern: "*.log"},               // 2
+		{Action: ActionExclude, Pattern: "/build/"},             // 3
+		{Action: ActionExclude, Pattern: "*.tmp"},               // 4
+		{Action: ActionInclude, Pattern: "keep.tmp"},            // 5
+		{Action: ActionExclude, Pattern: "*.tmp"},               // 6 duplicates 4, shadows 5
+		{Action: ActionExclude, Pattern: "a/node_modules/x.js"}, // 7 shadowed by 8
+		{Action: ActionExclude, Pattern: "node_modules/"},       // 8
+		{Action: ActionInclude, Pattern: "node_modules"},        // 9 file named node_modules is not covered
+		{Action: ActionInclude, Pattern: "/src/**"},             // 10
+		{Action: ActionExclude, Pattern: "/src"},                // 11 not covered by 10
+		{Action: ActionExclude, Pattern: "docs/*.md"},           // 12 wildcard last part is not covered
+		{Action: ActionInclude, Pattern: "*.md"},                // 13
+	}
+
+	want := map[int]DiagnosticCode{
+		0: DiagShadowedRule,
+		1: DiagShadowedRule,
+		5: DiagShadowedRule,
+		6: DiagDuplicateRule,
+		7: DiagShadowedRule,
+	}
+
+	diags := LintRules(rules)
+	got := make(map[int]DiagnosticCode, len(diags))
+	for _, d := range diags {
+		if _, dup := got[d.RuleIndex]; dup {
+			t.Fatalf("rule %d reported twice: %v", d.RuleIndex, diags)
+		}
+
+		got[d.RuleIndex] = d.Code
+	}
+
+	for index, code := range want {
+		if got[index] != code {
+			t.Errorf("rule %d: code %q, want %q (%v)", index, got[index], code, diags)
+		}
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("diagnostics=%v, want rules %v", diags, want)
+	}
+
+	if diags := LintRules([]Rule{{Action: ActionExclude, Pattern: "a"}, {Action: ActionInclude, Pattern: "**"}}); len(diags) != 1 {
+		t.Fatalf("catch-all: %v, want one shadowed rule", diags)
+	}
+}
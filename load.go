@@ -6,10 +6,13 @@ package pathrules
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 )
 
 // LoadRulesFile reads and parses rules from a file.
+//
+// Parsed rules record path as Source together with line numbers.
 func LoadRulesFile(path string) ([]Rule, error) {
 	f, err := os.Open(path)
 	if err != nil {
@@ -17,7 +20,7 @@ func LoadRulesFile(path string) ([]Rule, error) {
 	}
 	defer func() { _ = f.Close() }()
 
-	rules, err := ParseRules(f)
+	rules, err := ParseRulesNamed(f, path)
 	if err != nil {
 		return nil, fmt.Errorf("parse rules file: %w", err)
 	}
@@ -41,3 +44,37 @@ func LoadRulesFiles(paths ...string) ([]Rule, error) {
 
 	return out, nil
 }
+
+// LoadRulesFileFS reads and parses rules from a file in fsys.
+//
+// It works with embed.FS, zip readers, fstest.MapFS and other fs.FS
+// implementations. Parsed rules record path as Source.
+func LoadRulesFileFS(fsys fs.FS, path string) ([]Rule, error) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open rules file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	rules, err := ParseRulesNamed(f, path)
+	if err != nil {
+		return nil, fmt.Errorf("parse rules file: %w", err)
+	}
+
+	return rules, nil
+}
+
+// LoadRulesFilesFS reads and merges rules from files in fsys in the given order.
+func LoadRulesFilesFS(fsys fs.FS, paths ...string) ([]Rule, error) {
+	out := make([]Rule, 0, len(paths)*8)
+	for _, path := range paths {
+		rules, err := LoadRulesFileFS(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, rules...)
+	}
+
+	return out, nil
+}
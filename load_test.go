@@ -5,9 +5,12 @@
 package pathrules
 
 import (
+	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 )
 
 func TestLoadRulesFile(t *testing.T) {
@@ -31,6 +34,10 @@ func TestLoadRulesFile(t *testing.T) {
 	if rules[0].Action != ActionExclude || rules[1].Action != ActionInclude {
 		t.Fatalf("unexpected actions: %+v", rules)
 	}
+
+	if rules[1].Source != path || rules[1].Line != 2 {
+		t.Fatalf("rule[1] source=%q line=%d, want %q:2", rules[1].Source, rules[1].Line, path)
+	}
 }
 
 func TestLoadRulesFiles(t *testing.T) {
@@ -61,3 +68,29 @@ func TestLoadRulesFiles(t *testing.T) {
 		t.Fatalf("unexpected merged rules: %+v", rules)
 	}
 }
+
+func TestLoadRulesFilesFS(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"rules/a.rules": {Data: []byte("*.tmp\n")},
+		"rules/b.rules": {Data: []byte("# keep\n!keep.tmp\n")},
+	}
+
+	rules, err := LoadRulesFilesFS(fsys, "rules/a.rules", "rules/b.rules")
+	if err != nil {
+		t.Fatalf("LoadRulesFilesFS: %v", err)
+	}
+
+	if len(rules) != 2 || rules[0].Pattern != "*.tmp" || rules[1].Pattern != "keep.tmp" {
+		t.Fatalf("unexpected rules: %+v", rules)
+	}
+
+	if rules[1].Source != "rules/b.rules" || rules[1].Line != 2 {
+		t.Fatalf("rule[1] source=%q line=%d, want rules/b.rules:2", rules[1].Source, rules[1].Line)
+	}
+
+	if _, err := LoadRulesFileFS(fsys, "missing.rules"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("err=%v, want fs.ErrNotExist", err)
+	}
+}
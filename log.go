@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// logDebug writes one debug record to ProviderOptions.Logger when set.
+func (p *Provider) logDebug(msg string, attrs ...slog.Attr) {
+	if p.logger == nil {
+		return
+	}
+
+	p.logger.LogAttrs(context.Background(), slog.LevelDebug, msg, attrs...)
+}
+
+// logRulesLoad records one fresh load of the relDir rules file.
+func (p *Provider) logRulesLoad(relDir string, matcher *Matcher, err error) {
+	if p.logger == nil {
+		return
+	}
+
+	dir := slog.String("dir", relDir)
+	switch {
+	case errors.Is(err, ErrRulesPathOutsideRoot):
+		p.logDebug("rules file rejected by symlink escape check", dir, slog.Any("error", err))
+	case err != nil:
+		p.logDebug("rules file error", dir, slog.Any("error", err),
+			slog.String("policy", p.onRuleFileError.String()))
+	case matcher != nil && p.source != nil:
+		p.logDebug("rules loaded from source", dir, slog.Int("rules", matcher.ruleCount))
+	case matcher != nil:
+		p.logDebug("rules file loaded", dir, slog.String("file", p.rulesFilePath(relDir)),
+			slog.Int("rules", matcher.ruleCount))
+	}
+}
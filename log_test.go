@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProviderLogger(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, ".pathrules"), "*.tmp\n")
+	writeRulesFile(t, filepath.Join(root, "bad", ".pathrules"), "#pragma default=bogus\n")
+
+	var buf bytes.Buffer
+	p, err := NewProvider(root, ProviderOptions{
+		Logger:          slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		OnRuleFileError: RuleFileErrorSkipFile,
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if _, err := p.Decide("bad/a.txt", false); err != nil {
+		t.Fatalf("Decide: %v", err)
+	}
+
+	writeRulesFile(t, filepath.Join(root, ".pathrules"), "*.tmp\n*.log\n")
+	if _, err := p.Refresh(); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		`msg="rules file loaded" dir="" file=` + filepath.Join(root, ".pathrules"),
+		"rules=1",
+		`msg="rules file error" dir=bad`,
+		"policy=skip-file",
+		`msg="rules cache entry dropped" dir=""`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("log missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestProviderLoggerSymlinkEscape(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	outside := t.TempDir()
+	writeRulesFile(t, filepath.Join(outside, ".pathrules"), "*.tmp\n")
+
+	if err := os.Symlink(outside, filepath.Join(root, "linked")); err != nil {
+		t.Skipf("symlink not available: %v", err)
+	}
+
+	var buf bytes.Buffer
+	p, err := NewProvider(root, ProviderOptions{
+		EnableSymlinkEscapeCheck: true,
+		Logger:                   slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if _, err := p.Decide("linked/a.tmp", false); err == nil {
+		t.Fatal("Decide succeeded, want escape error")
+	}
+
+	if !strings.Contains(buf.String(), `msg="rules file rejected by symlink escape check" dir=linked`) {
+		t.Fatalf("log missing escape rejection:\n%s", buf.String())
+	}
+}
@@ -4,43 +4,264 @@
 
 package pathrules
 
+import (
+	"fmt"
+	"slices"
+)
+
 // Matcher evaluates path decisions against compiled ordered rules.
 type Matcher struct {
 	compiled        []compiledRule
 	defaultAction   Action
+	dialect         Dialect
 	caseInsensitive bool
+	// implicitBefore is the number of dialect default rules compiled before user rules.
+	implicitBefore int
+	// ruleCount is the number of user rules.
+	ruleCount int
+	// memo caches recent decisions, nil when MatcherOptions.MemoSize is 0.
+	memo *decisionMemo
+	// index serves anchored literal rules without a linear scan, nil for small rule sets.
+	index *literalIndex
+	// explicitDefault reports that defaultAction was declared by a rules file directive.
+	explicitDefault bool
+	// coverage counts rule wins and matches, nil unless MatcherOptions.TrackCoverage is set.
+	coverage *ruleCoverage
+	// trace receives every rule test, nil unless MatcherOptions.Trace is set.
+	trace func(TraceEvent)
+	// maxPriority is the highest rule priority.
+	maxPriority int
+	// prioritized reports that rules have different priorities.
+	prioritized bool
+	// metaRules reports that some rules have a metadata predicate.
+	metaRules bool
+	// foldRules reports that some rules were folded by MatcherOptions.SmartCase.
+	foldRules bool
+	// actionHandlers resolve custom rule actions, see MatcherOptions.ActionHandlers.
+	actionHandlers map[Action]ActionHandler
+	// firstMatch reports PolicyFirstMatchWins; user rules are compiled in reverse order.
+	firstMatch bool
+	// posixSeparators reports PathSeparatorPOSIX; "\" is kept in candidates.
+	posixSeparators bool
+	// maxPathLength excludes longer candidates without evaluation, 0 disables.
+	maxPathLength int
+	// resultRule sets MatchResult.Rule, see MatcherOptions.ResultRule.
+	resultRule bool
+	// strictPaths rejects empty, absolute and traversal candidate paths.
+	strictPaths bool
 }
 
 // NewMatcher compiles ordered rules into matcher.
 func NewMatcher(rules []Rule, opts MatcherOptions) (*Matcher, error) {
 	opts.applyDefaults()
+	if !opts.Dialect.valid() {
+		return nil, fmt.Errorf("%w: unsupported dialect %d", ErrInvalidOptions, opts.Dialect)
+	}
 
-	compiled := make([]compiledRule, 0, len(rules))
-	for _, rule := range rules {
-		cr, err := compileRule(rule, opts.CaseInsensitive)
-		if err != nil {
-			return nil, err
-		}
+	if !opts.Policy.valid() {
+		return nil, fmt.Errorf("%w: unsupported policy %d", ErrInvalidOptions, opts.Policy)
+	}
+
+	if !opts.PathSeparators.valid() {
+		return nil, fmt.Errorf("%w: unsupported path separator policy %d", ErrInvalidOptions, opts.PathSeparators)
+	}
+
+	if opts.Profile != "" {
+		rules = SelectSections(rules, opts.Profile)
+	}
+
+	if err := checkActionHandlers(rules, opts.ActionHandlers); err != nil {
+		return nil, err
+	}
+
+	before, after := opts.Dialect.implicitRules()
+	user := rules
+	if opts.Policy == PolicyFirstMatchWins {
+		user = slices.Clone(rules)
+		slices.Reverse(user)
+	}
 
-		compiled = append(compiled, *cr)
+	compiled := make([]compiledRule, 0, len(before)+len(rules)+len(after))
+	for _, group := range [][]Rule{before, user, after} {
+		for _, rule := range group {
+			cr, err := compileRuleWithOptions(rule, &opts)
+			if err != nil {
+				return nil, &RuleError{Err: err, File: rule.Source, Pattern: rule.Pattern, Line: rule.Line}
+			}
+
+			compiled = append(compiled, *cr)
+		}
 	}
 
-	return &Matcher{
+	m := &Matcher{
 		compiled:        compiled,
 		defaultAction:   opts.DefaultAction,
+		dialect:         opts.Dialect,
 		caseInsensitive: opts.CaseInsensitive,
-	}, nil
+		implicitBefore:  len(before),
+		ruleCount:       len(rules),
+		memo:            newDecisionMemo(opts.MemoSize),
+		coverage:        newRuleCoverage(opts.TrackCoverage, len(rules)),
+		trace:           opts.Trace,
+		actionHandlers:  opts.ActionHandlers,
+		firstMatch:      opts.Policy == PolicyFirstMatchWins,
+		posixSeparators: opts.PathSeparators == PathSeparatorPOSIX,
+		maxPathLength:   max(opts.MaxPathLength, 0),
+		resultRule:      opts.ResultRule,
+		strictPaths:     opts.StrictPaths,
+	}
+
+	m.initRuleOrder()
+	return m, nil
+}
+
+// initRuleOrder sets up priority state and the literal index for compiled rules.
+//
+// The literal index assumes plain rule order and is not built for rules
+// with different priorities, metadata predicates or smart-case folding.
+func (m *Matcher) initRuleOrder() {
+	m.prioritized, m.maxPriority = rulePriorities(m.compiled)
+	m.metaRules = slices.ContainsFunc(m.compiled, func(r compiledRule) bool { return r.source.Meta != nil })
+	m.foldRules = slices.ContainsFunc(m.compiled, func(r compiledRule) bool { return r.foldCase })
+	if !m.prioritized && !m.metaRules && !m.foldRules {
+		m.index = newLiteralIndex(m.compiled)
+	}
 }
 
 // Decide returns deterministic include/exclude decision for one path.
 //
 // Decision policy:
-// - last matched rule wins
-// - if no rule matched, default action is used
+//   - last matched rule wins (first with PolicyFirstMatchWins), unless
+//     another one has higher Rule.Priority
+//   - if no rule matched, default action is used
+//   - with dialects other than DialectDefault, a path under a directory
+//     excluded by a rule is excluded
+//   - a dialect default rule match reports Matched with RuleIndex -1
+//   - rules with Rule.Meta never match, see DecideMeta
+//   - a custom action is resolved by its handler; it never excludes the
+//     contents of a matched directory
+//   - a path longer than MatcherOptions.MaxPathLength is excluded without
+//     a match
+//   - with MatcherOptions.StrictPaths, an empty, absolute or traversal
+//     path is excluded without a match, see DecideErr
+//
+// A trailing separator in path ("build/") implies isDir, see DirHint.
+//
+// With MatcherOptions.Trace set, every rule is tested in order and reported,
+// bypassing the decision memo.
 func (m *Matcher) Decide(path string, isDir bool) MatchResult {
-	candidate := normalizePath(path)
-	if m.caseInsensitive {
-		candidate = asciiLower(candidate)
+	if m.rejectsPath(path) {
+		return MatchResult{RuleIndex: -1}
+	}
+
+	return m.decidePath(path, isDir)
+}
+
+// decidePath is Decide for a path accepted by the strict path check.
+func (m *Matcher) decidePath(path string, isDir bool) MatchResult {
+	candidate := m.normalizeCandidate(path)
+	isDir = isDir || m.dirHint(path)
+
+	if m.memo == nil || m.trace != nil {
+		return m.resolveAction(candidate, isDir, m.decideTracked(candidate, isDir))
+	}
+
+	if res, ok := m.memo.get(candidate, isDir); ok {
+		if m.coverage != nil {
+			m.recordWin(res)
+		}
+
+		return m.resolveAction(candidate, isDir, res)
+	}
+
+	res := m.decideTracked(candidate, isDir)
+	m.memo.put(candidate, isDir, res)
+	return m.resolveAction(candidate, isDir, res)
+}
+
+// DecideAll returns the Decide result and indices of every user rule
+// matching path, in rules order, for audit listings.
+//
+// Rules affecting path only through parent exclusion (DialectGit and
+// similar) and dialect default rules are not listed. Every rule is tested,
+// so it is slower than Decide.
+func (m *Matcher) DecideAll(path string, isDir bool) (MatchResult, []int) {
+	if m.rejectsPath(path) {
+		return MatchResult{RuleIndex: -1}, nil
+	}
+
+	candidate := m.normalizeCandidate(path)
+	isDir = isDir || m.dirHint(path)
+
+	res := m.resolveAction(candidate, isDir, m.decideCandidate(candidate, isDir))
+	if m.overPathLimit(candidate) {
+		return res, nil
+	}
+
+	var matches []int
+	for i := range m.ruleCount {
+		if m.compiled[m.compiledIndex(i)].matches(candidate, isDir) {
+			matches = append(matches, i)
+		}
+	}
+
+	return res, matches
+}
+
+// decideTracked returns decideCandidate result and updates coverage counters when enabled.
+func (m *Matcher) decideTracked(candidate string, isDir bool) MatchResult {
+	res := m.decideCandidate(candidate, isDir)
+	if m.coverage != nil {
+		m.recordWin(res)
+		m.recordMatches(candidate, isDir)
+	}
+
+	return res
+}
+
+// decideCandidate returns decision for a normalized candidate including parent exclusion.
+func (m *Matcher) decideCandidate(candidate string, isDir bool) MatchResult {
+	if m.overPathLimit(candidate) {
+		return MatchResult{RuleIndex: -1}
+	}
+
+	if m.dialect.parentExclusion() {
+		for i := 0; i < len(candidate); i++ {
+			if candidate[i] != '/' {
+				continue
+			}
+
+			// Parent directories are checked top-down; the first excluded one wins.
+			if res := m.decideNormalized(candidate[:i], true); res.Matched && !res.Included {
+				return m.userResult(res)
+			}
+		}
+	}
+
+	return m.userResult(m.decideNormalized(candidate, isDir))
+}
+
+// userResult maps compiled rule index to user rule index, -1 for dialect default rules.
+func (m *Matcher) userResult(res MatchResult) MatchResult {
+	if res.RuleIndex < 0 {
+		return res
+	}
+
+	res.RuleIndex -= m.implicitBefore
+	switch {
+	case res.RuleIndex < 0 || res.RuleIndex >= m.ruleCount:
+		res.RuleIndex = -1
+	case m.firstMatch:
+		res.RuleIndex = m.ruleCount - 1 - res.RuleIndex
+	}
+
+	return res
+}
+
+// decideNormalized evaluates rules for an already normalized candidate.
+func (m *Matcher) decideNormalized(candidate string, isDir bool) MatchResult {
+	if m.trace != nil {
+		return m.decideTraced(candidate, isDir)
 	}
 
 	res := MatchResult{
@@ -49,14 +270,38 @@ func (m *Matcher) Decide(path string, isDir bool) MatchResult {
 		RuleIndex: -1,
 	}
 
-	for i := range m.compiled {
-		if !m.compiled[i].matches(candidate, isDir) {
-			continue
+	// Last match wins, so rules are scanned backwards and the first match decides.
+	winner := -1
+	if m.index != nil {
+		if candidate != "" {
+			winner = m.index.lookup(candidate)
 		}
 
+		for j := len(m.index.scan) - 1; j >= 0 && m.index.scan[j] > winner; j-- {
+			if i := m.index.scan[j]; m.compiled[i].matches(candidate, isDir) {
+				winner = i
+				break
+			}
+		}
+	} else if m.metaRules {
+		winner = m.prioritizedWinner(candidate, isDir, m.withoutMeta)
+	} else if m.prioritized {
+		winner = m.prioritizedWinner(candidate, isDir, nil)
+	} else {
+		for i := len(m.compiled) - 1; i >= 0; i-- {
+			if m.compiled[i].matches(candidate, isDir) {
+				winner = i
+				break
+			}
+		}
+	}
+
+	if winner >= 0 {
 		res.Matched = true
-		res.RuleIndex = i
-		res.Included = m.compiled[i].source.Action == ActionInclude
+		res.RuleIndex = winner
+		res.Action = m.compiled[winner].source.Action
+		// Custom actions are resolved by Decide and never exclude parents.
+		res.Included = res.Action != ActionExclude
 	}
 
 	return res
@@ -4,7 +4,10 @@
 
 package pathrules
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func TestMatcherIgnoreMode(t *testing.T) {
 	t.Parallel()
@@ -242,3 +245,195 @@ func TestMatcherUnanchoredPathWildcard(t *testing.T) {
 		t.Fatalf("scripts/module_010/sub/main.c must not match single-segment wildcard")
 	}
 }
+
+func TestMatcherLastMatchRuleIndex(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher([]Rule{
+		{Action: ActionExclude, Pattern: "*.log"},
+		{Action: ActionInclude, Pattern: "keep.log"},
+		{Action: ActionExclude, Pattern: "/tmp/"},
+		{Action: ActionInclude, Pattern: "*.md"},
+	}, MatcherOptions{DefaultAction: ActionInclude})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	cases := []struct {
+		path      string
+		ruleIndex int
+		included  bool
+	}{
+		{path: "a/b.log", ruleIndex: 0, included: false},
+		{path: "a/keep.log", ruleIndex: 1, included: true},
+		{path: "tmp/keep.log", ruleIndex: 2, included: false},
+		{path: "tmp/readme.md", ruleIndex: 3, included: true},
+		{path: "main.c", ruleIndex: -1, included: true},
+	}
+
+	for _, tc := range cases {
+		res := m.Decide(tc.path, false)
+		if res.RuleIndex != tc.ruleIndex || res.Included != tc.included || res.Matched != (tc.ruleIndex >= 0) {
+			t.Fatalf("Decide(%q)=%+v, want rule %d included=%v", tc.path, res, tc.ruleIndex, tc.included)
+		}
+	}
+}
+
+func TestMatcherDecideAll(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher([]Rule{
+		{Action: ActionExclude, Pattern: "*.log"},
+		{Action: ActionExclude, Pattern: "logs/"},
+		{Action: ActionInclude, Pattern: "audit.log"},
+		{Action: ActionExclude, Pattern: "*.txt"},
+		{Action: ActionExclude, Pattern: "/AUDIT.LOG"},
+	}, MatcherOptions{CaseInsensitive: true})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	res, matches := m.DecideAll("Logs/Audit.log", false)
+	if !res.Included || res.RuleIndex != 2 {
+		t.Fatalf("DecideAll result=%+v, want included by rule 2", res)
+	}
+
+	if !slices.Equal(matches, []int{0, 1, 2}) {
+		t.Fatalf("matches=%v, want [0 1 2]", matches)
+	}
+
+	if res, matches := m.DecideAll("a.bin", false); res.Matched || matches != nil {
+		t.Fatalf("DecideAll(a.bin)=%+v %v, want no matches", res, matches)
+	}
+}
+
+func TestMatcherSmartCase(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionExclude, Pattern: "*.log"},
+		{Action: ActionExclude, Pattern: "/Build/"},
+		{Action: ActionExclude, Pattern: "docs/*.md"},
+	}
+
+	m, err := NewMatcher(rules, MatcherOptions{SmartCase: true})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	var decoded Matcher
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	cases := map[string]bool{
+		"app.LOG":         false,
+		"App.log":         false,
+		"Build/out.o":     false,
+		"build/out.o":     true,
+		"BUILD/out.o":     true,
+		"DOCS/Readme.MD":  false,
+		"docs/readme.txt": true,
+	}
+
+	for _, matcher := range []*Matcher{m, &decoded} {
+		for path, included := range cases {
+			if got := matcher.Included(path, false); got != included {
+				t.Fatalf("Included(%q)=%v, want %v", path, got, included)
+			}
+		}
+	}
+
+	strict, err := NewMatcher(rules, MatcherOptions{SmartCase: true, CaseInsensitive: true})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if strict.Included("BUILD/out.o", false) {
+		t.Fatalf("CaseInsensitive must take precedence over SmartCase")
+	}
+}
+
+func TestMatcherResultRule(t *testing.T) {
+	t.Parallel()
+
+	rules := MustParseRulesString("*.log\n!keep.log\n")
+	for _, policy := range []Policy{PolicyLastMatchWins, PolicyFirstMatchWins} {
+		m, err := NewMatcher(rules, MatcherOptions{ResultRule: true, Policy: policy})
+		if err != nil {
+			t.Fatalf("NewMatcher: %v", err)
+		}
+
+		res := m.Decide("x.log", false)
+		if res.Rule == nil || res.Rule.Pattern != "*.log" || res.Rule.Action != ActionExclude {
+			t.Fatalf("policy %s: Rule=%+v, want *.log", policy, res.Rule)
+		}
+
+		if res := m.Decide("a.txt", false); res.Rule != nil {
+			t.Fatalf("policy %s: unmatched Rule=%+v, want nil", policy, res.Rule)
+		}
+	}
+
+	m, err := NewMatcher(rules, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if res := m.Decide("x.log", false); res.Rule != nil {
+		t.Fatalf("Rule=%+v without ResultRule, want nil", res.Rule)
+	}
+}
+
+func TestMatcherResultRuleMemoIsCopy(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher(MustParseRulesString("*.log\n"), MatcherOptions{ResultRule: true, MemoSize: 8})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	res := m.Decide("x.log", false)
+	if res.Rule == nil {
+		t.Fatal("Rule=nil, want *.log")
+	}
+
+	res.Rule.Pattern = "changed"
+	if res := m.Decide("x.log", false); res.Rule == nil || res.Rule.Pattern != "*.log" {
+		t.Fatalf("memoized Rule=%+v after caller mutation, want *.log", res.Rule)
+	}
+}
+
+func TestMatcherResultRuleIsCopy(t *testing.T) {
+	t.Parallel()
+
+	above := int64(10)
+	m, err := NewMatcher([]Rule{{
+		Action:  ActionExclude,
+		Pattern: "*.log",
+		Tags:    []string{"logs"},
+		Meta:    &MetaPredicate{SizeAbove: &above},
+	}}, MatcherOptions{ResultRule: true})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	meta := FileMeta{Size: 20}
+	res := m.DecideMeta("x.log", meta)
+	if res.Rule == nil {
+		t.Fatal("Rule=nil, want *.log")
+	}
+
+	res.Rule.Pattern = "changed"
+	res.Rule.Tags[0] = "changed"
+	*res.Rule.Meta.SizeAbove = 100
+
+	res = m.DecideMeta("x.log", meta)
+	if res.Rule == nil || res.Rule.Pattern != "*.log" || res.Rule.Tags[0] != "logs" || *res.Rule.Meta.SizeAbove != 10 {
+		t.Fatalf("Rule=%+v after caller mutation, want original rule", res.Rule)
+	}
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// mediaType lists the file extensions of one MIME type.
+type mediaType struct {
+	// name is the lower-case MIME type.
+	name string
+	// exts are extensions without the leading dot.
+	exts []string
+}
+
+// mediaTypes is the built-in MIME type table; the system MIME database is
+// not consulted so results do not depend on the host.
+var mediaTypes = []mediaType{
+	{"image/png", []string{"png"}},
+	{"image/jpeg", []string{"jpg", "jpeg"}},
+	{"image/gif", []string{"gif"}},
+	{"image/webp", []string{"webp"}},
+	{"image/avif", []string{"avif"}},
+	{"image/heic", []string{"heic"}},
+	{"image/bmp", []string{"bmp"}},
+	{"image/tiff", []string{"tif", "tiff"}},
+	{"image/svg+xml", []string{"svg"}},
+	{"image/x-icon", []string{"ico"}},
+	{"audio/mpeg", []string{"mp3"}},
+	{"audio/ogg", []string{"ogg", "oga", "opus"}},
+	{"audio/wav", []string{"wav"}},
+	{"audio/flac", []string{"flac"}},
+	{"audio/aac", []string{"aac"}},
+	{"audio/mp4", []string{"m4a"}},
+	{"audio/midi", []string{"mid", "midi"}},
+	{"video/mp4", []string{"mp4", "m4v"}},
+	{"video/webm", []string{"webm"}},
+	{"video/x-matroska", []string{"mkv"}},
+	{"video/quicktime", []string{"mov"}},
+	{"video/x-msvideo", []string{"avi"}},
+	{"video/mpeg", []string{"mpg", "mpeg"}},
+	{"font/ttf", []string{"ttf"}},
+	{"font/otf", []string{"otf"}},
+	{"font/woff", []string{"woff"}},
+	{"font/woff2", []string{"woff2"}},
+	{"application/zip", []string{"zip"}},
+	{"application/gzip", []string{"gz", "tgz"}},
+	{"application/x-tar", []string{"tar"}},
+	{"application/x-bzip2", []string{"bz2"}},
+	{"application/x-xz", []string{"xz"}},
+	{"application/zstd", []string{"zst"}},
+	{"application/x-7z-compressed", []string{"7z"}},
+	{"application/vnd.rar", []string{"rar"}},
+	{"application/pdf", []string{"pdf"}},
+	{"application/rtf", []string{"rtf"}},
+	{"application/msword", []string{"doc"}},
+	{"application/vnd.openxmlformats-officedocument.wordprocessingml.document", []string{"docx"}},
+	{"application/vnd.ms-excel", []string{"xls"}},
+	{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", []string{"xlsx"}},
+	{"application/vnd.ms-powerpoint", []string{"ppt"}},
+	{"application/vnd.openxmlformats-officedocument.presentationml.presentation", []string{"pptx"}},
+	{"application/vnd.oasis.opendocument.text", []string{"odt"}},
+	{"application/vnd.oasis.opendocument.spreadsheet", []string{"ods"}},
+	{"text/plain", []string{"txt"}},
+	{"text/markdown", []string{"md", "markdown"}},
+	{"text/csv", []string{"csv"}},
+}
+
+// mediaCategories maps category names to MIME types or "type/*" wildcards.
+var mediaCategories = map[string][]string{
+	"images":    {"image/*"},
+	"audio":     {"audio/*"},
+	"video":     {"video/*"},
+	"fonts":     {"font/*"},
+	"archives":  {"application/zip", "application/gzip", "application/x-tar", "application/x-bzip2", "application/x-xz", "application/zstd", "application/x-7z-compressed", "application/vnd.rar"},
+	"documents": {"application/pdf", "application/rtf", "application/msword", "application/vnd.openxmlformats-officedocument.wordprocessingml.document", "application/vnd.ms-excel", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "application/vnd.ms-powerpoint", "application/vnd.openxmlformats-officedocument.presentationml.presentation", "application/vnd.oasis.opendocument.text", "application/vnd.oasis.opendocument.spreadsheet", "text/plain", "text/markdown", "text/csv"},
+}
+
+// MediaExtensions returns extensions (without dots) of MIME types
+// ("image/png", "image/*") and categories ("images", "audio", "video",
+// "fonts", "archives", "documents") from a built-in table, deduplicated in
+// table order. Names are case-insensitive and MIME parameters are ignored;
+// unknown names fail with ErrUnknownMediaType.
+func MediaExtensions(names ...string) ([]string, error) {
+	var selected []string
+	for _, raw := range names {
+		name, _, _ := strings.Cut(raw, ";")
+		name = asciiLower(strings.TrimSpace(name))
+		types, ok := mediaCategories[name]
+		if !ok {
+			types = []string{name}
+		}
+
+		for _, t := range types {
+			n := len(selected)
+			for _, mt := range mediaTypes {
+				if mediaTypeMatches(t, mt.name) {
+					selected = append(selected, mt.name)
+				}
+			}
+
+			if len(selected) == n {
+				return nil, fmt.Errorf("%w: %q", ErrUnknownMediaType, raw)
+			}
+		}
+	}
+
+	var exts []string
+	for _, mt := range mediaTypes {
+		if !slices.Contains(selected, mt.name) {
+			continue
+		}
+
+		for _, ext := range mt.exts {
+			if !slices.Contains(exts, ext) {
+				exts = append(exts, ext)
+			}
+		}
+	}
+
+	return exts, nil
+}
+
+// MediaTypeRules returns extension rules with action for MediaExtensions(names...).
+func MediaTypeRules(action Action, names ...string) ([]Rule, error) {
+	exts, err := MediaExtensions(names...)
+	if err != nil {
+		return nil, err
+	}
+
+	return extensionRules(exts, action), nil
+}
+
+// mediaTypeMatches reports whether pattern ("type/subtype" or "type/*") selects name.
+func mediaTypeMatches(pattern, name string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+		return strings.HasPrefix(name, prefix+"/")
+	}
+
+	return pattern == name
+}
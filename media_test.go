@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestMediaExtensions(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		names []string
+		want  []string
+	}{
+		{names: []string{"image/JPEG"}, want: []string{"jpg", "jpeg"}},
+		{names: []string{"text/plain; charset=utf-8"}, want: []string{"txt"}},
+		{names: []string{"fonts"}, want: []string{"ttf", "otf", "woff", "woff2"}},
+		{names: []string{"audio/ogg", "Audio"}, want: []string{"mp3", "ogg", "oga", "opus", "wav", "flac", "aac", "m4a", "mid", "midi"}},
+		{names: []string{"image/png", "image/*"}, want: []string{"png", "jpg", "jpeg", "gif", "webp", "avif", "heic", "bmp", "tif", "tiff", "svg", "ico"}},
+	} {
+		got, err := MediaExtensions(tc.names...)
+		if err != nil {
+			t.Fatalf("MediaExtensions(%q): %v", tc.names, err)
+		}
+
+		if !slices.Equal(got, tc.want) {
+			t.Fatalf("MediaExtensions(%q)=%v, want %v", tc.names, got, tc.want)
+		}
+	}
+
+	for _, name := range []string{"pictures", "image/x-unknown", "model/*"} {
+		if _, err := MediaExtensions(name); !errors.Is(err, ErrUnknownMediaType) {
+			t.Fatalf("MediaExtensions(%q) err=%v, want ErrUnknownMediaType", name, err)
+		}
+	}
+}
+
+func TestMediaTypeRules(t *testing.T) {
+	t.Parallel()
+
+	rules, err := MediaTypeRules(ActionExclude, "video")
+	if err != nil {
+		t.Fatalf("MediaTypeRules: %v", err)
+	}
+
+	m, err := NewMatcher(rules, MatcherOptions{CaseInsensitive: true})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if m.Included("clips/intro.MKV", false) || !m.Included("clips/intro.txt", false) {
+		t.Fatal("video rules do not exclude exactly video files")
+	}
+}
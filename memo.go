@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"container/list"
+	"sync"
+)
+
+// decisionMemo is a bounded LRU cache of matcher decisions, safe for concurrent use.
+type decisionMemo struct {
+	// entries maps cached keys to their order list element.
+	entries map[memoKey]*list.Element
+	// order lists entries from most to least recently used.
+	order *list.List
+	// size is the maximum number of cached decisions.
+	size int
+	// mu guards entries and order.
+	mu sync.Mutex
+}
+
+// memoKey identifies one cached decision.
+type memoKey struct {
+	// path is normalized candidate path.
+	path string
+	// isDir is candidate directory flag.
+	isDir bool
+}
+
+// memoEntry is one cached decision.
+type memoEntry struct {
+	// key is the entry key, kept for eviction.
+	key memoKey
+	// res is the cached decision.
+	res MatchResult
+}
+
+// newDecisionMemo returns a memo holding size decisions, nil when size is not positive.
+func newDecisionMemo(size int) *decisionMemo {
+	if size <= 0 {
+		return nil
+	}
+
+	return &decisionMemo{
+		entries: make(map[memoKey]*list.Element, size),
+		order:   list.New(),
+		size:    size,
+	}
+}
+
+// get returns a cached decision and marks it recently used.
+func (c *decisionMemo) get(path string, isDir bool) (MatchResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[memoKey{path: path, isDir: isDir}]
+	if !ok {
+		return MatchResult{}, false
+	}
+
+	c.order.MoveToFront(elem)
+	return elem.Value.(*memoEntry).res, true
+}
+
+// put caches a decision, evicting the least recently used one when full.
+func (c *decisionMemo) put(path string, isDir bool, res MatchResult) {
+	key := memoKey{path: path, isDir: isDir}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*memoEntry).res = res
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	if c.order.Len() >= c.size {
+		oldest := c.order.Back()
+		delete(c.entries, oldest.Value.(*memoEntry).key)
+		c.order.Remove(oldest)
+	}
+
+	c.entries[key] = c.order.PushFront(&memoEntry{key: key, res: res})
+}
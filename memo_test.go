@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestMatcherMemo(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionExclude, Pattern: "build/"},
+		{Action: ActionInclude, Pattern: "build/keep.txt"},
+	}
+
+	m, err := NewMatcher(rules, MatcherOptions{MemoSize: 2, Dialect: DialectGit})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	plain, err := NewMatcher(rules, MatcherOptions{Dialect: DialectGit})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	paths := []string{"build/keep.txt", "./build/keep.txt", "src/a.go", "build", "build/keep.txt"}
+	for _, path := range paths {
+		for _, isDir := range []bool{false, true} {
+			if got, want := m.Decide(path, isDir), plain.Decide(path, isDir); got != want {
+				t.Fatalf("Decide(%q, %v)=%+v, want %+v", path, isDir, got, want)
+			}
+		}
+	}
+
+	if got := m.memo.order.Len(); got != 2 {
+		t.Fatalf("memo size=%d, want 2", got)
+	}
+
+	if _, ok := m.memo.get("build/keep.txt", true); !ok {
+		t.Fatalf("most recent decision evicted")
+	}
+
+	if _, ok := m.memo.get("src/a.go", false); ok {
+		t.Fatalf("least recent decision kept")
+	}
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			for j := range 100 {
+				path := fmt.Sprintf("build/f%d.txt", (i+j)%5)
+				if m.Included(path, false) {
+					t.Errorf("Included(%q)=true, want false", path)
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"io/fs"
+	"time"
+)
+
+// FileMeta is caller-supplied metadata of one candidate path.
+type FileMeta struct {
+	// ModTime is the modification time.
+	ModTime time.Time `json:"mod_time,omitzero" yaml:"mod_time,omitempty"`
+	// Size is the size in bytes.
+	Size int64 `json:"size,omitempty" yaml:"size,omitempty"`
+	// Mode holds type bits and permissions; fs.ModeDir marks directories.
+	Mode fs.FileMode `json:"mode,omitempty" yaml:"mode,omitempty"`
+}
+
+// MetaPredicate restricts a rule to paths whose metadata satisfies every
+// set field; unset fields accept any value.
+type MetaPredicate struct {
+	// ModifiedBefore accepts modification times before it, zero disables.
+	ModifiedBefore time.Time `json:"modified_before,omitzero" yaml:"modified_before,omitempty"`
+	// ModifiedAfter accepts modification times after it, zero disables.
+	ModifiedAfter time.Time `json:"modified_after,omitzero" yaml:"modified_after,omitempty"`
+	// SizeAbove accepts sizes greater than it, nil disables.
+	SizeAbove *int64 `json:"size_above,omitempty" yaml:"size_above,omitempty"`
+	// SizeBelow accepts sizes less than it, nil disables.
+	SizeBelow *int64 `json:"size_below,omitempty" yaml:"size_below,omitempty"`
+	// Symlink accepts only symlinks when true and only non-symlinks when false, nil disables.
+	Symlink *bool `json:"symlink,omitempty" yaml:"symlink,omitempty"`
+	// ModeMask selects mode bits compared with Mode, zero disables.
+	ModeMask fs.FileMode `json:"mode_mask,omitempty" yaml:"mode_mask,omitempty"`
+	// Mode is the required value of ModeMask bits.
+	Mode fs.FileMode `json:"mode,omitempty" yaml:"mode,omitempty"`
+}
+
+// clone returns a copy of p sharing no pointer fields with it.
+func (p *MetaPredicate) clone() MetaPredicate {
+	c := *p
+	if p.SizeAbove != nil {
+		v := *p.SizeAbove
+		c.SizeAbove = &v
+	}
+
+	if p.SizeBelow != nil {
+		v := *p.SizeBelow
+		c.SizeBelow = &v
+	}
+
+	if p.Symlink != nil {
+		v := *p.Symlink
+		c.Symlink = &v
+	}
+
+	return c
+}
+
+// FileMetaOf returns metadata of info.
+func FileMetaOf(info fs.FileInfo) FileMeta {
+	return FileMeta{
+		ModTime: info.ModTime(),
+		Size:    info.Size(),
+		Mode:    info.Mode(),
+	}
+}
+
+// DecideMeta returns the decision for path with metadata, applying rules
+// whose Rule.Meta predicate accepts meta. Mode.IsDir reports whether path
+// is a directory.
+//
+// Decision policy is the same as Decide. Parent directories have no
+// metadata, so parent exclusion ignores rules with Meta. The decision memo,
+// coverage and trace are not used.
+func (m *Matcher) DecideMeta(path string, meta FileMeta) MatchResult {
+	if m.rejectsPath(path) {
+		return MatchResult{RuleIndex: -1}
+	}
+
+	candidate := m.normalizeCandidate(path)
+
+	keep := func(i int) bool {
+		p := m.compiled[i].source.Meta
+		return p == nil || p.accepts(meta)
+	}
+
+	isDir := meta.Mode.IsDir() || m.dirHint(path)
+	return m.resolveAction(candidate, isDir, m.decideKeptCandidate(candidate, isDir, m.withoutMeta, keep))
+}
+
+// IncludedMeta reports whether path with metadata is included.
+func (m *Matcher) IncludedMeta(path string, meta FileMeta) bool {
+	return m.DecideMeta(path, meta).Included
+}
+
+// accepts reports whether meta satisfies every set predicate field.
+func (p *MetaPredicate) accepts(meta FileMeta) bool {
+	switch {
+	case !p.ModifiedBefore.IsZero() && !meta.ModTime.Before(p.ModifiedBefore):
+		return false
+	case !p.ModifiedAfter.IsZero() && !meta.ModTime.After(p.ModifiedAfter):
+		return false
+	case p.SizeAbove != nil && meta.Size <= *p.SizeAbove:
+		return false
+	case p.SizeBelow != nil && meta.Size >= *p.SizeBelow:
+		return false
+	case p.Symlink != nil && *p.Symlink != (meta.Mode&fs.ModeSymlink != 0):
+		return false
+	default:
+		return meta.Mode&p.ModeMask == p.Mode&p.ModeMask
+	}
+}
+
+// withoutMeta accepts compiled rules without a metadata predicate.
+func (m *Matcher) withoutMeta(i int) bool {
+	return m.compiled[i].source.Meta == nil
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"io/fs"
+	"testing"
+	"time"
+)
+
+func TestMatcherDecideMeta(t *testing.T) {
+	t.Parallel()
+
+	tenMB := int64(10 << 20)
+	cutoff := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	symlink := true
+	rules := []Rule{
+		{Action: ActionExclude, Pattern: "*.log", Meta: &MetaPredicate{SizeAbove: &tenMB}},
+		{Action: ActionExclude, Pattern: "cache/", Meta: &MetaPredicate{ModifiedBefore: cutoff}},
+		{Action: ActionExclude, Pattern: "*", Meta: &MetaPredicate{Symlink: &symlink}},
+		{Action: ActionExclude, Pattern: "*.sh", Meta: &MetaPredicate{ModeMask: 0o111, Mode: 0}},
+	}
+
+	m, err := NewMatcher(rules, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	old := cutoff.Add(-time.Hour)
+	cases := []struct {
+		path     string
+		meta     FileMeta
+		included bool
+	}{
+		{path: "big.log", meta: FileMeta{Size: tenMB + 1}, included: false},
+		{path: "small.log", meta: FileMeta{Size: tenMB}, included: true},
+		{path: "cache", meta: FileMeta{Mode: fs.ModeDir, ModTime: old}, included: false},
+		{path: "cache", meta: FileMeta{Mode: fs.ModeDir, ModTime: cutoff}, included: true},
+		{path: "link", meta: FileMeta{Mode: fs.ModeSymlink}, included: false},
+		{path: "run.sh", meta: FileMeta{Mode: 0o644}, included: false},
+		{path: "run.sh", meta: FileMeta{Mode: 0o755}, included: true},
+	}
+
+	for _, tc := range cases {
+		if got := m.IncludedMeta(tc.path, tc.meta); got != tc.included {
+			t.Fatalf("IncludedMeta(%q, %+v)=%v, want %v", tc.path, tc.meta, got, tc.included)
+		}
+	}
+
+	if !m.Included("big.log", false) || !m.Included("link", false) {
+		t.Fatalf("Decide must not match rules with metadata predicates")
+	}
+
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	var decoded Matcher
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	for _, tc := range cases {
+		if got := decoded.IncludedMeta(tc.path, tc.meta); got != tc.included {
+			t.Fatalf("decoded IncludedMeta(%q, %+v)=%v, want %v", tc.path, tc.meta, got, tc.included)
+		}
+	}
+}
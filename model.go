@@ -4,6 +4,8 @@
 
 package pathrules
 
+import "slices"
+
 // Action represents a decision action of one rule.
 type Action uint8
 
@@ -16,20 +18,87 @@ const (
 	ActionInclude
 )
 
+// PatternSyntax selects how Rule.Pattern is interpreted.
+type PatternSyntax uint8
+
+const (
+	// PatternGlob is gitignore-like glob syntax interpreted by the matcher dialect.
+	PatternGlob PatternSyntax = iota
+	// PatternRegexp is RE2 regexp searched anywhere in the normalized path;
+	// a match on a directory also covers its contents. Use "^" to anchor.
+	PatternRegexp
+)
+
 // Rule is one user-visible path rule.
 type Rule struct {
 	// Pattern is a gitignore-like pattern.
 	Pattern string `json:"pattern" yaml:"pattern"`
+	// Source is the rules source name (usually a file path), empty for in-memory rules.
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+	// Section is the rules file section name, empty for common rules.
+	Section string `json:"section,omitempty" yaml:"section,omitempty"`
+	// Tags select the rule for Matcher.DecideTagged; Decide ignores them.
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	// Meta restricts the rule to paths whose metadata it accepts; such rules
+	// match only in Matcher.DecideMeta. Rules files cannot set it.
+	Meta *MetaPredicate `json:"meta,omitempty" yaml:"meta,omitempty"`
+	// Line is the 1-based line number in Source, 0 when unknown.
+	Line int `json:"line,omitempty" yaml:"line,omitempty"`
+	// Priority orders rules of one matcher: a matching rule with higher
+	// Priority wins over later rules with lower Priority, equal priorities
+	// keep last-match-wins. Rules files cannot set it.
+	Priority int `json:"priority,omitempty" yaml:"priority,omitempty"`
 	// Action is a decision action applied when the rule matches.
 	Action Action `json:"action" yaml:"action"`
+	// Syntax selects how Pattern is interpreted, PatternGlob when zero.
+	Syntax PatternSyntax `json:"syntax,omitempty" yaml:"syntax,omitempty"`
 }
 
 // MatcherOptions controls matcher behavior.
 type MatcherOptions struct {
+	// Trace, when set, receives every rule tested during Decide with its outcome.
+	// It is called synchronously and must be safe for concurrent use when the
+	// matcher is shared.
+	Trace func(TraceEvent) `json:"-" yaml:"-"`
+	// MemoSize enables an LRU cache of that many recent decisions, 0 disables it.
+	MemoSize int `json:"memo_size,omitempty" yaml:"memo_size,omitempty"`
 	// CaseInsensitive enables ASCII case-insensitive matching.
 	CaseInsensitive bool `json:"case_insensitive,omitempty" yaml:"case_insensitive,omitempty"`
+	// SmartCase matches patterns without ASCII uppercase letters
+	// case-insensitively and the others case-sensitively. CaseInsensitive
+	// takes precedence.
+	SmartCase bool `json:"smart_case,omitempty" yaml:"smart_case,omitempty"`
 	// DefaultAction is applied when no rule matched.
 	DefaultAction Action `json:"default_action,omitempty" yaml:"default_action,omitempty"`
+	// Dialect selects pattern and decision semantics, DialectDefault when zero.
+	Dialect Dialect `json:"dialect,omitempty" yaml:"dialect,omitempty"`
+	// Profile, when set, keeps only common rules (empty Section) and rules of
+	// that section, as SelectSections does; RuleIndex then refers to the kept rules.
+	Profile string `json:"profile,omitempty" yaml:"profile,omitempty"`
+	// Policy selects which matching rule decides, PolicyLastMatchWins when zero.
+	Policy Policy `json:"policy,omitempty" yaml:"policy,omitempty"`
+	// PathSeparators selects how "\" is treated, PathSeparatorAuto when zero.
+	PathSeparators PathSeparatorPolicy `json:"path_separators,omitempty" yaml:"path_separators,omitempty"`
+	// ActionHandlers resolve custom actions (ActionCustom and above) used by rules.
+	ActionHandlers map[Action]ActionHandler `json:"-" yaml:"-"`
+	// MaxRegexpSize caps the source size in bytes of a regexp generated for
+	// or given by one rule, 0 disables.
+	MaxRegexpSize int `json:"max_regexp_size,omitempty" yaml:"max_regexp_size,omitempty"`
+	// MaxDoubleStars caps "**" segments in one glob pattern, 0 disables.
+	MaxDoubleStars int `json:"max_double_stars,omitempty" yaml:"max_double_stars,omitempty"`
+	// MaxCharClasses caps char classes in one glob pattern, 0 disables.
+	MaxCharClasses int `json:"max_char_classes,omitempty" yaml:"max_char_classes,omitempty"`
+	// MaxPathLength caps candidate path length in bytes: longer paths are
+	// excluded without evaluating rules, 0 disables.
+	MaxPathLength int `json:"max_path_length,omitempty" yaml:"max_path_length,omitempty"`
+	// TrackCoverage counts per-rule wins and matches, see Matcher.Coverage.
+	TrackCoverage bool `json:"track_coverage,omitempty" yaml:"track_coverage,omitempty"`
+	// ResultRule sets MatchResult.Rule to the winning rule.
+	ResultRule bool `json:"result_rule,omitempty" yaml:"result_rule,omitempty"`
+	// StrictPaths rejects empty, absolute and traversal ("..") candidate
+	// paths instead of normalizing them: Matcher.DecideErr fails with
+	// ErrInvalidPath, other decide methods exclude them without a match.
+	StrictPaths bool `json:"strict_paths,omitempty" yaml:"strict_paths,omitempty"`
 }
 
 // MatchResult is a deterministic decision produced by matcher.
@@ -38,8 +107,14 @@ type MatchResult struct {
 	Included bool `json:"included" yaml:"included"`
 	// Matched reports whether at least one rule matched.
 	Matched bool `json:"matched" yaml:"matched"`
-	// RuleIndex is the matched rule index in matcher input order, -1 when no match.
+	// RuleIndex is the matched rule index in matcher input order, -1 when no
+	// match or when a dialect default rule matched.
 	RuleIndex int `json:"rule_index" yaml:"rule_index"`
+	// Action is the winning rule action, ActionUnknown when no rule matched.
+	Action Action `json:"action,omitempty" yaml:"action,omitempty"`
+	// Rule is a copy of the winning rule when MatcherOptions.ResultRule is
+	// set, nil otherwise or when no user rule matched.
+	Rule *Rule `json:"rule,omitempty" yaml:"rule,omitempty"`
 }
 
 // applyDefaults fills zero-valued options with defaults.
@@ -53,3 +128,15 @@ func (opts *MatcherOptions) applyDefaults() {
 func (a Action) valid() bool {
 	return a == ActionExclude || a == ActionInclude
 }
+
+// clone returns a copy of r sharing no Tags or Meta memory with it.
+func (r *Rule) clone() Rule {
+	c := *r
+	c.Tags = slices.Clone(r.Tags)
+	if r.Meta != nil {
+		meta := r.Meta.clone()
+		c.Meta = &meta
+	}
+
+	return c
+}
@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Mount attaches a Provider to a workspace path.
+type Mount struct {
+	// Provider decides paths below Prefix, given relative to Prefix.
+	Provider *Provider `json:"-" yaml:"-"`
+	// Prefix is the slash-separated workspace directory the provider root
+	// is mounted at, "" for the workspace root.
+	Prefix string `json:"prefix" yaml:"prefix"`
+}
+
+// MultiProviderOptions configures MultiProvider.
+type MultiProviderOptions struct {
+	// BaseRules are workspace-wide rules matched against full workspace
+	// paths before the owning provider decides.
+	BaseRules []Rule `json:"base_rules,omitempty" yaml:"base_rules,omitempty"`
+	// Mounts lists providers in precedence order: the first mount whose
+	// Prefix contains a path owns it, so nested mounts go first.
+	Mounts []Mount `json:"mounts" yaml:"mounts"`
+	// MatcherOptions controls BaseRules matching.
+	MatcherOptions MatcherOptions `json:"matcher_options" yaml:"matcher_options"`
+}
+
+// MultiProvider federates several Providers with distinct roots under one
+// workspace path space.
+type MultiProvider struct {
+	// baseMatcher evaluates workspace-wide rules, nil when BaseRules is empty.
+	baseMatcher *Matcher
+	// mounts are normalized mounts in precedence order.
+	mounts []Mount
+}
+
+// NewMultiProvider validates mounts and compiles workspace base rules.
+func NewMultiProvider(opts MultiProviderOptions) (*MultiProvider, error) {
+	mp := &MultiProvider{mounts: make([]Mount, 0, len(opts.Mounts))}
+	for i, mount := range opts.Mounts {
+		if mount.Provider == nil {
+			return nil, fmt.Errorf("%w: mount %d: %w", ErrInvalidOptions, i, ErrNilProvider)
+		}
+
+		prefix, err := cleanRelDirPath(mount.Prefix)
+		if err != nil {
+			return nil, fmt.Errorf("%w: mount %d prefix %q: %w", ErrInvalidOptions, i, mount.Prefix, err)
+		}
+
+		for _, prev := range mp.mounts {
+			if prev.Prefix == prefix {
+				return nil, fmt.Errorf("%w: duplicate mount prefix %q", ErrInvalidOptions, mount.Prefix)
+			}
+		}
+
+		mp.mounts = append(mp.mounts, Mount{Provider: mount.Provider, Prefix: prefix})
+	}
+
+	if len(opts.BaseRules) > 0 {
+		m, err := NewMatcher(opts.BaseRules, opts.MatcherOptions)
+		if err != nil {
+			return nil, fmt.Errorf("compile base rules: %w", err)
+		}
+
+		mp.baseMatcher = m
+	}
+
+	return mp, nil
+}
+
+// Route returns the provider owning a workspace path and the path relative
+// to its root. Paths owned by no mount fail with ErrPathOutsideRoot.
+func (mp *MultiProvider) Route(workspacePath string) (*Provider, string, error) {
+	normalized, err := cleanRelPath(workspacePath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	p, rel, ok := mp.route(normalized)
+	if !ok {
+		return nil, "", fmt.Errorf("%w: no mount owns %q", ErrPathOutsideRoot, workspacePath)
+	}
+
+	return p, rel, nil
+}
+
+// Decide returns the decision for a slash-separated workspace path.
+//
+// BaseRules are evaluated first on the full path; a matching rule of the
+// owning provider overrides them, and its default applies when neither
+// matched. Mount prefixes themselves are decided by an enclosing mount.
+func (mp *MultiProvider) Decide(workspacePath string, isDir bool) (MatchResult, error) {
+	normalized, err := cleanRelPath(workspacePath)
+	if err != nil {
+		return MatchResult{}, err
+	}
+
+	p, rel, ok := mp.route(normalized)
+	if !ok {
+		return MatchResult{}, fmt.Errorf("%w: no mount owns %q", ErrPathOutsideRoot, workspacePath)
+	}
+
+	res, err := p.Decide(rel, isDir)
+	if err != nil || res.Matched || mp.baseMatcher == nil {
+		return res, err
+	}
+
+	if base := mp.baseMatcher.Decide(normalized, isDir); base.Matched {
+		return base, nil
+	}
+
+	return res, nil
+}
+
+// Included reports whether a workspace path is included.
+func (mp *MultiProvider) Included(workspacePath string, isDir bool) (bool, error) {
+	res, err := mp.Decide(workspacePath, isDir)
+	if err != nil {
+		return false, err
+	}
+
+	return res.Included, nil
+}
+
+// Excluded reports whether a workspace path is excluded.
+func (mp *MultiProvider) Excluded(workspacePath string, isDir bool) (bool, error) {
+	included, err := mp.Included(workspacePath, isDir)
+	if err != nil {
+		return false, err
+	}
+
+	return !included, nil
+}
+
+// Refresh calls Refresh on every mounted provider and returns the total
+// number of dropped entries with all errors joined.
+func (mp *MultiProvider) Refresh() (int, error) {
+	var (
+		total int
+		errs  []error
+	)
+
+	for _, mount := range mp.mounts {
+		dropped, err := mount.Provider.Refresh()
+		total += dropped
+		if err != nil {
+			errs = append(errs, fmt.Errorf("mount %q: %w", mount.Prefix, err))
+		}
+	}
+
+	return total, errors.Join(errs...)
+}
+
+// Close closes every mounted provider and joins their errors.
+func (mp *MultiProvider) Close() error {
+	var errs []error
+	for _, mount := range mp.mounts {
+		if err := mount.Provider.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("mount %q: %w", mount.Prefix, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// route returns the first mount containing normalized and the path relative to it.
+func (mp *MultiProvider) route(normalized string) (*Provider, string, bool) {
+	for _, mount := range mp.mounts {
+		if mount.Prefix == "" {
+			return mount.Provider, normalized, true
+		}
+
+		if rel, ok := strings.CutPrefix(normalized, mount.Prefix+"/"); ok {
+			return mount.Provider, rel, true
+		}
+	}
+
+	return nil, "", false
+}
+
+// cleanRelDirPath normalizes a workspace directory, "" and "." for the root.
+func cleanRelDirPath(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" || trimmed == "." {
+		return "", nil
+	}
+
+	return cleanRelPath(strings.TrimSuffix(trimmed, "/"))
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMultiProvider(t *testing.T) {
+	t.Parallel()
+
+	newFS := func(rules string) *Provider {
+		t.Helper()
+
+		p, err := NewProviderFS(fstest.MapFS{".pathrules": {Data: []byte(rules)}}, ProviderOptions{})
+		if err != nil {
+			t.Fatalf("NewProviderFS: %v", err)
+		}
+
+		return p
+	}
+
+	app, lib, ws := newFS("*.tmp\n"), newFS("!*.log\n"), newFS("*.bin\n")
+	mp, err := NewMultiProvider(MultiProviderOptions{
+		BaseRules: []Rule{{Action: ActionExclude, Pattern: "*.log"}},
+		Mounts: []Mount{
+			{Prefix: "app/lib/", Provider: lib},
+			{Prefix: "app", Provider: app},
+			{Prefix: "", Provider: ws},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewMultiProvider: %v", err)
+	}
+
+	for path, want := range map[string]bool{
+		"app/a.tmp":     false,
+		"app/a.log":     false,
+		"app/a.bin":     true,
+		"app/lib/a.tmp": true,
+		"app/lib/a.log": true,
+		"tools/a.bin":   false,
+		"tools/a.tmp":   true,
+		"app":           true,
+	} {
+		if got, err := mp.Included(path, false); err != nil || got != want {
+			t.Fatalf("Included(%s)=%v err=%v, want %v", path, got, err, want)
+		}
+	}
+
+	if p, rel, err := mp.Route("app/lib/x/y.go"); err != nil || p != lib || rel != "x/y.go" {
+		t.Fatalf("Route=%p %q err=%v, want lib x/y.go", p, rel, err)
+	}
+
+	narrow, err := NewMultiProvider(MultiProviderOptions{Mounts: []Mount{{Prefix: "app", Provider: app}}})
+	if err != nil {
+		t.Fatalf("NewMultiProvider: %v", err)
+	}
+
+	if _, err := narrow.Decide("tools/a.go", false); !errors.Is(err, ErrPathOutsideRoot) {
+		t.Fatalf("unrouted Decide err=%v, want ErrPathOutsideRoot", err)
+	}
+
+	for _, mounts := range [][]Mount{
+		{{Prefix: "a"}},
+		{{Prefix: "a", Provider: app}, {Prefix: "a/", Provider: lib}},
+		{{Prefix: "../a", Provider: app}},
+	} {
+		if _, err := NewMultiProvider(MultiProviderOptions{Mounts: mounts}); !errors.Is(err, ErrInvalidOptions) {
+			t.Fatalf("NewMultiProvider(%+v) err=%v, want ErrInvalidOptions", mounts, err)
+		}
+	}
+}
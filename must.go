@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"io/fs"
+)
+
+// MustParseRulesString is like ParseRulesString but panics on error.
+//
+// It is meant for static rules in package-level variables.
+func MustParseRulesString(src string) []Rule {
+	rules, err := ParseRulesString(src)
+	if err != nil {
+		panic(fmt.Errorf("pathrules: %w", err))
+	}
+
+	return rules
+}
+
+// MustNewMatcher is like NewMatcher but panics on error.
+//
+// Panic values are errors, so recovered values still match sentinels via errors.Is.
+func MustNewMatcher(rules []Rule, opts MatcherOptions) *Matcher {
+	m, err := NewMatcher(rules, opts)
+	if err != nil {
+		panic(fmt.Errorf("pathrules: %w", err))
+	}
+
+	return m
+}
+
+// EmbedRules loads rules files from fsys in the given order and compiles them,
+// panicking on any error. It is meant for policies baked into the binary:
+//
+//	//go:embed policy.rules
+//	var policyFS embed.FS
+//
+//	var policy = pathrules.EmbedRules(policyFS, pathrules.MatcherOptions{}, "policy.rules")
+func EmbedRules(fsys fs.FS, opts MatcherOptions, paths ...string) *Matcher {
+	rules, err := LoadRulesFilesFS(fsys, paths...)
+	if err != nil {
+		panic(fmt.Errorf("pathrules: %w", err))
+	}
+
+	return MustNewMatcher(rules, opts)
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMustHelpers(t *testing.T) {
+	t.Parallel()
+
+	m := MustNewMatcher(MustParseRulesString("*.tmp\n"), MatcherOptions{})
+	if m.Included("a.tmp", false) {
+		t.Fatalf("a.tmp must be excluded")
+	}
+
+	fsys := fstest.MapFS{
+		"base.rules":  {Data: []byte("*\n")},
+		"extra.rules": {Data: []byte("!*.go\n")},
+	}
+
+	m = EmbedRules(fsys, MatcherOptions{}, "base.rules", "extra.rules")
+	if !m.Included("main.go", false) || m.Included("go.mod", false) {
+		t.Fatalf("EmbedRules merged rules in wrong order")
+	}
+}
+
+func TestMustHelpersPanic(t *testing.T) {
+	t.Parallel()
+
+	mustPanic := func(name string, target error, fn func()) {
+		t.Helper()
+		defer func() {
+			err, ok := recover().(error)
+			if !ok || !errors.Is(err, target) {
+				t.Fatalf("%s: recovered %v, want %v", name, err, target)
+			}
+		}()
+
+		fn()
+	}
+
+	mustPanic("MustNewMatcher", ErrInvalidPattern, func() {
+		MustNewMatcher([]Rule{{Action: ActionExclude, Pattern: "(", Syntax: PatternRegexp}}, MatcherOptions{})
+	})
+
+	mustPanic("EmbedRules", fs.ErrNotExist, func() {
+		EmbedRules(fstest.MapFS{}, MatcherOptions{}, "missing.rules")
+	})
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// windowsReservedNames are device names Windows reserves in every directory,
+// with or without an extension.
+var windowsReservedNames = map[string]struct{}{
+	"CON": {}, "PRN": {}, "AUX": {}, "NUL": {}, "CONIN$": {}, "CONOUT$": {},
+	"COM0": {}, "COM1": {}, "COM2": {}, "COM3": {}, "COM4": {},
+	"COM5": {}, "COM6": {}, "COM7": {}, "COM8": {}, "COM9": {},
+	"COM¹": {}, "COM²": {}, "COM³": {},
+	"LPT0": {}, "LPT1": {}, "LPT2": {}, "LPT3": {}, "LPT4": {},
+	"LPT5": {}, "LPT6": {}, "LPT7": {}, "LPT8": {}, "LPT9": {},
+	"LPT¹": {}, "LPT²": {}, "LPT³": {},
+}
+
+// checkWindowsName returns ErrUnsafeName when Windows cannot store name as
+// given: reserved device names, trailing dots or spaces (silently dropped),
+// ":" (drive or alternate data stream suffix) and other invalid characters.
+func checkWindowsName(name string) error {
+	if name == "" || name == "." || name == ".." {
+		return nil
+	}
+
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == ':':
+			return fmt.Errorf("%w: %q has a stream or drive separator", ErrUnsafeName, name)
+		case c < 0x20 || strings.IndexByte(`<>"|?*`, c) >= 0:
+			return fmt.Errorf("%w: %q contains %q", ErrUnsafeName, name, c)
+		}
+	}
+
+	if last := name[len(name)-1]; last == '.' || last == ' ' {
+		return fmt.Errorf("%w: %q ends with a dot or space", ErrUnsafeName, name)
+	}
+
+	base, _, _ := strings.Cut(name, ".")
+	if _, ok := windowsReservedNames[strings.ToUpper(strings.TrimRight(base, " "))]; ok {
+		return fmt.Errorf("%w: %q is a reserved device name", ErrUnsafeName, name)
+	}
+
+	return nil
+}
+
+// checkWindowsPath checks every component of raw with checkWindowsName,
+// skipping a leading Windows volume prefix.
+func checkWindowsPath(raw string) error {
+	raw = raw[windowsVolumeLen(raw):]
+	for start := 0; start <= len(raw); {
+		end := start
+		for end < len(raw) && !isWindowsSeparator(raw[end]) {
+			end++
+		}
+
+		if err := checkWindowsName(raw[start:end]); err != nil {
+			return err
+		}
+
+		start = end + 1
+	}
+
+	return nil
+}
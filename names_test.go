@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckWindowsPath(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]bool{
+		"src/main.go":            true,
+		`C:\repo\src\main.go`:    true,
+		"./docs/../readme.md":    true,
+		"console/config.txt":     true,
+		"NUL":                    false,
+		"dir/con.txt":            false,
+		"dir/Com1 .log":          false,
+		"LPT¹":                   false,
+		"file.txt:stream":        false,
+		"file.txt::$DATA":        false,
+		"dir./file":              false,
+		"file.txt ":              false,
+		"what?.txt":              false,
+		"a\x01b":                 false,
+		`dir\aux\file.txt`:       false,
+		`\\server\share\nul.txt`: false,
+	}
+
+	for raw, safe := range cases {
+		err := checkWindowsPath(raw)
+		if safe != (err == nil) || (err != nil && !errors.Is(err, ErrUnsafeName)) {
+			t.Fatalf("checkWindowsPath(%q)=%v, want safe=%v", raw, err, safe)
+		}
+	}
+}
+
+func TestProviderRejectUnsafeNames(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	strict, err := NewProvider(root, ProviderOptions{RejectUnsafeNames: true})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if _, err := strict.Decide("dir/NUL.txt", false); !errors.Is(err, ErrUnsafeName) {
+		t.Fatalf("Decide err=%v, want ErrUnsafeName", err)
+	}
+
+	if _, err := strict.DecideInDir("dir", []DirEntry{{Name: "ok.txt"}, {Name: "evil.txt:ads"}}); !errors.Is(err, ErrUnsafeName) {
+		t.Fatalf("DecideInDir err=%v, want ErrUnsafeName", err)
+	}
+
+	if _, err := strict.Decide("dir/ok.txt", false); err != nil {
+		t.Fatalf("Decide(dir/ok.txt) err=%v, want nil", err)
+	}
+
+	lenient, err := NewProvider(root, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if _, err := lenient.Decide("dir/NUL.txt", false); err != nil {
+		t.Fatalf("Decide err=%v, want nil without RejectUnsafeNames", err)
+	}
+}
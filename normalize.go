@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "strings"
+
+// NormalizeOptions selects how NormalizePath, NormalizePattern and
+// SplitPathSegments prepare strings; fields mean the same as in
+// MatcherOptions, see Matcher.NormalizeOptions.
+type NormalizeOptions struct {
+	// PathSeparators selects how "\" is treated, PathSeparatorAuto when zero.
+	PathSeparators PathSeparatorPolicy `json:"path_separators,omitempty" yaml:"path_separators,omitempty"`
+	// Dialect selects pattern semantics, DialectDefault when zero.
+	Dialect Dialect `json:"dialect,omitempty" yaml:"dialect,omitempty"`
+	// CaseInsensitive folds ASCII letters to lower case.
+	CaseInsensitive bool `json:"case_insensitive,omitempty" yaml:"case_insensitive,omitempty"`
+	// Unicode, when set, is applied to the input first, for example
+	// norm.NFC.String from golang.org/x/text. Matchers apply no Unicode
+	// normalization, so run both candidates and rule patterns through it.
+	Unicode func(string) string `json:"-" yaml:"-"`
+}
+
+// NormalizeOptions returns the normalization settings m applies to
+// candidate paths and patterns. MatcherOptions.SmartCase folds case per
+// rule and is not reflected.
+func (m *Matcher) NormalizeOptions() NormalizeOptions {
+	opts := NormalizeOptions{
+		Dialect:         m.dialect,
+		CaseInsensitive: m.caseInsensitive,
+	}
+
+	if m.posixSeparators {
+		opts.PathSeparators = PathSeparatorPOSIX
+	}
+
+	return opts
+}
+
+// NormalizePath returns path in the form matchers compare against rules:
+// slash-separated, relative and clean, without "./" or trailing
+// separators. Windows volume prefixes are kept. Trailing separators carry a directory hint,
+// see DirHint; "" stands for root.
+func NormalizePath(path string, opts NormalizeOptions) string {
+	if opts.Unicode != nil {
+		path = opts.Unicode(path)
+	}
+
+	if opts.PathSeparators == PathSeparatorPOSIX {
+		path = normalizePOSIXPath(path)
+	} else {
+		path = normalizePath(path)
+	}
+
+	if opts.CaseInsensitive {
+		path = asciiLower(path)
+	}
+
+	return path
+}
+
+// NormalizePattern returns a glob pattern as matchers read it before
+// compilation: trimmed, with "\" turned into "/" unless it escapes the next
+// byte (PathSeparatorPOSIX and the git dialects), and case folded. Git
+// dialect patterns are otherwise kept as parsed.
+func NormalizePattern(pattern string, opts NormalizeOptions) string {
+	if opts.Unicode != nil {
+		pattern = opts.Unicode(pattern)
+	}
+
+	switch opts.Dialect {
+	case DialectGit, DialectESLint, DialectPrettier:
+		// Git patterns keep surrounding spaces and backslash escapes.
+	default:
+		pattern = strings.TrimSpace(pattern)
+		if opts.PathSeparators != PathSeparatorPOSIX {
+			pattern = normalizePattern(pattern)
+		}
+	}
+
+	if opts.CaseInsensitive {
+		pattern = asciiLower(pattern)
+	}
+
+	return pattern
+}
+
+// SplitPathSegments returns the segments of NormalizePath(path, opts),
+// nil for root.
+func SplitPathSegments(path string, opts NormalizeOptions) []string {
+	path = NormalizePath(path, opts)
+	if path == "" {
+		return nil
+	}
+
+	return strings.Split(path, "/")
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizePath(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		opts NormalizeOptions
+		raw  string
+		want string
+	}{
+		{raw: ` ./Src\Main.go `, want: "Src/Main.go"},
+		{raw: `C:\repo\a.txt`, want: "C:/repo/a.txt"},
+		{raw: "a//b/./c/", want: "a/b/c"},
+		{raw: "/", want: ""},
+		{opts: NormalizeOptions{CaseInsensitive: true}, raw: "Src/MAIN.go", want: "src/main.go"},
+		{opts: NormalizeOptions{PathSeparators: PathSeparatorPOSIX}, raw: `a\b/c`, want: `a\b/c`},
+		{opts: NormalizeOptions{Unicode: strings.ToUpper}, raw: "a/b", want: "A/B"},
+	}
+
+	for _, tc := range cases {
+		if got := NormalizePath(tc.raw, tc.opts); got != tc.want {
+			t.Fatalf("NormalizePath(%q, %+v)=%q, want %q", tc.raw, tc.opts, got, tc.want)
+		}
+	}
+}
+
+func TestNormalizePattern(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		opts NormalizeOptions
+		raw  string
+		want string
+	}{
+		{raw: ` build\*.o `, want: "build/*.o"},
+		{opts: NormalizeOptions{PathSeparators: PathSeparatorPOSIX}, raw: `\*.o`, want: `\*.o`},
+		{opts: NormalizeOptions{Dialect: DialectGit}, raw: `\#file `, want: `\#file `},
+		{opts: NormalizeOptions{CaseInsensitive: true}, raw: "*.LOG", want: "*.log"},
+	}
+
+	for _, tc := range cases {
+		if got := NormalizePattern(tc.raw, tc.opts); got != tc.want {
+			t.Fatalf("NormalizePattern(%q, %+v)=%q, want %q", tc.raw, tc.opts, got, tc.want)
+		}
+	}
+}
+
+func TestSplitPathSegments(t *testing.T) {
+	t.Parallel()
+
+	if got := SplitPathSegments(`./a\b//c/`, NormalizeOptions{}); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Fatalf("SplitPathSegments=%q, want [a b c]", got)
+	}
+
+	if got := SplitPathSegments(".", NormalizeOptions{}); got != nil {
+		t.Fatalf("SplitPathSegments(.)=%q, want nil", got)
+	}
+}
+
+func TestMatcherNormalizeOptions(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher([]Rule{{Action: ActionExclude, Pattern: "src/*.go"}}, MatcherOptions{
+		CaseInsensitive: true,
+		Dialect:         DialectGit,
+		PathSeparators:  PathSeparatorPOSIX,
+	})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	opts := m.NormalizeOptions()
+	if !opts.CaseInsensitive || opts.Dialect != DialectGit || opts.PathSeparators != PathSeparatorPOSIX {
+		t.Fatalf("NormalizeOptions()=%+v, want matcher settings", opts)
+	}
+
+	if got := NormalizePath("./SRC/Main.go", opts); got != "src/main.go" || m.Included(got, false) {
+		t.Fatalf("NormalizePath=%q, want excluded src/main.go", got)
+	}
+}
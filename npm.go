@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// npmAlwaysExcluded are names npm never packs, whatever "files" or ignore rules say.
+var npmAlwaysExcluded = []string{
+	".git",
+	".git/",
+	".svn/",
+	".hg/",
+	"CVS/",
+	".npmignore",
+	".gitignore",
+	".npmrc",
+	".lock-wscript",
+	".wafpickle-*",
+	".*.swp",
+	".DS_Store",
+	"._*",
+	"*.orig",
+	"npm-debug.log",
+	"config.gypi",
+	"/node_modules/",
+	"/archived-packages/",
+	"/package-lock.json",
+	"/yarn.lock",
+	"/pnpm-lock.yaml",
+}
+
+// npmAlwaysIncludedNames are root file base names npm always packs,
+// matched case-insensitively with or without extension.
+var npmAlwaysIncludedNames = []string{"readme", "license", "licence", "copying"}
+
+// NPMPackage holds package.json fields and ignore rules used by NPMRules.
+type NPMPackage struct {
+	// Files is package.json "files" allowlist, nil when the field is absent.
+	Files []string `json:"files,omitempty" yaml:"files,omitempty"`
+	// Main is package.json "main" entry point, always packed.
+	Main string `json:"main,omitempty" yaml:"main,omitempty"`
+	// Bin lists package.json "bin" targets, always packed.
+	Bin []string `json:"bin,omitempty" yaml:"bin,omitempty"`
+	// IgnoreRules are root ".npmignore" rules (or ".gitignore" rules when
+	// ".npmignore" is absent). They are not applied when Files is set.
+	IgnoreRules []Rule `json:"ignore_rules,omitempty" yaml:"ignore_rules,omitempty"`
+}
+
+// ParseNPMPackage reads "files", "main" and "bin" fields from package.json.
+//
+// "bin" may be a string or a name-to-path object; paths are sorted.
+// IgnoreRules is left empty.
+func ParseNPMPackage(r io.Reader) (*NPMPackage, error) {
+	var raw struct {
+		Main  string          `json:"main"`
+		Files []string        `json:"files"`
+		Bin   json.RawMessage `json:"bin"`
+	}
+
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decode package.json: %w", err)
+	}
+
+	pkg := &NPMPackage{
+		Files: raw.Files,
+		Main:  raw.Main,
+	}
+
+	if len(raw.Bin) == 0 || string(raw.Bin) == "null" {
+		return pkg, nil
+	}
+
+	var single string
+	if err := json.Unmarshal(raw.Bin, &single); err == nil {
+		pkg.Bin = []string{single}
+		return pkg, nil
+	}
+
+	var named map[string]string
+	if err := json.Unmarshal(raw.Bin, &named); err != nil {
+		return nil, fmt.Errorf("decode package.json bin: %w", err)
+	}
+
+	for _, target := range named {
+		pkg.Bin = append(pkg.Bin, target)
+	}
+
+	sort.Strings(pkg.Bin)
+	return pkg, nil
+}
+
+// NPMRules returns rules reproducing npm pack file selection for pkg.
+//
+// Precedence, lowest to highest (last match wins):
+//  1. with Files: everything excluded, then each entry and its contents
+//     included ("!" entries exclude); without Files: IgnoreRules
+//  2. always-excluded names (".git", "node_modules", lock files, ...)
+//  3. always-included root files ("package.json", README, LICENSE/LICENCE,
+//     COPYING, Main and Bin targets)
+//
+// Use the rules with DialectDefault and DefaultAction include. Nested
+// ".npmignore" files can be layered with a Provider.
+func NPMRules(pkg *NPMPackage) []Rule {
+	rules := make([]Rule, 0, 2*len(pkg.Files)+len(npmAlwaysExcluded)+len(npmAlwaysIncludedNames)*2+4)
+	if pkg.Files != nil {
+		rules = append(rules, Rule{Action: ActionExclude, Pattern: "*"})
+		for _, entry := range pkg.Files {
+			action := ActionInclude
+			entry = strings.TrimSpace(entry)
+			if rest, ok := strings.CutPrefix(entry, "!"); ok {
+				action = ActionExclude
+				entry = rest
+			}
+
+			entry = normalizePath(entry)
+			if entry == "" {
+				continue
+			}
+
+			rules = append(rules,
+				Rule{Action: action, Pattern: "/" + entry},
+				Rule{Action: action, Pattern: "/" + entry + "/**"},
+			)
+		}
+	} else {
+		rules = append(rules, pkg.IgnoreRules...)
+	}
+
+	for _, pattern := range npmAlwaysExcluded {
+		rules = append(rules, Rule{Action: ActionExclude, Pattern: pattern})
+	}
+
+	rules = append(rules, Rule{Action: ActionInclude, Pattern: "/package.json"})
+	for _, name := range npmAlwaysIncludedNames {
+		folded := asciiFoldPattern(name)
+		rules = append(rules,
+			Rule{Action: ActionInclude, Pattern: "/" + folded},
+			Rule{Action: ActionInclude, Pattern: "/" + folded + ".*"},
+		)
+	}
+
+	targets := append([]string{pkg.Main}, pkg.Bin...)
+	for _, target := range targets {
+		if target = normalizePath(target); target != "" {
+			rules = append(rules, Rule{Action: ActionInclude, Pattern: "/" + target})
+		}
+	}
+
+	return rules
+}
+
+// NewNPMMatcher compiles NPMRules(pkg) with npm decision defaults.
+func NewNPMMatcher(pkg *NPMPackage) (*Matcher, error) {
+	return NewMatcher(NPMRules(pkg), MatcherOptions{DefaultAction: ActionInclude})
+}
+
+// asciiFoldPattern returns a pattern matching word in any ASCII letter case.
+func asciiFoldPattern(word string) string {
+	var b strings.Builder
+	for i := 0; i < len(word); i++ {
+		c := word[i]
+		if c >= 'a' && c <= 'z' {
+			b.WriteByte('[')
+			b.WriteByte(c)
+			b.WriteByte(c - 'a' + 'A')
+			b.WriteByte(']')
+			continue
+		}
+
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
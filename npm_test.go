@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseNPMPackage(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := ParseNPMPackage(strings.NewReader(`{
+		"name": "demo",
+		"main": "./lib/index.js",
+		"files": ["dist", "!dist/test"],
+		"bin": {"b": "bin/b.js", "a": "bin/a.js"}
+	}`))
+	if err != nil {
+		t.Fatalf("ParseNPMPackage: %v", err)
+	}
+
+	if pkg.Main != "./lib/index.js" || len(pkg.Files) != 2 || len(pkg.Bin) != 2 || pkg.Bin[0] != "bin/a.js" {
+		t.Fatalf("unexpected package: %+v", pkg)
+	}
+
+	pkg, err = ParseNPMPackage(strings.NewReader(`{"bin": "cli.js"}`))
+	if err != nil {
+		t.Fatalf("ParseNPMPackage: %v", err)
+	}
+
+	if pkg.Files != nil || len(pkg.Bin) != 1 || pkg.Bin[0] != "cli.js" {
+		t.Fatalf("unexpected package: %+v", pkg)
+	}
+}
+
+func TestNPMMatcherFilesAllowlist(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewNPMMatcher(&NPMPackage{
+		Files:       []string{"dist/", "!dist/test", "./types/*.d.ts"},
+		Main:        "./lib/index.js",
+		Bin:         []string{"bin/cli.js"},
+		IgnoreRules: []Rule{{Action: ActionExclude, Pattern: "dist"}},
+	})
+	if err != nil {
+		t.Fatalf("NewNPMMatcher: %v", err)
+	}
+
+	cases := map[string]bool{
+		"dist/index.js":        true,
+		"dist/sub/a.js":        true,
+		"dist/test/a.js":       false,
+		"dist/.DS_Store":       false,
+		"dist/.git/config":     false,
+		"types/a.d.ts":         true,
+		"types/a.ts":           false,
+		"src/index.ts":         false,
+		"package.json":         true,
+		"README.md":            true,
+		"Readme":               true,
+		"LICENCE.txt":          true,
+		"docs/README.md":       false,
+		"lib/index.js":         true,
+		"lib/other.js":         false,
+		"bin/cli.js":           true,
+		"package-lock.json":    false,
+		"node_modules/x/a.js":  false,
+		"dist/node_modules/ok": true,
+	}
+
+	for path, want := range cases {
+		if got := m.Included(path, false); got != want {
+			t.Errorf("Included(%q)=%v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestNPMMatcherIgnoreRules(t *testing.T) {
+	t.Parallel()
+
+	ignore, err := ParseRulesString("*.test.js\ncoverage/\n")
+	if err != nil {
+		t.Fatalf("ParseRulesString: %v", err)
+	}
+
+	m, err := NewNPMMatcher(&NPMPackage{IgnoreRules: ignore})
+	if err != nil {
+		t.Fatalf("NewNPMMatcher: %v", err)
+	}
+
+	cases := map[string]bool{
+		"index.js":          true,
+		"a.test.js":         false,
+		"coverage/lcov.txt": false,
+		".npmrc":            false,
+		"yarn.lock":         false,
+		"sub/yarn.lock":     true,
+		"node_modules/a.js": false,
+	}
+
+	for path, want := range cases {
+		if got := m.Included(path, false); got != want {
+			t.Errorf("Included(%q)=%v, want %v", path, got, want)
+		}
+	}
+}
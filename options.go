@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"io/fs"
+	"log/slog"
+	"maps"
+	"slices"
+)
+
+// MatcherOption sets one MatcherOptions field for NewMatcherOpt and WithMatcherOptions.
+type MatcherOption func(*MatcherOptions)
+
+// ProviderOption sets one ProviderOptions field for NewProviderOpt and NewProviderFSOpt.
+type ProviderOption func(*ProviderOptions)
+
+// NewMatcherOpt compiles rules with MatcherOptions built from opts, applied in order.
+func NewMatcherOpt(rules []Rule, opts ...MatcherOption) (*Matcher, error) {
+	var o MatcherOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return NewMatcher(rules, o)
+}
+
+// NewProviderOpt creates a provider rooted at rootDir with ProviderOptions
+// built from opts, applied in order.
+func NewProviderOpt(rootDir string, opts ...ProviderOption) (*Provider, error) {
+	return NewProvider(rootDir, buildProviderOptions(opts))
+}
+
+// NewProviderFSOpt creates a provider reading rules files from fsys with
+// ProviderOptions built from opts, applied in order.
+func NewProviderFSOpt(fsys fs.FS, opts ...ProviderOption) (*Provider, error) {
+	return NewProviderFS(fsys, buildProviderOptions(opts))
+}
+
+// buildProviderOptions applies opts to zero ProviderOptions.
+func buildProviderOptions(opts []ProviderOption) ProviderOptions {
+	var o ProviderOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithCaseInsensitive enables ASCII case-insensitive matching.
+func WithCaseInsensitive() MatcherOption {
+	return func(o *MatcherOptions) { o.CaseInsensitive = true }
+}
+
+// WithSmartCase enables smart-case matching, see MatcherOptions.SmartCase.
+func WithSmartCase() MatcherOption {
+	return func(o *MatcherOptions) { o.SmartCase = true }
+}
+
+// WithDefaultAction sets the decision applied when no rule matched.
+func WithDefaultAction(a Action) MatcherOption {
+	return func(o *MatcherOptions) { o.DefaultAction = a }
+}
+
+// WithDialect selects pattern and decision semantics.
+func WithDialect(d Dialect) MatcherOption {
+	return func(o *MatcherOptions) { o.Dialect = d }
+}
+
+// WithPolicy selects which matching rule decides.
+func WithPolicy(p Policy) MatcherOption {
+	return func(o *MatcherOptions) { o.Policy = p }
+}
+
+// WithPathSeparators selects how "\" is treated.
+func WithPathSeparators(p PathSeparatorPolicy) MatcherOption {
+	return func(o *MatcherOptions) { o.PathSeparators = p }
+}
+
+// WithProfile keeps only common rules and rules of section name.
+func WithProfile(name string) MatcherOption {
+	return func(o *MatcherOptions) { o.Profile = name }
+}
+
+// WithTrace sets the rule test tracer, see MatcherOptions.Trace.
+func WithTrace(fn func(TraceEvent)) MatcherOption {
+	return func(o *MatcherOptions) { o.Trace = fn }
+}
+
+// WithMemoSize enables an LRU cache of n recent decisions.
+func WithMemoSize(n int) MatcherOption {
+	return func(o *MatcherOptions) { o.MemoSize = n }
+}
+
+// WithCoverage enables per-rule coverage counting.
+func WithCoverage() MatcherOption {
+	return func(o *MatcherOptions) { o.TrackCoverage = true }
+}
+
+// WithResultRule sets MatchResult.Rule to the winning rule.
+func WithResultRule() MatcherOption {
+	return func(o *MatcherOptions) { o.ResultRule = true }
+}
+
+// WithStrictPaths rejects empty, absolute and traversal candidate paths.
+func WithStrictPaths() MatcherOption {
+	return func(o *MatcherOptions) { o.StrictPaths = true }
+}
+
+// WithActionHandler resolves custom action a with h.
+func WithActionHandler(a Action, h ActionHandler) MatcherOption {
+	return func(o *MatcherOptions) {
+		o.ActionHandlers = maps.Clone(o.ActionHandlers)
+		if o.ActionHandlers == nil {
+			o.ActionHandlers = make(map[Action]ActionHandler)
+		}
+
+		o.ActionHandlers[a] = h
+	}
+}
+
+// WithMatcherOptions applies matcher options to ProviderOptions.MatcherOptions.
+func WithMatcherOptions(opts ...MatcherOption) ProviderOption {
+	return func(o *ProviderOptions) {
+		for _, opt := range opts {
+			opt(&o.MatcherOptions)
+		}
+	}
+}
+
+// WithRulesFileName sets the per-directory rules file name.
+func WithRulesFileName(name string) ProviderOption {
+	return func(o *ProviderOptions) { o.RulesFileName = name }
+}
+
+// WithRulesFormat selects rules file syntax.
+func WithRulesFormat(f RulesFormat) ProviderOption {
+	return func(o *ProviderOptions) { o.RulesFormat = f }
+}
+
+// WithBaseRules appends rules evaluated before rules files.
+func WithBaseRules(rules ...Rule) ProviderOption {
+	return func(o *ProviderOptions) { o.BaseRules = append(slices.Clip(o.BaseRules), rules...) }
+}
+
+// WithFinalRules appends rules evaluated after rules files.
+func WithFinalRules(rules ...Rule) ProviderOption {
+	return func(o *ProviderOptions) { o.FinalRules = append(slices.Clip(o.FinalRules), rules...) }
+}
+
+// WithSections enables rules file sections and selects names.
+func WithSections(names ...string) ProviderOption {
+	return func(o *ProviderOptions) { o.Sections = append(slices.Clip(o.Sections), names...) }
+}
+
+// WithRulesSource loads directory rules from s instead of rules files.
+func WithRulesSource(s RulesSource) ProviderOption {
+	return func(o *ProviderOptions) { o.RulesSource = s }
+}
+
+// WithDirPrecedence selects how rules files along a path are combined.
+func WithDirPrecedence(d DirPrecedence) ProviderOption {
+	return func(o *ProviderOptions) { o.DirPrecedence = d }
+}
+
+// WithParentExclusionBlocksReinclude keeps paths below excluded directories excluded.
+func WithParentExclusionBlocksReinclude() ProviderOption {
+	return func(o *ProviderOptions) { o.ParentExclusionBlocksReinclude = true }
+}
+
+// WithSymlinkEscapeCheck blocks rules files reached through symlinks leading outside root.
+func WithSymlinkEscapeCheck() ProviderOption {
+	return func(o *ProviderOptions) { o.EnableSymlinkEscapeCheck = true }
+}
+
+// WithRuleFileErrorPolicy selects how unreadable or invalid rules files are handled.
+func WithRuleFileErrorPolicy(p RuleFileErrorPolicy) ProviderOption {
+	return func(o *ProviderOptions) { o.OnRuleFileError = p }
+}
+
+// WithMissingPaths selects how DecideStat treats paths that do not exist.
+func WithMissingPaths(m MissingPathPolicy) ProviderOption {
+	return func(o *ProviderOptions) { o.MissingPaths = m }
+}
+
+// WithLogger sets the logger receiving provider debug records.
+func WithLogger(l *slog.Logger) ProviderOption {
+	return func(o *ProviderOptions) { o.Logger = l }
+}
+
+// WithInstrumentation sets the observer of provider operations.
+func WithInstrumentation(i Instrumentation) ProviderOption {
+	return func(o *ProviderOptions) { o.Instrumentation = i }
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestNewMatcherOpt(t *testing.T) {
+	t.Parallel()
+
+	var traced int
+	m, err := NewMatcherOpt(MustParseRulesString("!*.GO\n"),
+		WithCaseInsensitive(),
+		WithDefaultAction(ActionExclude),
+		WithTrace(func(TraceEvent) { traced++ }),
+	)
+	if err != nil {
+		t.Fatalf("NewMatcherOpt: %v", err)
+	}
+
+	if !m.Included("main.go", false) {
+		t.Fatal("main.go excluded, want included by case-insensitive rule")
+	}
+
+	if m.Included("readme.md", false) {
+		t.Fatal("readme.md included, want excluded by default action")
+	}
+
+	if traced == 0 {
+		t.Fatal("trace not called")
+	}
+}
+
+func TestNewProviderFSOpt(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"sub/.ignore": {Data: []byte("!keep.tmp\n")},
+	}
+
+	p, err := NewProviderFSOpt(fsys,
+		WithRulesFileName(".ignore"),
+		WithBaseRules(MustParseRulesString("*.tmp\n")...),
+		WithFinalRules(MustParseRulesString("secret.*\n")...),
+		WithMatcherOptions(WithCaseInsensitive()),
+	)
+	if err != nil {
+		t.Fatalf("NewProviderFSOpt: %v", err)
+	}
+
+	for path, want := range map[string]bool{
+		"a.TMP":          false,
+		"sub/keep.tmp":   true,
+		"sub/SECRET.txt": false,
+		"sub/b.txt":      true,
+	} {
+		included, err := p.Included(path, false)
+		if err != nil {
+			t.Fatalf("Included(%s): %v", path, err)
+		}
+
+		if included != want {
+			t.Fatalf("Included(%s)=%v, want %v", path, included, want)
+		}
+	}
+}
+
+func TestWithActionHandlerDoesNotAlias(t *testing.T) {
+	t.Parallel()
+
+	var base MatcherOptions
+	WithActionHandler(ActionCustom, func(string, bool, Rule) bool { return true })(&base)
+
+	derived := base
+	WithActionHandler(ActionCustom+1, func(string, bool, Rule) bool { return false })(&derived)
+
+	if len(base.ActionHandlers) != 1 || len(derived.ActionHandlers) != 2 {
+		t.Fatalf("handlers base=%d derived=%d, want 1 and 2", len(base.ActionHandlers), len(derived.ActionHandlers))
+	}
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+)
+
+// readRootRulesFile reads one directory rules file through the provider os.Root.
+//
+// Every path component is opened relative to its parent directory, so
+// symlinks swapped in concurrently cannot lead the read outside root.
+func (p *Provider) readRootRulesFile(relDir string) (rulesFile, error) {
+	name := path.Join(relDir, p.rulesFileName)
+	f, err := p.osRoot.Open(name)
+	return readRulesFile(f, err, filepath.Join(p.root, filepath.FromSlash(name)), p.maxRulesFileSize)
+}
+
+// statRootRulesFile stats one directory rules file through the provider os.Root.
+func (p *Provider) statRootRulesFile(relDir string) (fs.FileInfo, error) {
+	return p.osRoot.Stat(path.Join(relDir, p.rulesFileName))
+}
+
+// Close releases the root directory handle opened with
+// ProviderOptions.UseOSRoot; the provider and its Scope views must not be
+// used afterwards. It is a no-op for other providers.
+func (p *Provider) Close() error {
+	if p == nil {
+		return ErrNilProvider
+	}
+
+	if p.osRoot == nil {
+		return nil
+	}
+
+	return p.osRoot.Close()
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProviderUseOSRoot(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	outside := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, ".pathrules"), "*.tmp\n")
+	writeRulesFile(t, filepath.Join(outside, ".pathrules"), "!*.tmp\n")
+	if err := os.Symlink(outside, filepath.Join(root, "linked")); err != nil {
+		t.Skipf("symlink not available: %v", err)
+	}
+
+	p, err := NewProvider(root, ProviderOptions{UseOSRoot: true})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	defer func() { _ = p.Close() }()
+
+	if included, err := p.Included("a.tmp", false); err != nil || included {
+		t.Fatalf("Included(a.tmp)=%v err=%v, want excluded", included, err)
+	}
+
+	if _, err := p.Included("linked/a.tmp", false); err == nil {
+		t.Fatal("Included(linked/a.tmp) err=nil, want escape error from os.Root")
+	}
+}
@@ -0,0 +1,346 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const defaultOwnersFileName = "CODEOWNERS"
+
+// OwnersRule is one CODEOWNERS-like line: a pattern and its owners.
+type OwnersRule struct {
+	// Pattern is a gitignore-like pattern.
+	Pattern string `json:"pattern" yaml:"pattern"`
+	// Source is the owners file name, empty for in-memory rules.
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+	// Owners are owner values in file order, empty for explicitly unowned paths.
+	Owners []string `json:"owners,omitempty" yaml:"owners,omitempty"`
+	// Line is the 1-based line number in Source, 0 when unknown.
+	Line int `json:"line,omitempty" yaml:"line,omitempty"`
+}
+
+// OwnersResult is an ownership lookup result.
+type OwnersResult struct {
+	// Owners are owners of the last matching line, nil when unmatched or unowned.
+	Owners []string `json:"owners,omitempty" yaml:"owners,omitempty"`
+	// Source is the owners file of the matching line.
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+	// RuleIndex is the matched rule index in owners file order, -1 when no match.
+	RuleIndex int `json:"rule_index" yaml:"rule_index"`
+	// Line is the 1-based line number of the matching line, 0 when unknown.
+	Line int `json:"line,omitempty" yaml:"line,omitempty"`
+	// Matched reports whether at least one line matched.
+	Matched bool `json:"matched" yaml:"matched"`
+}
+
+// OwnersMatcher resolves path owners with last-matching-line-wins semantics.
+//
+// A line matching a directory also owns everything inside it.
+type OwnersMatcher struct {
+	// matcher holds compiled patterns in rules order.
+	matcher *Matcher
+	// rules are source owners rules aligned with compiled patterns.
+	rules []OwnersRule
+}
+
+// OwnersProviderOptions configures hierarchical owners lookup.
+type OwnersProviderOptions struct {
+	// RulesFileName is the owners file loaded in each directory.
+	// Empty value defaults to "CODEOWNERS".
+	RulesFileName string `json:"rules_file_name,omitempty" yaml:"rules_file_name,omitempty"`
+	// MatcherOptions controls pattern matching; DefaultAction is ignored.
+	// DialectGit reproduces GitHub CODEOWNERS pattern semantics.
+	MatcherOptions MatcherOptions `json:"matcher_options" yaml:"matcher_options"`
+	// EnableSymlinkEscapeCheck blocks owners files resolved outside root.
+	EnableSymlinkEscapeCheck bool `json:"enable_symlink_escape_check,omitempty" yaml:"enable_symlink_escape_check,omitempty"`
+}
+
+// OwnersProvider loads owners files along path hierarchy.
+//
+// The deepest owners file with a matching line decides; within one file the
+// last matching line wins.
+type OwnersProvider struct {
+	// files validates paths and reads owners files under root.
+	files *Provider
+	// cache stores directory-local owners matcher by relative directory path.
+	cache map[string]*cachedOwnersMatcher
+	// matcherOptions are shared pattern compilation options.
+	matcherOptions MatcherOptions
+	// mu guards cache access.
+	mu sync.Mutex
+}
+
+// cachedOwnersMatcher stores one directory owners matcher or a cached load error.
+type cachedOwnersMatcher struct {
+	// matcher is nil when directory has no owners file.
+	matcher *OwnersMatcher
+	// err stores parse/compile error for deterministic repeated calls.
+	err error
+}
+
+// ParseOwners parses CODEOWNERS-like rules from reader.
+//
+// Semantics:
+//   - blank lines and "#" comments are ignored, "#" after owners starts a comment
+//   - first field is the pattern ("\ " escapes space, "\#" leading "#")
+//   - remaining fields are owners, none marks the path as unowned
+func ParseOwners(r io.Reader, sourceName string) ([]OwnersRule, error) {
+	s := bufio.NewScanner(r)
+	rules := make([]OwnersRule, 0, 16)
+	lineNo := 0
+
+	for s.Scan() {
+		lineNo++
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		pattern, rest := splitOwnersPattern(line)
+		if pattern == "" {
+			return nil, &RuleError{
+				File:    sourceName,
+				Line:    lineNo,
+				Pattern: line,
+				Err:     fmt.Errorf("%w: empty pattern", ErrInvalidRule),
+			}
+		}
+
+		var owners []string
+		for _, field := range strings.Fields(rest) {
+			if strings.HasPrefix(field, "#") {
+				break
+			}
+
+			owners = append(owners, field)
+		}
+
+		rules = append(rules, OwnersRule{
+			Pattern: pattern,
+			Owners:  owners,
+			Source:  sourceName,
+			Line:    lineNo,
+		})
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("scan owners: %w", err)
+	}
+
+	return rules, nil
+}
+
+// NewOwnersMatcher compiles owners rules with matcher options.
+//
+// DefaultAction is ignored. DialectGit reproduces GitHub CODEOWNERS
+// pattern semantics.
+func NewOwnersMatcher(rules []OwnersRule, opts MatcherOptions) (*OwnersMatcher, error) {
+	patterns := make([]Rule, len(rules))
+	for i := range rules {
+		patterns[i] = Rule{
+			Action:  ActionInclude,
+			Pattern: rules[i].Pattern,
+			Source:  rules[i].Source,
+			Line:    rules[i].Line,
+		}
+	}
+
+	matcher, err := NewMatcher(patterns, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OwnersMatcher{
+		matcher: matcher,
+		rules:   slices.Clone(rules),
+	}, nil
+}
+
+// Owners returns owners of path from the last matching line.
+func (m *OwnersMatcher) Owners(path string, isDir bool) OwnersResult {
+	candidate := m.matcher.normalizeCandidate(path)
+	isDir = isDir || m.matcher.dirHint(path)
+	if m.matcher.overPathLimit(candidate) {
+		return OwnersResult{RuleIndex: -1}
+	}
+
+	// Dialect default rules are not ownership lines and are skipped.
+	for i := len(m.rules) - 1; i >= 0; i-- {
+		if !matchesSelfOrParent(&m.matcher.compiled[m.matcher.compiledIndex(i)], candidate, isDir) {
+			continue
+		}
+
+		rule := &m.rules[i]
+		return OwnersResult{
+			Owners:    slices.Clone(rule.Owners),
+			Source:    rule.Source,
+			RuleIndex: i,
+			Line:      rule.Line,
+			Matched:   true,
+		}
+	}
+
+	return OwnersResult{RuleIndex: -1}
+}
+
+// NewOwnersProvider creates a hierarchical owners provider rooted at rootDir.
+func NewOwnersProvider(rootDir string, opts OwnersProviderOptions) (*OwnersProvider, error) {
+	rulesFileName := opts.RulesFileName
+	if strings.TrimSpace(rulesFileName) == "" {
+		rulesFileName = defaultOwnersFileName
+	}
+
+	files, err := NewProvider(rootDir, ProviderOptions{
+		RulesFileName:            rulesFileName,
+		MatcherOptions:           opts.MatcherOptions,
+		EnableSymlinkEscapeCheck: opts.EnableSymlinkEscapeCheck,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &OwnersProvider{
+		files:          files,
+		matcherOptions: files.matcherOptions,
+		cache:          make(map[string]*cachedOwnersMatcher),
+	}, nil
+}
+
+// Owners returns owners for a path relative to provider root.
+func (p *OwnersProvider) Owners(relPath string, isDir bool) (OwnersResult, error) {
+	if p == nil {
+		return OwnersResult{}, ErrNilProvider
+	}
+
+	normalized, err := p.files.cleanInputPath(relPath)
+	if err != nil {
+		return OwnersResult{}, err
+	}
+
+	isDir = isDir || dirHint(relPath, p.files.posixSeparators())
+
+	// Walk from the deepest directory up to root, first match decides.
+	relDir := pathDir(normalized, isDir)
+	for {
+		if relDir != normalized {
+			res, err := p.ownersInDir(relDir, normalized, isDir)
+			if err != nil || res.Matched {
+				return res, err
+			}
+		}
+
+		if relDir == "" {
+			return OwnersResult{RuleIndex: -1}, nil
+		}
+
+		relDir = pathDir(relDir, false)
+	}
+}
+
+// ownersInDir evaluates one directory owners file for normalized path.
+func (p *OwnersProvider) ownersInDir(relDir string, normalized string, isDir bool) (OwnersResult, error) {
+	matcher, err := p.loadOwnersMatcher(relDir)
+	if err != nil || matcher == nil {
+		return OwnersResult{RuleIndex: -1}, err
+	}
+
+	candidate := normalized
+	if relDir != "" {
+		candidate = normalized[len(relDir)+1:]
+	}
+
+	return matcher.Owners(candidate, isDir), nil
+}
+
+// loadOwnersMatcher returns cached or newly loaded owners matcher for one relative directory.
+func (p *OwnersProvider) loadOwnersMatcher(relDir string) (*OwnersMatcher, error) {
+	p.mu.Lock()
+	cached, ok := p.cache[relDir]
+	p.mu.Unlock()
+	if ok {
+		return cached.matcher, cached.err
+	}
+
+	cached = &cachedOwnersMatcher{}
+	file, err := p.files.readDirRulesFile(relDir)
+	switch {
+	case err != nil:
+		cached.err = err
+	case file.found:
+		rules, parseErr := ParseOwners(bytes.NewReader(file.content), file.path)
+		if parseErr != nil {
+			cached.err = fmt.Errorf("parse %s: %w", file.path, parseErr)
+			break
+		}
+
+		cached.matcher, cached.err = NewOwnersMatcher(rules, p.matcherOptions)
+		if cached.err != nil {
+			cached.err = fmt.Errorf("compile %s: %w", file.path, cached.err)
+		}
+	}
+
+	if cached.err != nil {
+		cached.err = &LoadError{Err: cached.err, Dir: relDir, Path: p.files.rulesFilePath(relDir)}
+	}
+
+	p.mu.Lock()
+	if existing, ok := p.cache[relDir]; ok {
+		cached = existing
+	} else {
+		p.cache[relDir] = cached
+	}
+	p.mu.Unlock()
+
+	return cached.matcher, cached.err
+}
+
+// matchesSelfOrParent reports whether rule matches candidate or one of its parent directories.
+func matchesSelfOrParent(rule *compiledRule, candidate string, isDir bool) bool {
+	if rule.matches(candidate, isDir) {
+		return true
+	}
+
+	for i := 0; i < len(candidate); i++ {
+		if candidate[i] == '/' && rule.matches(candidate[:i], true) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitOwnersPattern splits leading pattern field honoring "\ " and leading "\#" escapes.
+func splitOwnersPattern(line string) (string, string) {
+	var b strings.Builder
+	i := 0
+	if strings.HasPrefix(line, `\#`) {
+		b.WriteByte('#')
+		i = 2
+	}
+
+	for ; i < len(line); i++ {
+		c := line[i]
+		if c == '\\' && i+1 < len(line) && line[i+1] == ' ' {
+			b.WriteByte(' ')
+			i++
+			continue
+		}
+
+		if c == ' ' || c == '\t' {
+			break
+		}
+
+		b.WriteByte(c)
+	}
+
+	return b.String(), line[i:]
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseOwners(t *testing.T) {
+	t.Parallel()
+
+	src := "# owners\n*       @org/all\n/docs/ @docs @writer # trailing\nmy\\ file.txt @a\n\\#hash @b\n/vendor/\n"
+	rules, err := ParseOwners(strings.NewReader(src), "CODEOWNERS")
+	if err != nil {
+		t.Fatalf("ParseOwners: %v", err)
+	}
+
+	want := []OwnersRule{
+		{Pattern: "*", Owners: []string{"@org/all"}, Source: "CODEOWNERS", Line: 2},
+		{Pattern: "/docs/", Owners: []string{"@docs", "@writer"}, Source: "CODEOWNERS", Line: 3},
+		{Pattern: "my file.txt", Owners: []string{"@a"}, Source: "CODEOWNERS", Line: 4},
+		{Pattern: "#hash", Owners: []string{"@b"}, Source: "CODEOWNERS", Line: 5},
+		{Pattern: "/vendor/", Source: "CODEOWNERS", Line: 6},
+	}
+
+	if len(rules) != len(want) {
+		t.Fatalf("rules=%+v, want %+v", rules, want)
+	}
+
+	for i := range want {
+		got := rules[i]
+		if got.Pattern != want[i].Pattern || got.Line != want[i].Line || got.Source != want[i].Source ||
+			!slices.Equal(got.Owners, want[i].Owners) {
+			t.Fatalf("rules[%d]=%+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestOwnersMatcherLastLineWins(t *testing.T) {
+	t.Parallel()
+
+	rules, err := ParseOwners(strings.NewReader("* @all\n*.js @js\n/apps/ @apps\n/apps/github @octo\n/apps/vendor/\n"), "")
+	if err != nil {
+		t.Fatalf("ParseOwners: %v", err)
+	}
+
+	m, err := NewOwnersMatcher(rules, MatcherOptions{Dialect: DialectGit})
+	if err != nil {
+		t.Fatalf("NewOwnersMatcher: %v", err)
+	}
+
+	cases := []struct {
+		path  string
+		owner string
+		line  int
+	}{
+		{path: "README.md", owner: "@all", line: 1},
+		{path: "src/index.js", owner: "@js", line: 2},
+		{path: "apps/web/index.js", owner: "@apps", line: 3},
+		{path: "apps/github/deep/file.go", owner: "@octo", line: 4},
+		{path: "apps/vendor/lib.js", owner: "", line: 5},
+	}
+
+	for _, tc := range cases {
+		res := m.Owners(tc.path, false)
+		owner := ""
+		if len(res.Owners) > 0 {
+			owner = res.Owners[0]
+		}
+
+		if !res.Matched || owner != tc.owner || res.Line != tc.line {
+			t.Fatalf("Owners(%q)=%+v, want owner %q line %d", tc.path, res, tc.owner, tc.line)
+		}
+	}
+
+	empty, err := NewOwnersMatcher(nil, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewOwnersMatcher: %v", err)
+	}
+
+	if res := empty.Owners("a", false); res.Matched || res.RuleIndex != -1 {
+		t.Fatalf("empty matcher result=%+v", res)
+	}
+}
+
+func TestOwnersProviderHierarchy(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, "CODEOWNERS"), "* @root\n/lib/ @lib\n")
+	if err := os.MkdirAll(filepath.Join(root, "lib", "core"), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	writeRulesFile(t, filepath.Join(root, "lib", "core", "CODEOWNERS"), "*.go @core\n")
+
+	p, err := NewOwnersProvider(root, OwnersProviderOptions{
+		MatcherOptions: MatcherOptions{Dialect: DialectGit},
+	})
+	if err != nil {
+		t.Fatalf("NewOwnersProvider: %v", err)
+	}
+
+	cases := map[string]string{
+		"main.go":          "@root",
+		"lib/a.c":          "@lib",
+		"lib/core/a.c":     "@lib",
+		"lib/core/x/a.go":  "@core",
+		"lib/core":         "@lib",
+		"docs/guide/x.txt": "@root",
+	}
+
+	for path, want := range cases {
+		isDir := path == "lib/core"
+		res, err := p.Owners(path, isDir)
+		if err != nil {
+			t.Fatalf("Owners(%q): %v", path, err)
+		}
+
+		if len(res.Owners) != 1 || res.Owners[0] != want {
+			t.Fatalf("Owners(%q)=%+v, want %s", path, res, want)
+		}
+	}
+
+	if _, err := p.Owners("../x", false); err == nil {
+		t.Fatalf("expected traversal error")
+	}
+}
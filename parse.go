@@ -11,6 +11,22 @@ import (
 	"strings"
 )
 
+// ParseOptions controls rules text parsing.
+type ParseOptions struct {
+	// SourceName is recorded as Rule.Source and used in error messages.
+	SourceName string `json:"source_name,omitempty" yaml:"source_name,omitempty"`
+	// Strict validates every pattern while parsing and returns *ParseError
+	// listing all invalid lines instead of deferring failures to NewMatcher.
+	Strict bool `json:"strict,omitempty" yaml:"strict,omitempty"`
+	// Sections enables "[name]" section header lines. Rules after a header
+	// record it in Rule.Section; rules before the first header are common.
+	// Disabled by default because "[abc]" is also a valid char-class pattern.
+	Sections bool `json:"sections,omitempty" yaml:"sections,omitempty"`
+	// Actions enables "@name pattern" lines applying the named custom action;
+	// "\@" escapes a leading "@". Unknown names are invalid lines.
+	Actions map[string]Action `json:"actions,omitempty" yaml:"actions,omitempty"`
+}
+
 // ParseRules parses gitignore-like rules from reader.
 //
 // Semantics:
@@ -18,11 +34,51 @@ import (
 // - "!" creates include rule
 // - plain lines create exclude rule
 // - "\#" and "\!" escape leading comment/negation tokens
+// - with ParseOptions.Actions, "@name pattern" applies a custom action
+//
+// Parsed rules carry 1-based line numbers and an empty Source.
 func ParseRules(r io.Reader) ([]Rule, error) {
+	return ParseRulesNamed(r, "")
+}
+
+// ParseRulesNamed parses rules like ParseRules and records sourceName and
+// 1-based line number in every parsed rule.
+func ParseRulesNamed(r io.Reader, sourceName string) ([]Rule, error) {
+	return ParseRulesWithOptions(r, ParseOptions{SourceName: sourceName})
+}
+
+// ParseRulesWithOptions parses rules with explicit parse options.
+//
+// In strict mode every invalid line is collected; the returned error is
+// *ParseError and matches ErrInvalidPattern/ErrInvalidRule via errors.Is.
+// Header directives are validated but discarded, use ParseRuleSet to keep them.
+func ParseRulesWithOptions(r io.Reader, opts ParseOptions) ([]Rule, error) {
+	rs, err := ParseRuleSet(r, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return rs.Rules, nil
+}
+
+// ParseRuleSet parses rules together with "#pragma" header directives.
+//
+// Directives are recognized only before the first rule; later "#pragma"
+// lines are ordinary comments. Invalid values of known directives are
+// reported as *ParseError matching ErrInvalidDirective; unknown directive
+// names are reported only in strict mode and ignored otherwise.
+func ParseRuleSet(r io.Reader, opts ParseOptions) (*RuleSet, error) {
 	s := bufio.NewScanner(r)
-	rules := make([]Rule, 0, 16)
+	rs := &RuleSet{
+		Rules: make([]Rule, 0, 16),
+	}
+	lineNo := 0
+	header := true
+	section := ""
 
+	var lineErrs []*RuleError
 	for s.Scan() {
+		lineNo++
 		line := strings.TrimRight(s.Text(), "\r")
 		if line == "" {
 			continue
@@ -34,18 +90,55 @@ func ParseRules(r io.Reader) ([]Rule, error) {
 		}
 
 		if strings.HasPrefix(line, "#") {
+			if header {
+				if err := rs.Directives.parsePragma(line, opts.Strict); err != nil {
+					lineErrs = append(lineErrs, &RuleError{
+						File:    opts.SourceName,
+						Line:    lineNo,
+						Pattern: line,
+						Err:     err,
+					})
+				}
+			}
+
 			continue
 		}
 
+		header = false
+		if opts.Sections {
+			if name, ok := parseSectionHeader(line); ok {
+				section = name
+				continue
+			}
+		}
+
 		if strings.HasPrefix(line, `\#`) {
 			line = line[1:]
 		}
 
 		action := ActionExclude
-		if strings.HasPrefix(line, "!") {
+		switch {
+		case opts.Actions != nil && strings.HasPrefix(line, "@"):
+			name, pattern, _ := strings.Cut(line[1:], " ")
+			custom, ok := opts.Actions[name]
+			if !ok || !custom.custom() {
+				lineErrs = append(lineErrs, &RuleError{
+					File:    opts.SourceName,
+					Line:    lineNo,
+					Pattern: line,
+					Err:     fmt.Errorf("%w: unknown action %q", ErrInvalidRule, name),
+				})
+				continue
+			}
+
+			action = custom
+			line = strings.TrimLeft(pattern, " \t")
+		case opts.Actions != nil && strings.HasPrefix(line, `\@`):
+			line = line[1:]
+		case strings.HasPrefix(line, "!"):
 			action = ActionInclude
 			line = line[1:]
-		} else if strings.HasPrefix(line, `\!`) {
+		case strings.HasPrefix(line, `\!`):
 			line = line[1:]
 		}
 
@@ -53,17 +146,38 @@ func ParseRules(r io.Reader) ([]Rule, error) {
 			continue
 		}
 
-		rules = append(rules, Rule{
+		rule := Rule{
 			Action:  action,
 			Pattern: line,
-		})
+			Source:  opts.SourceName,
+			Line:    lineNo,
+			Section: section,
+		}
+
+		if opts.Strict {
+			if _, err := compileRuleWithOptions(rule, &MatcherOptions{}); err != nil {
+				lineErrs = append(lineErrs, &RuleError{
+					File:    opts.SourceName,
+					Line:    lineNo,
+					Pattern: rule.Pattern,
+					Err:     err,
+				})
+				continue
+			}
+		}
+
+		rs.Rules = append(rs.Rules, rule)
 	}
 
 	if err := s.Err(); err != nil {
 		return nil, fmt.Errorf("scan rules: %w", err)
 	}
 
-	return rules, nil
+	if len(lineErrs) > 0 {
+		return nil, &ParseError{Errors: lineErrs}
+	}
+
+	return rs, nil
 }
 
 // ParseRulesString parses rules from string input.
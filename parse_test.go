@@ -4,7 +4,11 @@
 
 package pathrules
 
-import "testing"
+import (
+	"errors"
+	"strings"
+	"testing"
+)
 
 func TestParseRules(t *testing.T) {
 	t.Parallel()
@@ -45,3 +49,56 @@ name\
 		t.Fatalf("rule[4]=%+v", rules[4])
 	}
 }
+
+func TestParseRulesNamed(t *testing.T) {
+	t.Parallel()
+
+	rules, err := ParseRulesNamed(strings.NewReader("# header\n\n*.tmp\r\n!keep.tmp\n"), "dir/.rules")
+	if err != nil {
+		t.Fatalf("ParseRulesNamed: %v", err)
+	}
+
+	if len(rules) != 2 {
+		t.Fatalf("len(rules)=%d, want 2", len(rules))
+	}
+
+	if rules[0].Source != "dir/.rules" || rules[0].Line != 3 {
+		t.Fatalf("rule[0]=%+v, want dir/.rules:3", rules[0])
+	}
+
+	if rules[1].Source != "dir/.rules" || rules[1].Line != 4 {
+		t.Fatalf("rule[1]=%+v, want dir/.rules:4", rules[1])
+	}
+}
+
+func TestParseRulesStrict(t *testing.T) {
+	t.Parallel()
+
+	src := "*.tmp\n/\nok/\n//\n"
+
+	rules, err := ParseRulesWithOptions(strings.NewReader(src), ParseOptions{})
+	if err != nil || len(rules) != 4 {
+		t.Fatalf("non-strict rules=%d err=%v, want 4 rules", len(rules), err)
+	}
+
+	_, err = ParseRulesWithOptions(strings.NewReader(src), ParseOptions{
+		SourceName: "x.rules",
+		Strict:     true,
+	})
+	if !errors.Is(err, ErrInvalidPattern) {
+		t.Fatalf("err=%v, want ErrInvalidPattern", err)
+	}
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("err=%T, want *ParseError", err)
+	}
+
+	if len(parseErr.Errors) != 2 {
+		t.Fatalf("len(Errors)=%d, want 2", len(parseErr.Errors))
+	}
+
+	if parseErr.Errors[0].Line != 2 || parseErr.Errors[1].Line != 4 || parseErr.Errors[1].File != "x.rules" {
+		t.Fatalf("unexpected line errors: %v", err)
+	}
+}
@@ -7,8 +7,29 @@ package pathrules
 import (
 	"path"
 	"strings"
+	"unicode"
 )
 
+// DirHint reports whether raw ends with a path separator ("/" or "\"),
+// which Decide treats as an implicit isDir=true hint.
+//
+// Surrounding whitespace is ignored. Normalization still strips the
+// separator, so "build/" and "build" address the same candidate.
+func DirHint(raw string) bool {
+	return dirHint(raw, false)
+}
+
+// dirHint is DirHint with "\" ignored when posix is set.
+func dirHint(raw string, posix bool) bool {
+	raw = strings.TrimRightFunc(raw, unicode.IsSpace)
+	if raw == "" {
+		return false
+	}
+
+	last := raw[len(raw)-1]
+	return last == '/' || (!posix && last == '\\')
+}
+
 // normalizePath normalizes matching path to slash-separated relative clean form.
 func normalizePath(raw string) string {
 	raw = strings.TrimSpace(raw)
@@ -16,6 +37,16 @@ func normalizePath(raw string) string {
 		raw = strings.ReplaceAll(raw, `\`, `/`)
 	}
 
+	return cleanSlashPath(raw)
+}
+
+// normalizePOSIXPath is normalizePath with "/" as the only separator.
+func normalizePOSIXPath(raw string) string {
+	return cleanSlashPath(strings.TrimSpace(raw))
+}
+
+// cleanSlashPath cleans a "/"-separated path into relative normalized form.
+func cleanSlashPath(raw string) string {
 	raw = strings.TrimPrefix(raw, "./")
 	raw = strings.TrimPrefix(raw, "/")
 	if raw == "" {
@@ -36,6 +67,105 @@ func normalizePath(raw string) string {
 	return strings.TrimSuffix(raw, "/")
 }
 
+// windowsVolumeLen returns the length of a leading Windows volume prefix:
+// drive "C:", UNC "\\server\share", device "\\?\C:", "\\?\UNC\server\share"
+// or "\\?\Volume{id}"; 0 when raw has none.
+//
+// UNC and device prefixes are recognized in backslash form only, so "//a/b"
+// stays a plain path.
+func windowsVolumeLen(raw string) int {
+	if !strings.HasPrefix(raw, `\\`) {
+		return driveLetterLen(raw)
+	}
+
+	if len(raw) >= 4 && (raw[2] == '?' || raw[2] == '.') && isWindowsSeparator(raw[3]) {
+		rest := raw[4:]
+		switch {
+		case len(rest) >= 4 && strings.EqualFold(rest[:3], "UNC") && isWindowsSeparator(rest[3]):
+			return 8 + componentsLen(rest[4:], 2)
+		case driveLetterLen(rest) > 0:
+			return 6
+		default:
+			return 4 + componentsLen(rest, 1)
+		}
+	}
+
+	return 2 + componentsLen(raw[2:], 2)
+}
+
+// driveLetterLen returns 2 when raw starts with a drive letter volume ("C:", "C:\").
+func driveLetterLen(raw string) int {
+	if len(raw) < 2 || raw[1] != ':' || (raw[0]|0x20 < 'a' || raw[0]|0x20 > 'z') {
+		return 0
+	}
+
+	if len(raw) > 2 && !isWindowsSeparator(raw[2]) {
+		return 0
+	}
+
+	return 2
+}
+
+// componentsLen returns the length of the first n separator-delimited components of s.
+func componentsLen(s string, n int) int {
+	for i := 0; i < len(s); i++ {
+		if isWindowsSeparator(s[i]) {
+			n--
+			if n == 0 {
+				return i
+			}
+		}
+	}
+
+	return len(s)
+}
+
+// isWindowsSeparator reports whether c separates Windows path components.
+func isWindowsSeparator(c byte) bool {
+	return c == '\\' || c == '/'
+}
+
+// canonicalWindowsPath converts device paths ("\\?\C:\x", "\\?\UNC\s\x") to
+// their plain form with backslash separators and no trailing separator.
+func canonicalWindowsPath(raw string) string {
+	raw = strings.ReplaceAll(raw, "/", `\`)
+	if rest, ok := strings.CutPrefix(raw, `\\?\`); ok {
+		if len(rest) >= 4 && strings.EqualFold(rest[:4], `UNC\`) {
+			raw = `\\` + rest[4:]
+		} else if driveLetterLen(rest) > 0 {
+			raw = rest
+		}
+	}
+
+	if len(raw) > windowsVolumeLen(raw)+1 {
+		raw = strings.TrimSuffix(raw, `\`)
+	}
+
+	return raw
+}
+
+// windowsRootRel returns absolute Windows path raw relative to root with
+// slash separators, false when raw is not inside root. Paths compare
+// case-insensitively.
+func windowsRootRel(root string, raw string) (string, bool) {
+	root = canonicalWindowsPath(root)
+	raw = canonicalWindowsPath(raw)
+	if windowsVolumeLen(root) == 0 || len(raw) <= len(root) || !strings.EqualFold(raw[:len(root)], root) {
+		return "", false
+	}
+
+	rest := raw[len(root):]
+	if !strings.HasSuffix(root, `\`) {
+		if rest[0] != '\\' {
+			return "", false
+		}
+
+		rest = rest[1:]
+	}
+
+	return strings.ReplaceAll(rest, `\`, "/"), true
+}
+
 // normalizePattern normalizes source pattern for compilation.
 func normalizePattern(raw string) string {
 	raw = strings.TrimSpace(raw)
@@ -61,6 +191,17 @@ func asciiLower(s string) string {
 	return s
 }
 
+// hasASCIIUpper reports whether s contains an ASCII uppercase letter.
+func hasASCIIUpper(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 'A' && s[i] <= 'Z' {
+			return true
+		}
+	}
+
+	return false
+}
+
 // isSimpleNormalizedPath reports whether path is already normalized enough to skip path.Clean.
 func isSimpleNormalizedPath(path string) bool {
 	if path == "" ||
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"runtime"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func TestWindowsVolumeLen(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		`C:\repo\a.txt`:                   `C:`,
+		`c:/repo/a.txt`:                   `c:`,
+		`\\server\share\repo\a.txt`:       `\\server\share`,
+		`\\?\C:\repo\a.txt`:               `\\?\C:`,
+		`\\?\UNC\server\share\repo\a.txt`: `\\?\UNC\server\share`,
+		`\\?\Volume{1234}\repo\a.txt`:     `\\?\Volume{1234}`,
+		`//server/share/a.txt`:            "",
+		`ab:/c`:                           "",
+		`C:name`:                          "",
+	}
+
+	for raw, want := range cases {
+		if got := raw[:windowsVolumeLen(raw)]; got != want {
+			t.Fatalf("volume of %q=%q, want %q", raw, got, want)
+		}
+	}
+}
+
+func TestProviderPOSIXDriveLikeNames(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("drive-like names are volumes on Windows")
+	}
+
+	p, err := NewProviderFS(fstest.MapFS{
+		".pathrules": {Data: []byte("/c:/g.tmp\n")},
+		"c:/f.txt":   {},
+		"c:/g.tmp":   {},
+	}, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	if res, err := p.Decide("c:/f.txt", false); err != nil || !res.Included {
+		t.Fatalf("Decide(c:/f.txt)=%+v err=%v, want included", res, err)
+	}
+
+	if res, err := p.Decide("c:/g.tmp", false); err != nil || res.Included {
+		t.Fatalf("Decide(c:/g.tmp)=%+v err=%v, want excluded", res, err)
+	}
+
+	if got, want := collectWalk(t, p, nil), []string{".pathrules", "c:", "c:/f.txt"}; !slices.Equal(got, want) {
+		t.Fatalf("Walk=%v, want %v", got, want)
+	}
+
+	m, err := NewMatcher([]Rule{{Action: ActionExclude, Pattern: "/c:/g.tmp"}}, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if m.Included("c:/g.tmp", false) {
+		t.Fatal("Included(c:/g.tmp)=true, want false")
+	}
+}
+
+func TestProviderWindowsInputPaths(t *testing.T) {
+	t.Parallel()
+
+	p := &Provider{root: `C:\Work\Repo`, matcherOptions: MatcherOptions{PathSeparators: PathSeparatorWindows}}
+	cases := []struct {
+		raw  string
+		want string
+		err  error
+	}{
+		{raw: `C:\Work\Repo\src\main.go`, want: "src/main.go"},
+		{raw: `c:\work\repo\SRC\main.go`, want: "SRC/main.go"},
+		{raw: `\\?\C:\Work\Repo\src\main.go`, want: "src/main.go"},
+		{raw: `C:\Work\Repository\main.go`, err: ErrPathOutsideRoot},
+		{raw: `C:\Work\Repo`, err: ErrPathOutsideRoot},
+		{raw: `D:\Work\Repo\main.go`, err: ErrPathOutsideRoot},
+		{raw: `C:\Work\Repo\..\other.go`, err: ErrPathOutsideRoot},
+		{raw: `src\main.go`, want: "src/main.go"},
+	}
+
+	for _, tc := range cases {
+		got, err := p.cleanInputPath(tc.raw)
+		if !errors.Is(err, tc.err) || got != tc.want {
+			t.Fatalf("cleanInputPath(%q)=%q, %v, want %q, %v", tc.raw, got, err, tc.want, tc.err)
+		}
+	}
+
+	unc := &Provider{root: `\\?\UNC\server\share\repo`, scope: "src", matcherOptions: p.matcherOptions}
+	if got, err := unc.cleanInputPath(`\\server\share\repo\src\main.go`); err != nil || got != "main.go" {
+		t.Fatalf("scoped UNC cleanInputPath=%q, %v, want main.go", got, err)
+	}
+
+	if _, err := unc.cleanInputPath(`\\server\share\repo\docs\a.md`); !errors.Is(err, ErrPathOutsideRoot) {
+		t.Fatalf("err=%v, want ErrPathOutsideRoot outside scope", err)
+	}
+}
+
+func TestDirHint(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]bool{
+		"build/":   true,
+		"build/ ":  true,
+		`build\`:   true,
+		"/":        true,
+		"build":    false,
+		"build/a":  false,
+		"":         false,
+		"   ":      false,
+		`C:\repo\`: true,
+	}
+
+	for raw, want := range cases {
+		if got := DirHint(raw); got != want {
+			t.Fatalf("DirHint(%q)=%v, want %v", raw, got, want)
+		}
+	}
+
+	if dirHint(`build\`, true) {
+		t.Fatal(`dirHint("build\\", posix)=true, want false`)
+	}
+}
+
+func TestMatcherTrailingSlashDirHint(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher([]Rule{{Action: ActionExclude, Pattern: "build/"}}, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if !m.Included("build", false) {
+		t.Fatal("build file excluded, want included")
+	}
+
+	for _, path := range []string{"build/", `build\`, "./build/"} {
+		if m.Included(path, false) {
+			t.Fatalf("Included(%q)=true, want dir-only rule to match", path)
+		}
+	}
+
+	posix, err := NewMatcher([]Rule{{Action: ActionExclude, Pattern: "build/"}}, MatcherOptions{
+		PathSeparators: PathSeparatorPOSIX,
+	})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if !posix.Included(`build\`, false) {
+		t.Fatal(`POSIX Included("build\\")=false, want backslash kept as name`)
+	}
+}
+
+func TestProviderTrailingSlashDirHint(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":      {Data: []byte("cache/\n")},
+		"src/cache/a.txt": {Data: []byte("x")},
+	}
+
+	p, err := NewProviderFS(fsys, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	if included, err := p.Included("src/cache", false); err != nil || !included {
+		t.Fatalf("Included(src/cache)=%v err=%v, want included", included, err)
+	}
+
+	if included, err := p.Included("src/cache/", false); err != nil || included {
+		t.Fatalf("Included(src/cache/)=%v err=%v, want excluded", included, err)
+	}
+}
@@ -0,0 +1,235 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+// Package pathrulesd serves pathrules Provider decisions over a small
+// HTTP+JSON API, so tools outside Go can query the same policy.
+//
+// Endpoints, all POST with JSON bodies:
+//
+//	/v1/decide        {"path": "a/b.txt", "is_dir": false}
+//	/v1/decide/batch  {"paths": [{"path": "a", "is_dir": true}, ...]}
+//	/v1/decide-in-dir {"dir": "a", "entries": [{"name": "b.txt"}, ...]}
+//
+// Invalid or too deep paths answer 400, other failures 500, both with
+// {"error": "..."}.
+// Batch items fail one by one, reporting "error" per result.
+package pathrulesd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/woozymasta/pathrules"
+)
+
+// Default limits applied when Options fields are zero.
+const (
+	// DefaultMaxBodySize limits request bodies to 4 MiB.
+	DefaultMaxBodySize = 4 << 20
+	// DefaultMaxBatch limits batch requests to 10000 paths or entries.
+	DefaultMaxBatch = 10000
+)
+
+// Options configures NewHandler.
+type Options struct {
+	// MaxBodySize limits request bodies in bytes, DefaultMaxBodySize when zero.
+	MaxBodySize int64 `json:"max_body_size,omitempty" yaml:"max_body_size,omitempty"`
+	// MaxBatch limits paths or entries per request, DefaultMaxBatch when zero.
+	MaxBatch int `json:"max_batch,omitempty" yaml:"max_batch,omitempty"`
+}
+
+// PathRequest is one path to decide.
+type PathRequest struct {
+	// Path is relative to provider root.
+	Path string `json:"path"`
+	// IsDir reports whether Path is a directory.
+	IsDir bool `json:"is_dir,omitempty"`
+}
+
+// BatchRequest is the /v1/decide/batch request body.
+type BatchRequest struct {
+	// Paths are decided in order.
+	Paths []PathRequest `json:"paths"`
+}
+
+// DirRequest is the /v1/decide-in-dir request body.
+type DirRequest struct {
+	// Dir is relative to provider root, empty for root.
+	Dir string `json:"dir"`
+	// Entries are names directly inside Dir.
+	Entries []pathrules.DirEntry `json:"entries"`
+}
+
+// Decision is one decided path.
+type Decision struct {
+	// Path is the path as requested.
+	Path string `json:"path"`
+	// Error is the decision error of a batch item, empty on success.
+	Error string `json:"error,omitempty"`
+	// MatchResult is the decision, zero value when Error is set.
+	pathrules.MatchResult
+}
+
+// DecisionsResponse answers batch and directory requests.
+type DecisionsResponse struct {
+	// Results are in request order.
+	Results []Decision `json:"results"`
+}
+
+// ErrorResponse is the body of failed requests.
+type ErrorResponse struct {
+	// Error describes the failure.
+	Error string `json:"error"`
+}
+
+// handler serves one provider.
+type handler struct {
+	// provider decides paths.
+	provider *pathrules.Provider
+	// maxBodySize limits request bodies.
+	maxBodySize int64
+	// maxBatch limits paths or entries per request.
+	maxBatch int
+}
+
+// NewHandler returns an http.Handler answering decisions of p.
+func NewHandler(p *pathrules.Provider, opts Options) (http.Handler, error) {
+	if p == nil {
+		return nil, pathrules.ErrNilProvider
+	}
+
+	if opts.MaxBodySize < 0 || opts.MaxBatch < 0 {
+		return nil, fmt.Errorf("%w: negative limits", pathrules.ErrInvalidOptions)
+	}
+
+	h := &handler{provider: p, maxBodySize: opts.MaxBodySize, maxBatch: opts.MaxBatch}
+	if h.maxBodySize == 0 {
+		h.maxBodySize = DefaultMaxBodySize
+	}
+
+	if h.maxBatch == 0 {
+		h.maxBatch = DefaultMaxBatch
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/decide", h.decide)
+	mux.HandleFunc("POST /v1/decide/batch", h.decideBatch)
+	mux.HandleFunc("POST /v1/decide-in-dir", h.decideInDir)
+	return mux, nil
+}
+
+// decide serves /v1/decide.
+func (h *handler) decide(w http.ResponseWriter, r *http.Request) {
+	var req PathRequest
+	if !h.readJSON(w, r, &req) {
+		return
+	}
+
+	res, err := h.provider.Decide(req.Path, req.IsDir)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Decision{Path: req.Path, MatchResult: res})
+}
+
+// decideBatch serves /v1/decide/batch.
+func (h *handler) decideBatch(w http.ResponseWriter, r *http.Request) {
+	var req BatchRequest
+	if !h.readJSON(w, r, &req) {
+		return
+	}
+
+	if len(req.Paths) > h.maxBatch {
+		writeStatus(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("batch of %d paths exceeds %d", len(req.Paths), h.maxBatch))
+		return
+	}
+
+	results := make([]Decision, len(req.Paths))
+	for i, item := range req.Paths {
+		results[i].Path = item.Path
+		res, err := h.provider.Decide(item.Path, item.IsDir)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+
+		results[i].MatchResult = res
+	}
+
+	writeJSON(w, http.StatusOK, DecisionsResponse{Results: results})
+}
+
+// decideInDir serves /v1/decide-in-dir.
+func (h *handler) decideInDir(w http.ResponseWriter, r *http.Request) {
+	var req DirRequest
+	if !h.readJSON(w, r, &req) {
+		return
+	}
+
+	if len(req.Entries) > h.maxBatch {
+		writeStatus(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("batch of %d entries exceeds %d", len(req.Entries), h.maxBatch))
+		return
+	}
+
+	decided, err := h.provider.DecideInDir(req.Dir, req.Entries)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	results := make([]Decision, len(decided))
+	for i, res := range decided {
+		results[i] = Decision{Path: req.Entries[i].Name, MatchResult: res}
+	}
+
+	writeJSON(w, http.StatusOK, DecisionsResponse{Results: results})
+}
+
+// readJSON decodes a size-limited request body into v, answering 400 on failure.
+func (h *handler) readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.maxBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeStatus(w, http.StatusRequestEntityTooLarge, err.Error())
+			return false
+		}
+
+		writeStatus(w, http.StatusBadRequest, fmt.Sprintf("decode request: %v", err))
+		return false
+	}
+
+	return true
+}
+
+// writeError answers a decision error, 400 for invalid or too deep input paths.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, pathrules.ErrPathOutsideRoot) ||
+		errors.Is(err, pathrules.ErrUnsafeName) ||
+		errors.Is(err, pathrules.ErrInvalidEntryName) ||
+		errors.Is(err, pathrules.ErrInvalidPath) ||
+		errors.Is(err, pathrules.ErrHierarchyTooDeep) {
+		status = http.StatusBadRequest
+	}
+
+	writeStatus(w, status, err.Error())
+}
+
+// writeStatus answers status with an ErrorResponse.
+func writeStatus(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, ErrorResponse{Error: msg})
+}
+
+// writeJSON answers status with v encoded as JSON.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
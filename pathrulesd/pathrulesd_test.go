@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrulesd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/woozymasta/pathrules"
+)
+
+// newTestServer serves a provider excluding "*.log" and "build/".
+func newTestServer(t *testing.T, opts Options) *httptest.Server {
+	t.Helper()
+
+	p, err := pathrules.NewProvider(t.TempDir(), pathrules.ProviderOptions{
+		BaseRules: pathrules.MustParseRulesString("*.log\nbuild/\n"),
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	h, err := NewHandler(p, opts)
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// post sends body to path and decodes the response into v, returning the status.
+func post(t *testing.T, srv *httptest.Server, path, body string, v any) int {
+	t.Helper()
+
+	resp, err := http.Post(srv.URL+path, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST %s: %v", path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("decode %s: %v", path, err)
+	}
+
+	return resp.StatusCode
+}
+
+func TestDecide(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t, Options{})
+
+	var d Decision
+	if status := post(t, srv, "/v1/decide", `{"path":"a/x.log"}`, &d); status != http.StatusOK {
+		t.Fatalf("status=%d", status)
+	}
+
+	if d.Path != "a/x.log" || d.Included || !d.Matched || d.Action != pathrules.ActionExclude {
+		t.Fatalf("decision=%+v", d)
+	}
+
+	var e ErrorResponse
+	if status := post(t, srv, "/v1/decide", `{"path":"../x"}`, &e); status != http.StatusBadRequest || e.Error == "" {
+		t.Fatalf("status=%d error=%q, want 400", status, e.Error)
+	}
+
+	if status := post(t, srv, "/v1/decide", `{"bogus":1}`, &e); status != http.StatusBadRequest {
+		t.Fatalf("status=%d, want 400", status)
+	}
+}
+
+func TestDecideClientErrors(t *testing.T) {
+	t.Parallel()
+
+	p, err := pathrules.NewProvider(t.TempDir(), pathrules.ProviderOptions{MaxHierarchyDepth: 1})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	h, err := NewHandler(p, Options{})
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	var e ErrorResponse
+	if status := post(t, srv, "/v1/decide", `{"path":"a/b/c.txt"}`, &e); status != http.StatusBadRequest || e.Error == "" {
+		t.Fatalf("status=%d error=%q, want 400 for too deep hierarchy", status, e.Error)
+	}
+
+	for _, err := range []error{pathrules.ErrInvalidPath, pathrules.ErrHierarchyTooDeep} {
+		rec := httptest.NewRecorder()
+		writeError(rec, fmt.Errorf("decide: %w", err))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%v: status=%d, want 400", err, rec.Code)
+		}
+	}
+}
+
+func TestDecideBatch(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t, Options{MaxBatch: 3})
+
+	var resp DecisionsResponse
+	body := `{"paths":[{"path":"a.txt"},{"path":"build","is_dir":true},{"path":"../x"}]}`
+	if status := post(t, srv, "/v1/decide/batch", body, &resp); status != http.StatusOK {
+		t.Fatalf("status=%d", status)
+	}
+
+	if len(resp.Results) != 3 || !resp.Results[0].Included || resp.Results[1].Included || resp.Results[2].Error == "" {
+		t.Fatalf("results=%+v", resp.Results)
+	}
+
+	var e ErrorResponse
+	body = `{"paths":[{"path":"a"},{"path":"b"},{"path":"c"},{"path":"d"}]}`
+	if status := post(t, srv, "/v1/decide/batch", body, &e); status != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status=%d, want 413", status)
+	}
+}
+
+func TestDecideInDir(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t, Options{})
+
+	var resp DecisionsResponse
+	body := `{"dir":"src","entries":[{"name":"main.go"},{"name":"debug.log"},{"name":"build","is_dir":true}]}`
+	if status := post(t, srv, "/v1/decide-in-dir", body, &resp); status != http.StatusOK {
+		t.Fatalf("status=%d", status)
+	}
+
+	var got []bool
+	for _, d := range resp.Results {
+		got = append(got, d.Included)
+	}
+
+	if len(got) != 3 || !got[0] || got[1] || got[2] {
+		t.Fatalf("included=%v, want [true false false]", got)
+	}
+
+	var e ErrorResponse
+	if status := post(t, srv, "/v1/decide-in-dir", `{"dir":"src","entries":[{"name":"a/b"}]}`, &e); status != http.StatusBadRequest {
+		t.Fatalf("status=%d, want 400", status)
+	}
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+// Package pathrulesotel reports pathrules Provider operations to
+// OpenTelemetry: a span and a duration measurement per Decide, DecideInDir
+// and rules file load.
+//
+// It is a separate module, so pathrules itself keeps no dependencies.
+//
+//	p, _ := pathrules.NewProvider(root, pathrules.ProviderOptions{
+//		Instrumentation: pathrulesotel.New(pathrulesotel.Options{}),
+//	})
+//	res, err := p.WithContext(ctx).Decide("a/b.txt", false)
+package pathrulesotel
+
+import (
+	"context"
+	"time"
+
+	"github.com/woozymasta/pathrules"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of tracers and meters.
+const ScopeName = "github.com/woozymasta/pathrules"
+
+// Attribute keys set on spans and measurements.
+const (
+	// AttrOperation is the pathrules.OperationKind name.
+	AttrOperation = attribute.Key("pathrules.operation")
+	// AttrPath is the decided path or directory.
+	AttrPath = attribute.Key("pathrules.path")
+	// AttrEntries is the DecideInDir entry count.
+	AttrEntries = attribute.Key("pathrules.entries")
+	// AttrIncluded is the Decide decision.
+	AttrIncluded = attribute.Key("pathrules.included")
+	// AttrRules is the number of rules loaded.
+	AttrRules = attribute.Key("pathrules.rules")
+	// AttrError reports a failed operation on measurements.
+	AttrError = attribute.Key("pathrules.error")
+)
+
+// Options configures New.
+type Options struct {
+	// TracerProvider creates spans, otel.GetTracerProvider() when nil.
+	TracerProvider trace.TracerProvider `json:"-" yaml:"-"`
+	// MeterProvider records durations, otel.GetMeterProvider() when nil.
+	MeterProvider metric.MeterProvider `json:"-" yaml:"-"`
+	// OmitPaths leaves AttrPath out of spans, for paths that must not leave
+	// the process or would blow up span cardinality.
+	OmitPaths bool `json:"omit_paths,omitempty" yaml:"omit_paths,omitempty"`
+}
+
+// instrumentation implements pathrules.Instrumentation with OpenTelemetry.
+type instrumentation struct {
+	// tracer starts operation spans.
+	tracer trace.Tracer
+	// duration records operation durations in seconds, nil when the
+	// instrument could not be created.
+	duration metric.Float64Histogram
+	// omitPaths leaves AttrPath out of spans.
+	omitPaths bool
+}
+
+// New returns a pathrules.Instrumentation reporting to OpenTelemetry.
+//
+// Spans are named "pathrules.<operation>" and nest under the context given
+// to Provider.WithContext. Durations go to the "pathrules.operation.duration"
+// histogram with AttrOperation and AttrError.
+func New(opts Options) pathrules.Instrumentation {
+	tp := opts.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+
+	mp := opts.MeterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	duration, err := mp.Meter(ScopeName).Float64Histogram("pathrules.operation.duration",
+		metric.WithDescription("Duration of pathrules provider operations."),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+		duration = nil
+	}
+
+	return &instrumentation{
+		tracer:    tp.Tracer(ScopeName),
+		duration:  duration,
+		omitPaths: opts.OmitPaths,
+	}
+}
+
+// Start implements pathrules.Instrumentation.
+func (i *instrumentation) Start(ctx context.Context, op pathrules.Operation) (context.Context, func(pathrules.OperationEnd)) {
+	kind := AttrOperation.String(op.Kind.String())
+	attrs := make([]attribute.KeyValue, 0, 3)
+	attrs = append(attrs, kind)
+	if !i.omitPaths {
+		attrs = append(attrs, AttrPath.String(op.Path))
+	}
+
+	if op.Kind == pathrules.OperationDecideInDir {
+		attrs = append(attrs, AttrEntries.Int(op.Entries))
+	}
+
+	ctx, span := i.tracer.Start(ctx, "pathrules."+op.Kind.String(), trace.WithAttributes(attrs...))
+	start := time.Now()
+
+	return ctx, func(end pathrules.OperationEnd) {
+		switch {
+		case end.Err != nil:
+			span.RecordError(end.Err)
+			span.SetStatus(codes.Error, end.Err.Error())
+		case op.Kind == pathrules.OperationDecide:
+			span.SetAttributes(AttrIncluded.Bool(end.Included))
+		case op.Kind == pathrules.OperationLoadRules:
+			span.SetAttributes(AttrRules.Int(end.Rules))
+		}
+
+		span.End()
+		if i.duration != nil {
+			i.duration.Record(ctx, time.Now().Sub(start).Seconds(),
+				metric.WithAttributes(kind, AttrError.Bool(end.Err != nil)))
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrulesotel
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/woozymasta/pathrules"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInstrumentation(t *testing.T) {
+	t.Parallel()
+
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	p, err := pathrules.NewProviderFS(fstest.MapFS{
+		".pathrules":     {Data: []byte("*.tmp\n")},
+		"bad/.pathrules": {Data: []byte("#pragma default=bogus\n")},
+	}, pathrules.ProviderOptions{
+		Instrumentation: New(Options{TracerProvider: tp, MeterProvider: mp}),
+	})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "request")
+	if res, err := p.WithContext(ctx).Decide("a.tmp", false); err != nil || res.Included {
+		t.Fatalf("Decide=%+v err=%v, want excluded", res, err)
+	}
+
+	parent.End()
+	if _, err := p.Decide("bad/a.txt", false); err == nil {
+		t.Fatal("Decide succeeded, want directive error")
+	}
+
+	byName := make(map[string][]sdktrace.ReadOnlySpan)
+	for _, span := range spans.Ended() {
+		byName[span.Name()] = append(byName[span.Name()], span)
+	}
+
+	decides := byName["pathrules.decide"]
+	if len(decides) != 2 {
+		t.Fatalf("decide spans=%d, want 2", len(decides))
+	}
+
+	if decides[0].Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Fatal("decide span is not a child of the request span")
+	}
+
+	if !hasAttr(decides[0].Attributes(), AttrIncluded.Bool(false)) || !hasAttr(decides[0].Attributes(), AttrPath.String("a.tmp")) {
+		t.Fatalf("decide attributes=%v, want path and decision", decides[0].Attributes())
+	}
+
+	if decides[1].Status().Code != codes.Error {
+		t.Fatalf("failed decide status=%v, want error", decides[1].Status())
+	}
+
+	if loads := byName["pathrules.load-rules"]; len(loads) != 2 || !hasAttr(loads[0].Attributes(), AttrRules.Int(1)) {
+		t.Fatalf("load spans=%v, want root load with 1 rule and failed bad load", loads)
+	}
+
+	for i, load := range byName["pathrules.load-rules"] {
+		if load.Parent().SpanID() != decides[i].SpanContext().SpanID() {
+			t.Fatalf("load span %d is not a child of its decide span", i)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+
+	var points uint64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if hist, ok := m.Data.(metricdata.Histogram[float64]); ok && m.Name == "pathrules.operation.duration" {
+				for _, dp := range hist.DataPoints {
+					points += dp.Count
+				}
+			}
+		}
+	}
+
+	if points != 4 {
+		t.Fatalf("duration measurements=%d, want 4", points)
+	}
+}
+
+func TestInstrumentationOmitPaths(t *testing.T) {
+	t.Parallel()
+
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	p, err := pathrules.NewProviderFS(fstest.MapFS{}, pathrules.ProviderOptions{
+		Instrumentation: New(Options{TracerProvider: tp, OmitPaths: true}),
+	})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	if _, err := p.DecideInDir("docs", []pathrules.DirEntry{{Name: "a.md"}}); err != nil {
+		t.Fatalf("DecideInDir: %v", err)
+	}
+
+	for _, span := range spans.Ended() {
+		for _, kv := range span.Attributes() {
+			if kv.Key == AttrPath {
+				t.Fatalf("span %s has path attribute %v", span.Name(), kv)
+			}
+		}
+	}
+}
+
+// hasAttr reports whether attrs contains want.
+func hasAttr(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, kv := range attrs {
+		if kv == want {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+// Package pathrulestest provides a configurable pathrules.Decider fake for
+// unit tests of code that depends on pathrules.Decider.
+package pathrulestest
+
+import (
+	"sync"
+
+	"github.com/woozymasta/pathrules"
+)
+
+// Call is one recorded Decider call.
+type Call struct {
+	// Path is the path as passed by the caller.
+	Path string
+	// IsDir is the directory flag as passed by the caller.
+	IsDir bool
+}
+
+// Decider is a pathrules.Decider returning canned decisions.
+//
+// Lookup order is Errors, Results, Func, then Default. Set fields before
+// first use; Decider is safe for concurrent calls afterwards.
+type Decider struct {
+	// Results maps exact paths to decisions.
+	Results map[string]pathrules.MatchResult
+	// Errors maps exact paths to returned errors.
+	Errors map[string]error
+	// Func, when set, decides paths missing from Results and Errors.
+	Func func(path string, isDir bool) (pathrules.MatchResult, error)
+	// Default is returned for paths no other field decides.
+	Default pathrules.MatchResult
+	// calls records every call in order.
+	calls []Call
+	// mu guards calls.
+	mu sync.Mutex
+}
+
+var _ pathrules.Decider = (*Decider)(nil)
+
+// IncludeOnly returns a Decider including the listed paths and excluding the rest.
+func IncludeOnly(paths ...string) *Decider {
+	return withPaths(true, paths)
+}
+
+// ExcludeOnly returns a Decider excluding the listed paths and including the rest.
+func ExcludeOnly(paths ...string) *Decider {
+	return withPaths(false, paths)
+}
+
+// Decide implements pathrules.Decider and records the call.
+func (d *Decider) Decide(path string, isDir bool) (pathrules.MatchResult, error) {
+	d.mu.Lock()
+	d.calls = append(d.calls, Call{Path: path, IsDir: isDir})
+	d.mu.Unlock()
+
+	if err, ok := d.Errors[path]; ok {
+		return pathrules.MatchResult{}, err
+	}
+
+	if res, ok := d.Results[path]; ok {
+		return res, nil
+	}
+
+	if d.Func != nil {
+		return d.Func(path, isDir)
+	}
+
+	return d.Default, nil
+}
+
+// Included implements pathrules.Decider.
+func (d *Decider) Included(path string, isDir bool) (bool, error) {
+	res, err := d.Decide(path, isDir)
+	return res.Included, err
+}
+
+// Excluded implements pathrules.Decider.
+func (d *Decider) Excluded(path string, isDir bool) (bool, error) {
+	res, err := d.Decide(path, isDir)
+	if err != nil {
+		return false, err
+	}
+
+	return !res.Included, nil
+}
+
+// Calls returns a copy of the recorded calls in order.
+func (d *Decider) Calls() []Call {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	out := make([]Call, len(d.calls))
+	copy(out, d.calls)
+	return out
+}
+
+// withPaths returns a Decider matching paths with the given inclusion and
+// defaulting to the opposite.
+func withPaths(included bool, paths []string) *Decider {
+	action := pathrules.ActionExclude
+	if included {
+		action = pathrules.ActionInclude
+	}
+
+	d := &Decider{
+		Results: make(map[string]pathrules.MatchResult, len(paths)),
+		Default: pathrules.MatchResult{Included: !included, RuleIndex: -1},
+	}
+
+	for i, path := range paths {
+		d.Results[path] = pathrules.MatchResult{Included: included, Matched: true, RuleIndex: i, Action: action}
+	}
+
+	return d
+}
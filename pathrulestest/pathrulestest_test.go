@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrulestest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/woozymasta/pathrules"
+)
+
+func TestDecider(t *testing.T) {
+	t.Parallel()
+
+	errBoom := errors.New("boom")
+	d := IncludeOnly("a.go", "b.go")
+	d.Errors = map[string]error{"bad": errBoom}
+
+	var decider pathrules.Decider = d
+	if ok, err := decider.Included("a.go", false); err != nil || !ok {
+		t.Fatalf("Included(a.go)=%v err=%v, want included", ok, err)
+	}
+
+	if ok, err := decider.Excluded("c.go", false); err != nil || !ok {
+		t.Fatalf("Excluded(c.go)=%v err=%v, want excluded", ok, err)
+	}
+
+	if res, err := decider.Decide("b.go", false); err != nil || res.RuleIndex != 1 || res.Action != pathrules.ActionInclude {
+		t.Fatalf("Decide(b.go)=%+v err=%v", res, err)
+	}
+
+	if _, err := decider.Excluded("bad", true); !errors.Is(err, errBoom) {
+		t.Fatalf("Excluded(bad) err=%v, want %v", err, errBoom)
+	}
+
+	calls := d.Calls()
+	if len(calls) != 4 || calls[0].Path != "a.go" || !calls[3].IsDir {
+		t.Fatalf("Calls=%+v", calls)
+	}
+
+	d = ExcludeOnly("vendor")
+	d.Func = func(path string, isDir bool) (pathrules.MatchResult, error) {
+		return pathrules.MatchResult{Included: isDir, RuleIndex: -1}, nil
+	}
+
+	if ok, _ := d.Included("vendor", true); ok {
+		t.Fatalf("vendor must be excluded")
+	}
+
+	if ok, _ := d.Included("src", true); !ok {
+		t.Fatalf("Func must decide src")
+	}
+}
+
+func TestMatcherDecider(t *testing.T) {
+	t.Parallel()
+
+	rules, err := pathrules.ParseRulesString("*.tmp\n")
+	if err != nil {
+		t.Fatalf("ParseRulesString: %v", err)
+	}
+
+	m, err := pathrules.NewMatcher(rules, pathrules.MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if ok, err := m.Decider().Excluded("a.tmp", false); err != nil || !ok {
+		t.Fatalf("Excluded(a.tmp)=%v err=%v", ok, err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 // compiledRule is matcher-internal compiled representation of one rule.
@@ -28,14 +29,20 @@ type compiledRule struct {
 	pathRE *regexp.Regexp
 	// pathDirRE matches full path patterns targeting a directory subtree.
 	pathDirRE *regexp.Regexp
+	// searchRE matches regexp-syntax rules anywhere in path or its ancestors.
+	searchRE *regexp.Regexp
 	// source is original source rule.
 	source Rule
 	// anchored means source pattern starts with "/".
 	anchored bool
 	// dirOnly means source pattern ends with "/".
 	dirOnly bool
+	// requireDir means rule matches directory candidates only, never their contents.
+	requireDir bool
 	// hasSlash means source pattern contains "/" after normalization.
 	hasSlash bool
+	// foldCase means the rule was compiled case-insensitively by MatcherOptions.SmartCase.
+	foldCase bool
 }
 
 // segmentPattern is precompiled component/path segment matcher.
@@ -48,12 +55,16 @@ type segmentPattern struct {
 
 // compileRule compiles one source rule into the cheapest matching strategy
 // that preserves expected gitignore-like semantics.
-func compileRule(rule Rule, caseInsensitive bool) (*compiledRule, error) {
-	if !rule.Action.valid() {
+func compileRule(rule Rule, caseInsensitive, backslashEscapes bool, maxRegexpSize int) (*compiledRule, error) {
+	if !rule.Action.compilable() {
 		return nil, fmt.Errorf("%w: unsupported action %d", ErrInvalidRule, rule.Action)
 	}
 
-	pattern := normalizePattern(rule.Pattern)
+	pattern := strings.TrimSpace(rule.Pattern)
+	if !backslashEscapes {
+		pattern = normalizePattern(pattern)
+	}
+
 	if caseInsensitive {
 		pattern = asciiLower(pattern)
 	}
@@ -80,6 +91,10 @@ func compileRule(rule Rule, caseInsensitive bool) (*compiledRule, error) {
 	cr.hasSlash = strings.Contains(pattern, "/") || cr.anchored
 	hasMeta := patternHasGlobMeta(pattern)
 	hasCharClass := patternHasCharClass(pattern)
+	if backslashEscapes && strings.Contains(pattern, `\`) {
+		// Escaped patterns always take the regexp path, which understands "\x".
+		hasMeta, hasCharClass = true, true
+	}
 
 	if !cr.hasSlash {
 		// Component-only rules can avoid regexp completely for exact and simple wildcard cases.
@@ -93,7 +108,12 @@ func compileRule(rule Rule, caseInsensitive bool) (*compiledRule, error) {
 			return cr, nil
 		}
 
-		re, err := regexp.Compile("^" + globToRegexComponent(pattern) + "$")
+		expr := "^" + globToRegexComponent(pattern, false) + "$"
+		if err := checkRegexpSize(rule.Pattern, expr, maxRegexpSize); err != nil {
+			return nil, err
+		}
+
+		re, err := compileRegexp(expr)
 		if err != nil {
 			return nil, fmt.Errorf("%w: compile component %q: %v", ErrInvalidPattern, rule.Pattern, err)
 		}
@@ -110,26 +130,31 @@ func compileRule(rule Rule, caseInsensitive bool) (*compiledRule, error) {
 
 	if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
 		// Trailing "/**" is common and can be matched as "prefix directory + any descendants".
-		if prefix != "" && canUseSimplePathSegments(prefix) {
+		if prefix != "" && !hasCharClass && canUseSimplePathSegments(prefix) {
 			cr.pathPrefixSegments = compilePathSegments(prefix)
 			return cr, nil
 		}
 	}
 
-	if canUseSimplePathSegments(pattern) {
+	if !hasCharClass && canUseSimplePathSegments(pattern) {
 		cr.pathSegments = compilePathSegments(pattern)
 		return cr, nil
 	}
 
 	// Fallback for patterns with char classes or complex "**" combinations.
-	body := globToRegexPath(pattern)
+	body := globToRegexPath(pattern, false)
 	prefix := `(?:^|.*/)`
 	if cr.anchored {
 		prefix = `^`
 	}
 
 	if cr.dirOnly {
-		re, err := regexp.Compile(prefix + body + `(?:/.*)?$`)
+		expr := prefix + body + `(?:/.*)?$`
+		if err := checkRegexpSize(rule.Pattern, expr, maxRegexpSize); err != nil {
+			return nil, err
+		}
+
+		re, err := compileRegexp(expr)
 		if err != nil {
 			return nil, fmt.Errorf("%w: compile dir pattern %q: %v", ErrInvalidPattern, rule.Pattern, err)
 		}
@@ -138,7 +163,12 @@ func compileRule(rule Rule, caseInsensitive bool) (*compiledRule, error) {
 		return cr, nil
 	}
 
-	re, err := regexp.Compile(prefix + body + `$`)
+	expr := prefix + body + `$`
+	if err := checkRegexpSize(rule.Pattern, expr, maxRegexpSize); err != nil {
+		return nil, err
+	}
+
+	re, err := compileRegexp(expr)
 	if err != nil {
 		return nil, fmt.Errorf("%w: compile path pattern %q: %v", ErrInvalidPattern, rule.Pattern, err)
 	}
@@ -149,10 +179,18 @@ func compileRule(rule Rule, caseInsensitive bool) (*compiledRule, error) {
 
 // matches reports whether compiled rule matches normalized candidate path.
 func (r *compiledRule) matches(candidate string, isDir bool) bool {
-	if candidate == "" {
+	if candidate == "" || (r.requireDir && !isDir) {
 		return false
 	}
 
+	if r.foldCase {
+		candidate = asciiLower(candidate)
+	}
+
+	if r.searchRE != nil {
+		return matchSearchRegexp(r.searchRE, candidate)
+	}
+
 	if r.hasSlash {
 		// Path strategy priority mirrors compile-time selection: exact -> fast segmented -> regexp.
 		if r.pathExact != "" {
@@ -202,6 +240,48 @@ func (r *compiledRule) matches(candidate string, isDir bool) bool {
 	return matchDirOnlyComponent(r.componentRE, candidate, isDir)
 }
 
+// compileSyntaxRule compiles one rule with non-glob pattern syntax.
+func compileSyntaxRule(rule Rule, caseInsensitive bool, maxRegexpSize int) (*compiledRule, error) {
+	if !rule.Action.compilable() {
+		return nil, fmt.Errorf("%w: unsupported action %d", ErrInvalidRule, rule.Action)
+	}
+
+	if rule.Syntax != PatternRegexp {
+		return nil, fmt.Errorf("%w: unsupported pattern syntax %d", ErrInvalidRule, rule.Syntax)
+	}
+
+	if rule.Pattern == "" {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidPattern)
+	}
+
+	expr := rule.Pattern
+	if caseInsensitive {
+		expr = "(?i)" + expr
+	}
+
+	if err := checkRegexpSize(rule.Pattern, expr, maxRegexpSize); err != nil {
+		return nil, err
+	}
+
+	re, err := compileRegexp(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: compile regexp %q: %v", ErrInvalidPattern, rule.Pattern, err)
+	}
+
+	return &compiledRule{source: rule, searchRE: re}, nil
+}
+
+// matchSearchRegexp reports whether re matches candidate or one of its ancestor directories.
+func matchSearchRegexp(re *regexp.Regexp, candidate string) bool {
+	for i := 0; i < len(candidate); i++ {
+		if candidate[i] == '/' && re.MatchString(candidate[:i]) {
+			return true
+		}
+	}
+
+	return re.MatchString(candidate)
+}
+
 // patternHasGlobMeta reports whether pattern contains supported glob meta.
 func patternHasGlobMeta(pattern string) bool {
 	for i := 0; i < len(pattern); i++ {
@@ -539,12 +619,13 @@ func matchDirOnlyComponentPattern(pattern segmentPattern, candidate string, isDi
 	return false
 }
 
-// globToRegexComponent converts a gitignore-like component pattern to regex body.
-func globToRegexComponent(pat string) string {
+// globToRegexComponent converts a gitignore-like component pattern to regex
+// body. With fold, ASCII letters match both cases, see appendRegexLiteral.
+func globToRegexComponent(pat string, fold bool) string {
 	var b strings.Builder
 
 	for i := 0; i < len(pat); i++ {
-		if next, ok := appendCharClassRegex(pat, i, &b); ok {
+		if next, ok := appendCharClassRegex(pat, i, &b, fold); ok {
 			i = next
 			continue
 		}
@@ -559,8 +640,14 @@ func globToRegexComponent(pat string) string {
 			b.WriteString(`[^/]*`)
 		case '?':
 			b.WriteString(`[^/]`)
+		case '\\':
+			// Backslash escapes the next character; only POSIX separator policy keeps it in patterns.
+			if i+1 < len(pat) {
+				i++
+			}
+			i = appendRegexLiteral(&b, pat, i, fold)
 		default:
-			b.WriteString(regexEscapeByte(c))
+			i = appendRegexLiteral(&b, pat, i, fold)
 		}
 	}
 
@@ -568,7 +655,8 @@ func globToRegexComponent(pat string) string {
 }
 
 // globToRegexPath converts a gitignore-like path pattern to regex body.
-func globToRegexPath(pat string) string {
+// With fold, ASCII letters match both cases, see appendRegexLiteral.
+func globToRegexPath(pat string, fold bool) string {
 	var b strings.Builder
 
 	for i := 0; i < len(pat); i++ {
@@ -579,7 +667,7 @@ func globToRegexPath(pat string) string {
 			continue
 		}
 
-		if next, ok := appendCharClassRegex(pat, i, &b); ok {
+		if next, ok := appendCharClassRegex(pat, i, &b, fold); ok {
 			i = next
 			continue
 		}
@@ -595,16 +683,24 @@ func globToRegexPath(pat string) string {
 			b.WriteString(`[^/]*`)
 		case '?':
 			b.WriteString(`[^/]`)
+		case '\\':
+			// Backslash escapes the next character.
+			if i+1 < len(pat) {
+				i++
+			}
+			i = appendRegexLiteral(&b, pat, i, fold)
 		default:
-			b.WriteString(regexEscapeByte(c))
+			i = appendRegexLiteral(&b, pat, i, fold)
 		}
 	}
 
 	return b.String()
 }
 
-// appendCharClassRegex appends a parsed glob char class (`[...]`) as regex class.
-func appendCharClassRegex(pat string, start int, b *strings.Builder) (int, bool) {
+// appendCharClassRegex appends a parsed glob char class (`[...]`) as regex
+// class. With fold, ASCII letters and letter ranges are added in the other
+// case too.
+func appendCharClassRegex(pat string, start int, b *strings.Builder, fold bool) (int, bool) {
 	if start < 0 || start >= len(pat) || pat[start] != '[' {
 		return start, false
 	}
@@ -633,19 +729,64 @@ func appendCharClassRegex(pat string, start int, b *strings.Builder) (int, bool)
 		idx++
 	}
 
-	for ; idx < end; idx++ {
-		if pat[idx] == '\\' {
+	body := pat[idx:end]
+	trailingDash := fold && len(body) > 1 && body[len(body)-1] == '-'
+	if trailingDash {
+		// Keep the literal '-' last so folded items cannot form a range with it.
+		body = body[:len(body)-1]
+	}
+
+	for i := 0; i < len(body); i++ {
+		if body[i] == '\\' {
 			b.WriteString(`\\`)
 			continue
 		}
 
-		b.WriteByte(pat[idx])
+		b.WriteByte(body[i])
+	}
+
+	if fold {
+		appendFoldedClassItems(b, body)
+	}
+
+	if trailingDash {
+		b.WriteByte('-')
 	}
 
 	b.WriteByte(']')
 	return end, true
 }
 
+// appendFoldedClassItems appends the other ASCII case of letters and letter
+// ranges in a glob class body.
+func appendFoldedClassItems(b *strings.Builder, body string) {
+	for i := 0; i < len(body); i++ {
+		lo, hi := body[i], body[i]
+		if i+2 < len(body) && body[i+1] == '-' {
+			hi = body[i+2]
+			i += 2
+		}
+
+		appendFoldedRange(b, lo, hi, 'a', 'z')
+		appendFoldedRange(b, lo, hi, 'A', 'Z')
+	}
+}
+
+// appendFoldedRange appends the part of lo-hi within first-last, switched
+// to the other ASCII case.
+func appendFoldedRange(b *strings.Builder, lo, hi, first, last byte) {
+	lo, hi = max(lo, first), min(hi, last)
+	if lo > hi {
+		return
+	}
+
+	b.WriteByte(lo ^ 0x20)
+	if hi > lo {
+		b.WriteByte('-')
+		b.WriteByte(hi ^ 0x20)
+	}
+}
+
 // findCharClassEnd locates closing bracket for a glob char class.
 func findCharClassEnd(pat string, start int) int {
 	if start < 0 || start >= len(pat) || pat[start] != '[' {
@@ -670,14 +811,29 @@ func findCharClassEnd(pat string, start int) int {
 	return -1
 }
 
-// regexEscapeByte escapes one byte for regexp source.
-func regexEscapeByte(c byte) string {
-	switch c {
-	case '.', '+', '(', ')', '|', '{', '}', '[', ']', '^', '$', '\\':
-		return `\` + string(c)
-	default:
-		return string(c)
+// appendRegexLiteral appends the character starting at pat[i] as an escaped
+// regexp literal and returns the index of its last byte. With fold, an ASCII
+// letter becomes a class of both cases; other characters never fold, as
+// asciiLower does not change them.
+func appendRegexLiteral(b *strings.Builder, pat string, i int, fold bool) int {
+	c := pat[i]
+	if fold && ('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z') {
+		b.WriteByte('[')
+		b.WriteByte(c | 0x20)
+		b.WriteByte(c &^ 0x20)
+		b.WriteByte(']')
+		return i
 	}
+
+	r, size := utf8.DecodeRuneInString(pat[i:])
+	if r == utf8.RuneError && size == 1 {
+		// regexp decodes invalid bytes of candidates as U+FFFD.
+		b.WriteString(`\x{fffd}`)
+		return i
+	}
+
+	b.WriteString(regexp.QuoteMeta(pat[i : i+size]))
+	return i + size - 1
 }
 
 // pathBase returns final path component using slash separator.
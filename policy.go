@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "fmt"
+
+// Policy selects which of several matching rules decides.
+type Policy uint8
+
+const (
+	// PolicyLastMatchWins lets the last matching rule decide (gitignore order).
+	PolicyLastMatchWins Policy = iota
+	// PolicyFirstMatchWins lets the first matching rule decide (rsync and
+	// firewall order). Dialect default rules keep their place.
+	PolicyFirstMatchWins
+)
+
+// String returns policy name.
+func (p Policy) String() string {
+	switch p {
+	case PolicyLastMatchWins:
+		return "last-match-wins"
+	case PolicyFirstMatchWins:
+		return "first-match-wins"
+	default:
+		return fmt.Sprintf("policy(%d)", uint8(p))
+	}
+}
+
+// valid reports whether policy value is supported.
+func (p Policy) valid() bool {
+	return p <= PolicyFirstMatchWins
+}
+
+// compiledIndex returns the compiled index of user rule i.
+//
+// With PolicyFirstMatchWins user rules are compiled in reverse order, so
+// last-match-wins evaluation picks the first matching rule.
+func (m *Matcher) compiledIndex(i int) int {
+	if m.firstMatch {
+		i = m.ruleCount - 1 - i
+	}
+
+	return m.implicitBefore + i
+}
+
+// inputOrder returns compiled rule indices in matcher input order.
+func (m *Matcher) inputOrder() []int {
+	order := make([]int, len(m.compiled))
+	for i := range order {
+		order[i] = i
+	}
+
+	if m.firstMatch {
+		for i := range m.ruleCount {
+			order[m.implicitBefore+i] = m.compiledIndex(i)
+		}
+	}
+
+	return order
+}
+
+// outranks reports whether compiled rule i wins over compiled rule j when both match.
+func (m *Matcher) outranks(i, j int) bool {
+	pi, pj := m.compiled[i].source.Priority, m.compiled[j].source.Priority
+	return pi > pj || pi == pj && i > j
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "testing"
+
+func TestMatcherFirstMatchWins(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionInclude, Pattern: "keep.log"},
+		{Action: ActionExclude, Pattern: "*.log"},
+		{Action: ActionInclude, Pattern: "*.log"},
+		{Action: ActionInclude, Pattern: "*.tmp", Priority: 1},
+		{Action: ActionExclude, Pattern: "*.tmp"},
+	}
+
+	var traced []int
+	opts := MatcherOptions{Policy: PolicyFirstMatchWins, TrackCoverage: true}
+	m, err := NewMatcher(rules, opts)
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	opts.Trace = func(ev TraceEvent) { traced = append(traced, ev.RuleIndex) }
+	traceMatcher, err := NewMatcher(rules, opts)
+	if err != nil {
+		t.Fatalf("NewMatcher(trace): %v", err)
+	}
+
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	var decoded Matcher
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	cases := []struct {
+		path     string
+		included bool
+		rule     int
+	}{
+		{path: "keep.log", included: true, rule: 0},
+		{path: "a.log", included: false, rule: 1},
+		{path: "a.tmp", included: true, rule: 3},
+		{path: "a.txt", included: true, rule: -1},
+	}
+
+	for _, matcher := range []*Matcher{m, traceMatcher, &decoded} {
+		for _, tc := range cases {
+			res := matcher.Decide(tc.path, false)
+			if res.Included != tc.included || res.RuleIndex != tc.rule {
+				t.Fatalf("Decide(%q)=%+v, want included=%v rule=%d", tc.path, res, tc.included, tc.rule)
+			}
+		}
+	}
+
+	if traced[0] != 0 || traced[len(rules)-1] != len(rules)-1 {
+		t.Fatalf("trace order=%v, want input order", traced[:len(rules)])
+	}
+
+	if cov := m.Coverage(); cov[1].Won != 1 || cov[1].Rule.Pattern != "*.log" || cov[1].Rule.Action != ActionExclude {
+		t.Fatalf("Coverage[1]=%+v, want one win of exclude *.log", cov[1])
+	}
+
+	if _, err := NewMatcher(rules, MatcherOptions{Policy: PolicyFirstMatchWins + 1}); err == nil {
+		t.Fatalf("NewMatcher accepted unsupported policy")
+	}
+}
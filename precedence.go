@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DirPrecedence selects how Provider combines rules files along a path.
+type DirPrecedence uint8
+
+const (
+	// DirPrecedenceAllLevels evaluates BaseRules and every rules file from
+	// root down; the last matching rule wins.
+	DirPrecedenceAllLevels DirPrecedence = iota
+	// DirPrecedenceNearest evaluates rules files from the deepest directory
+	// up and stops at the first one that matched or declares
+	// "#pragma default"; BaseRules decide only when no rules file did.
+	DirPrecedenceNearest
+)
+
+// String returns precedence name.
+func (d DirPrecedence) String() string {
+	switch d {
+	case DirPrecedenceAllLevels:
+		return "all-levels"
+	case DirPrecedenceNearest:
+		return "nearest"
+	default:
+		return fmt.Sprintf("dir-precedence(%d)", uint8(d))
+	}
+}
+
+// valid reports whether precedence value is supported.
+func (d DirPrecedence) valid() bool {
+	return d <= DirPrecedenceNearest
+}
+
+// applyNearestDirMatchers lets the deepest deciding matcher set the result.
+func (p *Provider) applyNearestDirMatchers(
+	matchers []providerDirMatcher,
+	normalized string,
+	isDir bool,
+	res *MatchResult,
+) {
+	for i := len(matchers) - 1; i >= 0; i-- {
+		candidate, ok := matchers[i].candidate(normalized)
+		if !ok || p.eval.skipsDir(matchers[i].prefix, normalized) {
+			continue
+		}
+
+		if matchers[i].excluded {
+			*res = MatchResult{RuleIndex: -1}
+			p.origin.set(DecisionSourceRuleFileError, matchers[i].prefix, nil, -1)
+			return
+		}
+
+		decision := matchers[i].matcher.Decide(candidate, isDir)
+		if decision.Matched {
+			p.applyDirDecision(matchers[i].prefix, normalized, matchers[i].matcher, decision, res)
+			return
+		}
+
+		if matchers[i].matcher.explicitDefault {
+			*res = MatchResult{Included: decision.Included, RuleIndex: -1}
+			p.origin.set(DecisionSourceDefault, matchers[i].prefix, matchers[i].matcher, -1)
+			return
+		}
+	}
+}
+
+// dirCandidate returns normalized relative to the rules file directory
+// prefix and reports whether that rules file applies to it.
+//
+// Rules from "dir/.pathrules" apply to paths under that directory, not to the
+// directory path itself when it is being evaluated as a directory entry.
+func dirCandidate(prefix, normalized string) (string, bool) {
+	if prefix == "" {
+		return normalized, true
+	}
+
+	if normalized == prefix || !strings.HasPrefix(normalized, prefix+"/") {
+		return "", false
+	}
+
+	return normalized[len(prefix)+1:], true
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestProviderDirPrecedenceNearest(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":          {Data: []byte("*.md\n!docs/**\n")},
+		"docs/.pathrules":     {Data: []byte("#pragma default=exclude\n!*.txt\n")},
+		"docs/api/.pathrules": {Data: []byte("*.txt\n")},
+	}
+
+	base := []Rule{{Action: ActionExclude, Pattern: "*.bin"}}
+	all, err := NewProviderFS(fsys, ProviderOptions{BaseRules: base})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	nearest, err := NewProviderFS(fsys, ProviderOptions{BaseRules: base, DirPrecedence: DirPrecedenceNearest})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	cases := []struct {
+		path    string
+		all     bool
+		nearest bool
+	}{
+		{path: "a.md", all: false, nearest: false},
+		{path: "a.bin", all: false, nearest: false},
+		{path: "b.txt", all: true, nearest: true},
+		{path: "docs/a.md", all: true, nearest: false},
+		{path: "docs/a.txt", all: true, nearest: true},
+		{path: "docs/api/a.txt", all: false, nearest: false},
+		{path: "docs/api/a.md", all: true, nearest: false},
+		{path: "docs/api/a.bin", all: true, nearest: false},
+	}
+
+	for _, tc := range cases {
+		if got, err := all.Included(tc.path, false); err != nil || got != tc.all {
+			t.Fatalf("all-levels Included(%s)=%v err=%v, want %v", tc.path, got, err, tc.all)
+		}
+
+		if got, err := nearest.Included(tc.path, false); err != nil || got != tc.nearest {
+			t.Fatalf("nearest Included(%s)=%v err=%v, want %v", tc.path, got, err, tc.nearest)
+		}
+
+		got, err := nearest.IncludedInDir(pathDir(tc.path, false), []DirEntry{{Name: pathBase(tc.path)}})
+		if err != nil || got[0] != tc.nearest {
+			t.Fatalf("nearest IncludedInDir(%s)=%v err=%v, want %v", tc.path, got, err, tc.nearest)
+		}
+	}
+
+	if _, err := NewProviderFS(fsys, ProviderOptions{DirPrecedence: DirPrecedence(9)}); !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("invalid precedence err=%v", err)
+	}
+}
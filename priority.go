@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"cmp"
+	"slices"
+)
+
+// rulePriorities reports whether compiled rules have different priorities
+// and the highest priority.
+func rulePriorities(compiled []compiledRule) (bool, int) {
+	if len(compiled) == 0 {
+		return false, 0
+	}
+
+	low, high := compiled[0].source.Priority, compiled[0].source.Priority
+	for i := range compiled {
+		low = min(low, compiled[i].source.Priority)
+		high = max(high, compiled[i].source.Priority)
+	}
+
+	return low != high, high
+}
+
+// priorityOrder returns rule indices stably sorted by ascending Priority.
+//
+// Evaluating rules in this order with last-match-wins gives the same
+// decisions as Priority-aware matching in input order.
+func priorityOrder(rules []Rule) []int {
+	order := make([]int, len(rules))
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(rules[a].Priority, rules[b].Priority)
+	})
+
+	return order
+}
+
+// prioritizedWinner returns the compiled index of the matching rule with the
+// highest priority, the last one among equals, -1 when none matched.
+//
+// Only rules accepted by keep are considered, all rules when keep is nil.
+func (m *Matcher) prioritizedWinner(candidate string, isDir bool, keep func(int) bool) int {
+	winner := -1
+	for i := len(m.compiled) - 1; i >= 0; i-- {
+		priority := m.compiled[i].source.Priority
+		if winner >= 0 && priority <= m.compiled[winner].source.Priority {
+			continue
+		}
+
+		if (keep == nil || keep(i)) && m.compiled[i].matches(candidate, isDir) {
+			winner = i
+			if priority == m.maxPriority {
+				break
+			}
+		}
+	}
+
+	return winner
+}
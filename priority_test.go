@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMatcherRulePriority(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionInclude, Pattern: "*.sig", Priority: 10},
+		{Action: ActionExclude, Pattern: "*.tmp", Priority: 1},
+		{Action: ActionExclude, Pattern: "dist/"},
+		{Action: ActionInclude, Pattern: "keep.tmp"},
+		{Action: ActionExclude, Pattern: "*.sig"},
+	}
+
+	// Enough literal rules to build the literal index without priorities.
+	for i := range literalIndexMinRules {
+		rules = append(rules, Rule{Action: ActionExclude, Pattern: fmt.Sprintf("/lit/%d", i)})
+	}
+
+	m, err := NewMatcher(rules, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if m.index != nil {
+		t.Fatalf("literal index built for prioritized rules")
+	}
+
+	cases := []struct {
+		path     string
+		included bool
+		rule     int
+	}{
+		{path: "dist/a.sig", included: true, rule: 0},
+		{path: "keep.tmp", included: false, rule: 1},
+		{path: "dist/a.txt", included: false, rule: 2},
+		{path: "lit/3", included: false, rule: 8},
+		{path: "a.txt", included: true, rule: -1},
+	}
+
+	traceMatcher, err := NewMatcher(rules, MatcherOptions{Trace: func(TraceEvent) {}})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	var decoded Matcher
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	for _, tc := range cases {
+		for name, matcher := range map[string]*Matcher{"plain": m, "trace": traceMatcher, "binary": &decoded} {
+			res := matcher.Decide(tc.path, false)
+			if res.Included != tc.included || res.RuleIndex != tc.rule {
+				t.Fatalf("%s: Decide(%q)=%+v, want included=%v rule=%d", name, tc.path, res, tc.included, tc.rule)
+			}
+		}
+	}
+}
+
+func TestExportRulesPriorityOrder(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionInclude, Pattern: "*.sig", Priority: 1},
+		{Action: ActionExclude, Pattern: "*.sig"},
+	}
+
+	text, _, err := ExportRules(rules, ExportPathrules)
+	if err != nil || text != "*.sig\n!*.sig\n" {
+		t.Fatalf("ExportRules=%q err=%v, want priority order", text, err)
+	}
+
+	if diags := LintRules(rules); len(diags) != 0 {
+		t.Fatalf("LintRules=%+v, want none for higher-priority earlier rule", diags)
+	}
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "fmt"
+
+// DecisionSource identifies the part of a Provider chain that decided a path.
+type DecisionSource uint8
+
+const (
+	// DecisionSourceDefault means no rule matched and the default action
+	// applied, from a "#pragma default" directive when Dir is set.
+	DecisionSourceDefault DecisionSource = iota
+	// DecisionSourceBase means a ProviderOptions.BaseRules rule decided.
+	DecisionSourceBase
+	// DecisionSourceRulesFile means a rule of the rules file in Dir decided.
+	DecisionSourceRulesFile
+	// DecisionSourceRuleFileError means the rules file in Dir failed to
+	// load and RuleFileErrorExcludeSubtree excluded the path.
+	DecisionSourceRuleFileError
+	// DecisionSourceFinal means a ProviderOptions.FinalRules rule decided.
+	DecisionSourceFinal
+)
+
+// VerboseResult is a Provider decision with its provenance.
+type VerboseResult struct {
+	// Rule is the deciding rule with Source set to File, zero value when
+	// no rule decided.
+	Rule Rule `json:"rule" yaml:"rule"`
+	// Dir is the deciding rules file directory relative to provider root,
+	// "" for root; ancestors above root use "../" elements.
+	Dir string `json:"dir,omitempty" yaml:"dir,omitempty"`
+	// File is the deciding rules file path as used in Rule.Source, empty
+	// when no rules file decided.
+	File string `json:"file,omitempty" yaml:"file,omitempty"`
+	// MatchResult is the same decision Decide returns; RuleIndex is local
+	// to the rules of Source and Dir.
+	MatchResult
+	// Source tells which part of the chain decided.
+	Source DecisionSource `json:"source" yaml:"source"`
+}
+
+// decisionOrigin records the last level that set a decision during DecideVerbose.
+type decisionOrigin struct {
+	// matcher holds the deciding rule or "#pragma default", nil otherwise.
+	matcher *Matcher
+	// dir is the deciding rules file directory.
+	dir string
+	// ruleIndex is the deciding rule index in matcher input order.
+	ruleIndex int
+	// source is the deciding chain part.
+	source DecisionSource
+}
+
+// String returns source name.
+func (s DecisionSource) String() string {
+	switch s {
+	case DecisionSourceDefault:
+		return "default"
+	case DecisionSourceBase:
+		return "base"
+	case DecisionSourceRulesFile:
+		return "rules-file"
+	case DecisionSourceRuleFileError:
+		return "rule-file-error"
+	case DecisionSourceFinal:
+		return "final"
+	default:
+		return fmt.Sprintf("decision-source(%d)", uint8(s))
+	}
+}
+
+// DecideVerbose is Decide that also reports which level decided: the
+// default action, BaseRules, FinalRules or a rules file with its directory, path and
+// the deciding rule including its line.
+func (p *Provider) DecideVerbose(relPath string, isDir bool) (VerboseResult, error) {
+	if p == nil {
+		return VerboseResult{}, ErrNilProvider
+	}
+
+	origin := &decisionOrigin{ruleIndex: -1}
+	view := *p
+	view.origin = origin
+	res, err := view.Decide(relPath, isDir)
+	if err != nil {
+		return VerboseResult{}, err
+	}
+
+	out := VerboseResult{MatchResult: res, Source: origin.source}
+	if origin.source == DecisionSourceRulesFile || origin.source == DecisionSourceRuleFileError ||
+		origin.source == DecisionSourceDefault && origin.matcher != nil {
+		out.Dir = origin.dir
+		out.File = p.rulesFilePath(origin.dir)
+	}
+
+	if origin.matcher != nil && origin.ruleIndex >= 0 && origin.ruleIndex < origin.matcher.ruleCount {
+		out.Rule = origin.matcher.compiled[origin.matcher.compiledIndex(origin.ruleIndex)].source
+		if out.File != "" {
+			out.Rule.Source = out.File
+		}
+	}
+
+	return out, nil
+}
+
+// set records the deciding level; it is a no-op outside DecideVerbose.
+func (o *decisionOrigin) set(source DecisionSource, dir string, matcher *Matcher, ruleIndex int) {
+	if o == nil {
+		return
+	}
+
+	*o = decisionOrigin{source: source, dir: dir, matcher: matcher, ruleIndex: ruleIndex}
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestProviderDecideVerbose(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":     {Data: []byte("*.log\n")},
+		"sub/.pathrules": {Data: []byte("#pragma default=exclude\n!keep.log\n")},
+	}
+
+	p, err := NewProviderFS(fsys, ProviderOptions{BaseRules: []Rule{{Action: ActionExclude, Pattern: "*.bak"}}})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	cases := []struct {
+		path     string
+		source   DecisionSource
+		dir      string
+		file     string
+		pattern  string
+		line     int
+		included bool
+	}{
+		{path: "a.txt", source: DecisionSourceDefault, included: true},
+		{path: "a.bak", source: DecisionSourceBase, pattern: "*.bak"},
+		{path: "a.log", source: DecisionSourceRulesFile, file: ".pathrules", pattern: "*.log", line: 1},
+		{path: "sub/keep.log", source: DecisionSourceRulesFile, dir: "sub", file: "sub/.pathrules", pattern: "keep.log", line: 2, included: true},
+		{path: "sub/a.txt", source: DecisionSourceDefault, dir: "sub", file: "sub/.pathrules"},
+	}
+
+	for _, tc := range cases {
+		got, err := p.DecideVerbose(tc.path, false)
+		if err != nil {
+			t.Fatalf("DecideVerbose(%s): %v", tc.path, err)
+		}
+
+		if got.Source != tc.source || got.Dir != tc.dir || got.File != tc.file || got.Included != tc.included {
+			t.Fatalf("DecideVerbose(%s)=%+v, want source=%s dir=%q file=%q included=%v",
+				tc.path, got, tc.source, tc.dir, tc.file, tc.included)
+		}
+
+		if got.Rule.Pattern != tc.pattern || got.Rule.Line != tc.line {
+			t.Fatalf("DecideVerbose(%s) rule=%+v, want %q line %d", tc.path, got.Rule, tc.pattern, tc.line)
+		}
+
+		if plain, err := p.Decide(tc.path, false); err != nil || plain != got.MatchResult {
+			t.Fatalf("Decide(%s)=%+v err=%v, DecideVerbose=%+v", tc.path, plain, err, got.MatchResult)
+		}
+	}
+}
@@ -6,11 +6,21 @@ package pathrules
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
 	"fmt"
+	"io/fs"
+	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
 	"sync"
+	"time"
+	"weak"
 )
 
 const defaultRulesFileName = ".pathrules"
@@ -20,14 +30,110 @@ type ProviderOptions struct {
 	// RulesFileName is the rules file loaded in each directory in the path chain.
 	// Empty value defaults to ".pathrules".
 	RulesFileName string `json:"rules_file_name,omitempty" yaml:"rules_file_name,omitempty"`
+	// RulesFormat selects rules file syntax, RulesFormatPathrules when zero.
+	// RulesFormatRsyncFilter is usually paired with DialectRsync and
+	// RulesFormatHgignore with DialectHg.
+	RulesFormat RulesFormat `json:"rules_format,omitempty" yaml:"rules_format,omitempty"`
 	// BaseRules are in-memory rules evaluated before directory-loaded rules.
 	BaseRules []Rule `json:"base_rules,omitempty" yaml:"base_rules,omitempty"`
+	// FinalRules are in-memory rules evaluated after every rules file; a
+	// matching final rule always decides, even below excluded subtrees of
+	// RuleFileErrorExcludeSubtree. Walks still prune excluded directories.
+	FinalRules []Rule `json:"final_rules,omitempty" yaml:"final_rules,omitempty"`
+	// Sections enables "[name]" section headers in rules files and compiles
+	// only common rules plus the listed sections (BaseRules and FinalRules
+	// are filtered too).
+	// Empty value disables section syntax.
+	Sections []string `json:"sections,omitempty" yaml:"sections,omitempty"`
 	// MatcherOptions controls rule matching behavior for all compiled matchers.
 	MatcherOptions MatcherOptions `json:"matcher_options" yaml:"matcher_options"`
+	// ParallelDecideThreshold makes DecideInDir split entries across
+	// GOMAXPROCS goroutines when at least this many are given; 0 disables it.
+	ParallelDecideThreshold int `json:"parallel_decide_threshold,omitempty" yaml:"parallel_decide_threshold,omitempty"`
 	// EnableSymlinkEscapeCheck enables resolved-path validation to block
-	// symlink/junction escapes outside provider root.
+	// symlink/junction escapes outside provider root. On Windows NTFS
+	// junctions, volume mount points and substituted drives are resolved too.
 	// Default is false for lower cold-path overhead.
 	EnableSymlinkEscapeCheck bool `json:"enable_symlink_escape_check,omitempty" yaml:"enable_symlink_escape_check,omitempty"`
+	// TrustJunctions makes the escape check follow only symlinks, so NTFS
+	// junctions and volume mount points may lead outside root. Windows only.
+	TrustJunctions bool `json:"trust_junctions,omitempty" yaml:"trust_junctions,omitempty"`
+	// RejectUnsafeNames fails input paths and entry names that Windows cannot
+	// store as given with ErrUnsafeName: reserved device names (CON, NUL,
+	// COM1, ...), trailing dots or spaces, and ":" stream suffixes
+	// ("file.txt:stream") or other invalid characters.
+	RejectUnsafeNames bool `json:"reject_unsafe_names,omitempty" yaml:"reject_unsafe_names,omitempty"`
+	// MaxRulesFileSize fails rules files larger than this many bytes with
+	// ErrRulesFileTooLarge, 0 disables.
+	MaxRulesFileSize int64 `json:"max_rules_file_size,omitempty" yaml:"max_rules_file_size,omitempty"`
+	// MaxRulesPerFile fails rules files with more rules with ErrTooManyRules, 0 disables.
+	MaxRulesPerFile int `json:"max_rules_per_file,omitempty" yaml:"max_rules_per_file,omitempty"`
+	// MaxHierarchyDepth fails decisions needing rules files more than this
+	// many directories below root with ErrHierarchyTooDeep, 0 disables.
+	MaxHierarchyDepth int `json:"max_hierarchy_depth,omitempty" yaml:"max_hierarchy_depth,omitempty"`
+	// CandidateSymlinks selects how candidate paths through symlinks are
+	// treated, CandidateSymlinksAllow when zero. Ignored by NewProviderFS.
+	CandidateSymlinks CandidateSymlinkPolicy `json:"candidate_symlinks,omitempty" yaml:"candidate_symlinks,omitempty"`
+	// MissingPaths selects how DecideStat treats paths that do not exist,
+	// MissingPathError when zero.
+	MissingPaths MissingPathPolicy `json:"missing_paths,omitempty" yaml:"missing_paths,omitempty"`
+	// UseOSRoot reads rules files through an os.Root opened at root, so
+	// concurrent renames or symlink swaps cannot lead reads outside it.
+	// Call Provider.Close to release the root handle. Ignored by NewProviderFS.
+	UseOSRoot bool `json:"use_os_root,omitempty" yaml:"use_os_root,omitempty"`
+	// ParentExclusionBlocksReinclude follows git: a path below a directory
+	// excluded by a rule stays excluded even when a rule re-includes it, and
+	// reports the decision of the outermost excluded ancestor.
+	ParentExclusionBlocksReinclude bool `json:"parent_exclusion_blocks_reinclude,omitempty" yaml:"parent_exclusion_blocks_reinclude,omitempty"`
+	// DirPrecedence selects how rules files along a path are combined,
+	// DirPrecedenceAllLevels when zero.
+	DirPrecedence DirPrecedence `json:"dir_precedence,omitempty" yaml:"dir_precedence,omitempty"`
+	// RootMarkerFileName, when set, names a marker file (e.g. ".pathrulesroot")
+	// that stops rules file inheritance: paths below a directory holding it
+	// ignore rules files above that directory. BaseRules still apply.
+	RootMarkerFileName string `json:"root_marker_file_name,omitempty" yaml:"root_marker_file_name,omitempty"`
+	// ScanAncestors also loads rules files from directories above root, as
+	// git does when run in a subdirectory. They are read once by NewProvider,
+	// apply before root rules files and are not reloaded by Refresh.
+	// Ignored by NewProviderFS.
+	ScanAncestors bool `json:"scan_ancestors,omitempty" yaml:"scan_ancestors,omitempty"`
+	// AncestorBoundary names a file or directory (e.g. ".git") marking the
+	// outermost ancestor ScanAncestors loads. When set and no ancestor holds
+	// it, nothing above root is loaded; empty scans up to the filesystem root.
+	// A directory holding RootMarkerFileName also stops the scan.
+	AncestorBoundary string `json:"ancestor_boundary,omitempty" yaml:"ancestor_boundary,omitempty"`
+	// OnRuleFileError selects how unreadable or invalid rules files are
+	// handled, RuleFileErrorFail when zero.
+	OnRuleFileError RuleFileErrorPolicy `json:"on_rule_file_error,omitempty" yaml:"on_rule_file_error,omitempty"`
+	// RulesSource, when set, supplies per-directory rules instead of
+	// RulesFileName files; RulesFormat and "#pragma" directives do not apply.
+	// Refresh reloads every directory. ScanAncestors and RootMarkerFileName
+	// still read the filesystem.
+	RulesSource RulesSource `json:"-" yaml:"-"`
+	// RuleFileErrorHandler, when set, receives every rules file error once per load.
+	RuleFileErrorHandler RuleFileErrorHandler `json:"-" yaml:"-"`
+	// OnRulesLoaded, when set, receives the rules of every loaded rules file.
+	OnRulesLoaded RulesLoadedHook `json:"-" yaml:"-"`
+	// OnDecisionOverridden, when set, receives every inclusion change made
+	// by a rules file during decisions, for audit trails.
+	OnDecisionOverridden DecisionOverriddenHook `json:"-" yaml:"-"`
+	// OnRuleFileLoaded, when set, is told about every loaded rules file.
+	OnRuleFileLoaded RuleFileLoadedHook `json:"-" yaml:"-"`
+	// OnRuleFileMissing, when set, is told about every directory loaded
+	// without a rules file.
+	OnRuleFileMissing RuleFileMissingHook `json:"-" yaml:"-"`
+	// OnCacheEvict, when set, is told about every directory dropped from
+	// the rules cache by Refresh.
+	OnCacheEvict CacheEvictHook `json:"-" yaml:"-"`
+	// OnDecision, when set, receives every Decide result and every
+	// DecideInDir entry result.
+	OnDecision DecisionHook `json:"-" yaml:"-"`
+	// Logger, when set, receives debug records of rules file loads and
+	// errors, symlink escape rejections and cache drops by Refresh.
+	Logger *slog.Logger `json:"-" yaml:"-"`
+	// Instrumentation, when set, observes Decide, DecideInDir and rules
+	// loads, for tracing and metrics; see package pathrulesotel.
+	Instrumentation Instrumentation `json:"-" yaml:"-"`
 }
 
 // DirEntry is one directory entry input for Provider batch APIs.
@@ -42,23 +148,101 @@ type DirEntry struct {
 type Provider struct {
 	// baseMatcher evaluates global in-memory rules before directory rules.
 	baseMatcher *Matcher
-	// cache stores directory-local compiled matcher by relative directory path.
-	cache map[string]*cachedDirMatcher
-	// root is absolute provider root directory path.
+	// finalMatcher evaluates FinalRules after directory rules, nil when unset.
+	finalMatcher *Matcher
+	// fsys is rules file source for NewProviderFS, nil for OS root providers.
+	fsys fs.FS
+	// source supplies directory rules instead of rules files, nil when unset.
+	source RulesSource
+	// cache stores directory-local compiled matchers, shared with scoped views.
+	cache *dirMatcherCache
+	// root is absolute provider root directory path, empty for fs.FS providers.
 	root string
 	// resolvedRoot is provider root with symlinks/junctions resolved when possible.
 	resolvedRoot string
 	// rulesFileName is per-directory rules file name.
 	rulesFileName string
+	// scope is relative directory prefix of a Scope view, empty for root provider.
+	scope string
+	// sections lists selected rules file sections, empty when section syntax is disabled.
+	sections []string
 
-	// mu guards cache access.
-	mu sync.Mutex
 	// matcherOptions are shared compilation and decision options.
 	matcherOptions MatcherOptions
+	// parallelThreshold is DecideInDir entry count that enables parallel evaluation, 0 disables it.
+	parallelThreshold int
+	// rulesFormat is per-directory rules file syntax.
+	rulesFormat RulesFormat
 	// defaultIncluded is fallback decision when no rule matched anywhere.
 	defaultIncluded bool
 	// enableSymlinkEscapeCheck enables resolved-path root boundary validation.
 	enableSymlinkEscapeCheck bool
+	// followJunctions resolves junctions and mount points during escape checks.
+	followJunctions bool
+	// rejectUnsafeNames enables Windows name checks of input paths.
+	rejectUnsafeNames bool
+	// maxRulesFileSize limits rules file size in bytes, 0 disables.
+	maxRulesFileSize int64
+	// maxRulesPerFile limits rules count per rules file, 0 disables.
+	maxRulesPerFile int
+	// maxHierarchyDepth limits depth of directories whose rules are loaded, 0 disables.
+	maxHierarchyDepth int
+	// candidateSymlinks selects symlink resolution of candidate paths.
+	candidateSymlinks CandidateSymlinkPolicy
+	// missingPaths selects DecideStat handling of missing paths.
+	missingPaths MissingPathPolicy
+	// osRoot reads rules files when ProviderOptions.UseOSRoot is set, nil otherwise.
+	osRoot *os.Root
+	// onRuleFileError selects rules file error handling.
+	onRuleFileError RuleFileErrorPolicy
+	// ruleFileErrorHandler receives rules file errors, nil when unset.
+	ruleFileErrorHandler RuleFileErrorHandler
+	// onRulesLoaded receives loaded rules, nil when unset.
+	onRulesLoaded RulesLoadedHook
+	// onDecisionOverridden receives decision changes made by rules files, nil when unset.
+	onDecisionOverridden DecisionOverriddenHook
+	// onRuleFileLoaded is told about loaded rules files, nil when unset.
+	onRuleFileLoaded RuleFileLoadedHook
+	// onRuleFileMissing is told about directories without rules files, nil when unset.
+	onRuleFileMissing RuleFileMissingHook
+	// onCacheEvict is told about directories dropped by Refresh, nil when unset.
+	onCacheEvict CacheEvictHook
+	// onDecision receives decisions, nil when unset.
+	onDecision DecisionHook
+	// logger receives debug records, nil when unset.
+	logger *slog.Logger
+	// instrumentation observes operations, nil when unset.
+	instrumentation Instrumentation
+	// ctx is passed to instrumentation, set by WithContext.
+	ctx context.Context
+	// dirPrecedence selects how rules files along a path are combined.
+	dirPrecedence DirPrecedence
+	// ancestors are rules files above root loaded by ScanAncestors, outermost first.
+	ancestors []providerDirMatcher
+	// eval narrows decisions during DecideWith, nil otherwise.
+	eval *EvalOptions
+	// origin records the deciding level during DecideVerbose, nil otherwise.
+	origin *decisionOrigin
+	// rootMarker is the inheritance stop marker file name, empty when disabled.
+	rootMarker string
+	// frozen marks a Snapshot view whose cached matchers are never dropped.
+	frozen bool
+	// parentBlocks makes excluded ancestor directories exclude their contents.
+	parentBlocks bool
+}
+
+// dirMatcherCache stores directory-local compiled matchers by relative directory path.
+type dirMatcherCache struct {
+	// entries maps relative directory path to its matcher.
+	entries map[string]*cachedDirMatcher
+	// shared maps rules file content hash to a compiled matcher reused by identical files.
+	shared map[[sha256.Size]byte]weak.Pointer[Matcher]
+	// markers caches whether a directory holds the root marker file.
+	markers map[string]bool
+	// counters are provider statistics shared with scoped views.
+	counters providerCounters
+	// mu guards entries and markers access.
+	mu sync.Mutex
 }
 
 // cachedDirMatcher stores one directory rules matcher or a cached load error.
@@ -67,18 +251,44 @@ type cachedDirMatcher struct {
 	matcher *Matcher
 	// err stores parse/compile error for deterministic repeated calls.
 	err error
+	// stamp is rules file state observed when matcher was loaded.
+	stamp rulesFileStamp
 	// loading reports whether matcher is currently being loaded by another goroutine.
 	loading bool
 	// wg coordinates concurrent waiters for one load attempt.
 	wg sync.WaitGroup
 }
 
+// rulesFileStamp is rules file state used to detect changes.
+type rulesFileStamp struct {
+	// modTime is file modification time.
+	modTime time.Time
+	// size is file size in bytes.
+	size int64
+	// found reports whether the file existed.
+	found bool
+}
+
+// rulesFile is one read rules file.
+type rulesFile struct {
+	// content is raw file content.
+	content []byte
+	// path is file path used in errors and Rule.Source.
+	path string
+	rulesFileStamp
+}
+
 // providerDirMatcher is one prepared directory-level matcher with prefix.
 type providerDirMatcher struct {
 	// matcher evaluates rules loaded from one directory.
 	matcher *Matcher
-	// prefix is relative directory prefix used for candidate trimming.
+	// prefix is relative directory prefix used for candidate trimming,
+	// a "../.." form for ancestors above root.
 	prefix string
+	// outer is root relative to an ancestor directory above it, prepended to candidates.
+	outer string
+	// excluded marks a subtree excluded by RuleFileErrorExcludeSubtree; matcher is nil.
+	excluded bool
 }
 
 // NewProvider creates a recursive rules provider rooted at rootDir.
@@ -89,73 +299,345 @@ func NewProvider(rootDir string, opts ProviderOptions) (*Provider, error) {
 	}
 
 	resolvedRoot := absRoot
-	if opts.EnableSymlinkEscapeCheck {
-		resolvedRoot, err = resolvePathOrAbs(absRoot)
+	if opts.EnableSymlinkEscapeCheck || opts.CandidateSymlinks != CandidateSymlinksAllow {
+		resolvedRoot, err = resolvePathOrAbs(absRoot, !opts.TrustJunctions)
 		if err != nil {
 			return nil, fmt.Errorf("resolve root: %w", err)
 		}
 	}
 
+	p, err := newProvider(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	p.root = absRoot
+	p.resolvedRoot = resolvedRoot
+	p.enableSymlinkEscapeCheck = opts.EnableSymlinkEscapeCheck
+	p.followJunctions = !opts.TrustJunctions
+	if opts.ScanAncestors {
+		p.ancestors, err = p.loadAncestors(opts.AncestorBoundary)
+		if err != nil {
+			return nil, fmt.Errorf("load ancestor rules: %w", err)
+		}
+	}
+
+	// Opened last so that no earlier failure leaks the root handle.
+	if opts.UseOSRoot {
+		p.osRoot, err = os.OpenRoot(absRoot)
+		if err != nil {
+			return nil, fmt.Errorf("open root: %w", err)
+		}
+	}
+
+	return p, nil
+}
+
+// NewProviderFS creates a recursive rules provider reading rules files from fsys.
+//
+// Paths are relative to fsys root. EnableSymlinkEscapeCheck is ignored:
+// fs.FS implementations resolve names inside their own root.
+func NewProviderFS(fsys fs.FS, opts ProviderOptions) (*Provider, error) {
+	if fsys == nil {
+		return nil, fmt.Errorf("%w: nil fs.FS", ErrInvalidOptions)
+	}
+
+	p, err := newProvider(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	p.fsys = fsys
+	return p, nil
+}
+
+// newProvider validates options and builds provider without rules file source.
+func newProvider(opts ProviderOptions) (*Provider, error) {
 	opts.MatcherOptions.applyDefaults()
+	if !opts.RulesFormat.valid() {
+		return nil, fmt.Errorf("%w: unsupported rules format %s", ErrInvalidOptions, opts.RulesFormat)
+	}
+
+	if !opts.OnRuleFileError.valid() {
+		return nil, fmt.Errorf("%w: unsupported rules file error policy %s", ErrInvalidOptions, opts.OnRuleFileError)
+	}
+
+	if !opts.DirPrecedence.valid() {
+		return nil, fmt.Errorf("%w: unsupported dir precedence %s", ErrInvalidOptions, opts.DirPrecedence)
+	}
+
+	if !opts.CandidateSymlinks.valid() {
+		return nil, fmt.Errorf("%w: unsupported candidate symlink policy %s", ErrInvalidOptions, opts.CandidateSymlinks)
+	}
+
+	if !opts.MissingPaths.valid() {
+		return nil, fmt.Errorf("%w: unsupported missing path policy %s", ErrInvalidOptions, opts.MissingPaths)
+	}
+
+	// A matcher profile selects a rules file section too; it moves into
+	// Sections so that per-directory matchers keep every listed section.
+	if opts.MatcherOptions.Profile != "" {
+		if !slices.Contains(opts.Sections, opts.MatcherOptions.Profile) {
+			opts.Sections = append(slices.Clone(opts.Sections), opts.MatcherOptions.Profile)
+		}
+
+		opts.MatcherOptions.Profile = ""
+	}
+
+	// Input paths are validated by the provider; its matchers only see
+	// relative candidates, including "" for root.
+	opts.MatcherOptions.StrictPaths = false
+
+	baseRules := opts.BaseRules
+	if len(opts.Sections) > 0 {
+		baseRules = SelectSections(baseRules, opts.Sections...)
+	}
 
-	baseMatcher, err := NewMatcher(opts.BaseRules, opts.MatcherOptions)
+	baseMatcher, err := NewMatcher(baseRules, opts.MatcherOptions)
 	if err != nil {
 		return nil, fmt.Errorf("compile base rules: %w", err)
 	}
 
+	var finalMatcher *Matcher
+	if len(opts.FinalRules) > 0 {
+		finalRules := opts.FinalRules
+		if len(opts.Sections) > 0 {
+			finalRules = SelectSections(finalRules, opts.Sections...)
+		}
+
+		finalMatcher, err = NewMatcher(finalRules, opts.MatcherOptions)
+		if err != nil {
+			return nil, fmt.Errorf("compile final rules: %w", err)
+		}
+	}
+
 	rulesFileName, err := cleanRulesFileName(opts.RulesFileName)
 	if err != nil {
 		return nil, err
 	}
 
+	rootMarker, err := cleanRootMarkerFileName(opts.RootMarkerFileName)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Provider{
-		root:                     absRoot,
-		resolvedRoot:             resolvedRoot,
-		rulesFileName:            rulesFileName,
-		matcherOptions:           opts.MatcherOptions,
-		baseMatcher:              baseMatcher,
-		defaultIncluded:          opts.MatcherOptions.DefaultAction == ActionInclude,
-		enableSymlinkEscapeCheck: opts.EnableSymlinkEscapeCheck,
-		cache:                    make(map[string]*cachedDirMatcher),
+		rulesFileName:        rulesFileName,
+		sections:             slices.Clone(opts.Sections),
+		matcherOptions:       opts.MatcherOptions,
+		rulesFormat:          opts.RulesFormat,
+		parallelThreshold:    opts.ParallelDecideThreshold,
+		rejectUnsafeNames:    opts.RejectUnsafeNames,
+		maxRulesFileSize:     opts.MaxRulesFileSize,
+		maxRulesPerFile:      opts.MaxRulesPerFile,
+		maxHierarchyDepth:    opts.MaxHierarchyDepth,
+		candidateSymlinks:    opts.CandidateSymlinks,
+		missingPaths:         opts.MissingPaths,
+		onRuleFileError:      opts.OnRuleFileError,
+		ruleFileErrorHandler: opts.RuleFileErrorHandler,
+		source:               opts.RulesSource,
+		onRulesLoaded:        opts.OnRulesLoaded,
+		onDecisionOverridden: opts.OnDecisionOverridden,
+		onRuleFileLoaded:     opts.OnRuleFileLoaded,
+		onRuleFileMissing:    opts.OnRuleFileMissing,
+		onCacheEvict:         opts.OnCacheEvict,
+		onDecision:           opts.OnDecision,
+		logger:               opts.Logger,
+		instrumentation:      opts.Instrumentation,
+		parentBlocks:         opts.ParentExclusionBlocksReinclude,
+		dirPrecedence:        opts.DirPrecedence,
+		rootMarker:           rootMarker,
+		baseMatcher:          baseMatcher,
+		finalMatcher:         finalMatcher,
+		defaultIncluded:      opts.MatcherOptions.DefaultAction == ActionInclude,
+		cache: &dirMatcherCache{
+			entries: make(map[string]*cachedDirMatcher),
+			shared:  make(map[[sha256.Size]byte]weak.Pointer[Matcher]),
+			markers: make(map[string]bool),
+		},
 	}, nil
 }
 
+// Scope returns a view of p rooted at relDir.
+//
+// The view accepts paths relative to relDir and returns the same decisions
+// as p for the joined path: BaseRules and rules files above relDir still
+// apply. Compiled matchers are shared with p, so Refresh on either affects both.
+func (p *Provider) Scope(relDir string) (*Provider, error) {
+	if p == nil {
+		return nil, ErrNilProvider
+	}
+
+	if err := p.checkNames(relDir); err != nil {
+		return nil, err
+	}
+
+	normalizedDir, err := p.cleanRelDir(relDir)
+	if err != nil {
+		return nil, err
+	}
+
+	scoped := *p
+	scoped.scope = p.scopedPath(normalizedDir)
+	return &scoped, nil
+}
+
 // Decide returns final include/exclude decision for a path relative to provider root.
 //
 // Decision order:
 // 1. BaseRules matcher.
 // 2. Rules files from root to deepest containing directory.
-// Last matched rule wins.
+// Last matched rule wins; ProviderOptions.DirPrecedence may let the nearest
+// rules file decide instead.
+//
+// Rules files may override matcher options with "#pragma" header directives.
+// A "#pragma default=..." directive replaces the fallback decision for paths
+// under that directory when no rule matched at any level.
+//
+// With PathSeparatorWindows, or PathSeparatorAuto on Windows, absolute
+// Windows paths ("C:\repo\a.txt", UNC, "\\?\" long paths) inside root are
+// accepted; other absolute paths fail with ErrPathOutsideRoot.
+// ProviderOptions.CandidateSymlinks may reject or resolve symlinked paths.
+// A trailing separator in relPath ("build/") implies isDir, see DirHint.
 func (p *Provider) Decide(relPath string, isDir bool) (MatchResult, error) {
 	if p == nil {
 		return MatchResult{}, ErrNilProvider
 	}
 
-	normalized, err := cleanRelPath(relPath)
+	view, end := p.instrument(Operation{Kind: OperationDecide, Path: relPath})
+	if end == nil && p.onDecision == nil {
+		return view.decide(relPath, isDir)
+	}
+
+	res, err := view.decide(relPath, isDir)
+	if end != nil {
+		end(OperationEnd{Err: err, Included: res.Included})
+	}
+
+	if p.onDecision != nil {
+		p.onDecision(relPath, isDir, res, err)
+	}
+
+	return res, err
+}
+
+// decide is Decide without instrumentation and OnDecision.
+func (p *Provider) decide(relPath string, isDir bool) (MatchResult, error) {
+	normalized, err := p.candidatePath(relPath)
+	if err != nil {
+		return MatchResult{}, err
+	}
+
+	isDir = isDir || dirHint(relPath, p.posixSeparators())
+	p.cache.counters.decisions.Add(1)
+	return p.decideResolved(normalized, isDir)
+}
+
+// candidatePath validates relPath and returns the root-relative path to
+// evaluate after scope and the candidate symlink policy were applied.
+func (p *Provider) candidatePath(relPath string) (string, error) {
+	if err := p.checkNames(relPath); err != nil {
+		return "", err
+	}
+
+	normalized, err := p.cleanInputPath(relPath)
+	if err != nil {
+		return "", err
+	}
+
+	return p.resolveCandidate(p.scopedPath(normalized))
+}
+
+// decideResolved returns the decision for a normalized root-relative path
+// after the candidate symlink policy was applied.
+func (p *Provider) decideResolved(normalized string, isDir bool) (MatchResult, error) {
+	res, err := p.decideLevels(normalized, isDir)
 	if err != nil {
 		return MatchResult{}, err
 	}
 
+	p.applyFinalRules(normalized, isDir, &res)
+	return res, nil
+}
+
+// applyFinalRules lets a matching FinalRules rule replace res.
+func (p *Provider) applyFinalRules(normalized string, isDir bool, res *MatchResult) {
+	if p.finalMatcher == nil || p.eval.skipsFinal() {
+		return
+	}
+
+	if final := p.finalMatcher.Decide(normalized, isDir); final.Matched {
+		*res = final
+		p.origin.set(DecisionSourceFinal, "", p.finalMatcher, final.RuleIndex)
+	}
+}
+
+// decideLevels returns the decision of base rules and rules files before FinalRules apply.
+func (p *Provider) decideLevels(normalized string, isDir bool) (MatchResult, error) {
+	if p.parentBlocks {
+		if blocked, ok, err := p.excludedAncestor(pathDir(normalized, false)); err != nil || ok {
+			return blocked, err
+		}
+	}
+
+	res, err := p.decideChain(normalized, isDir)
+	if errors.Is(err, errSubtreeExcluded) {
+		return MatchResult{RuleIndex: -1}, nil
+	}
+
+	return res, err
+}
+
+// decideChain evaluates base rules and the rules files from root to the path directory.
+func (p *Provider) decideChain(normalized string, isDir bool) (MatchResult, error) {
 	res := MatchResult{
 		Included:  p.defaultIncluded,
 		Matched:   false,
 		RuleIndex: -1,
 	}
 
-	if p.baseMatcher != nil {
+	p.origin.set(DecisionSourceDefault, "", nil, -1)
+	if p.baseMatcher != nil && !p.eval.skipsBase() {
 		baseRes := p.baseMatcher.Decide(normalized, isDir)
 		if baseRes.Matched {
 			res = baseRes
+			p.origin.set(DecisionSourceBase, "", p.baseMatcher, baseRes.RuleIndex)
 		}
 	}
 
+	// A root marker in a directory does not cut the directory itself off from
+	// outer rules; its own rules file is still loaded and validated.
 	relDir := pathDir(normalized, isDir)
-	if err := p.applyDirMatcherDecision("", normalized, isDir, &res); err != nil {
+	markerDir := pathDir(normalized, false)
+	if p.dirPrecedence == DirPrecedenceNearest {
+		matchers, err := p.prepareProviderDirMatchers(relDir, markerDir)
+		if err != nil {
+			return MatchResult{}, err
+		}
+
+		p.applyNearestDirMatchers(matchers, normalized, isDir, &res)
+		return res, nil
+	}
+
+	start, err := p.rulesStart(markerDir)
+	if err != nil {
 		return MatchResult{}, err
 	}
 
+	if start == "" {
+		if n := len(p.ancestors); n > 0 && p.ancestors[n-1].excluded && !p.eval.skipsDir(p.ancestors[n-1].prefix, normalized) {
+			p.origin.set(DecisionSourceRuleFileError, p.ancestors[n-1].prefix, nil, -1)
+			return MatchResult{}, errSubtreeExcluded
+		}
+
+		p.applyPreparedDirMatchers(p.ancestors, normalized, isDir, &res)
+		if err := p.applyDirMatcherDecision("", normalized, isDir, &res); err != nil {
+			return MatchResult{}, err
+		}
+	}
+
 	if relDir != "" {
-		for i := 0; i < len(relDir); i++ {
+		for i := len(start); i < len(relDir); i++ {
 			if relDir[i] != '/' {
 				continue
 			}
@@ -181,42 +663,69 @@ func (p *Provider) DecideInDir(relDir string, entries []DirEntry) ([]MatchResult
 		return nil, ErrNilProvider
 	}
 
-	normalizedDir, err := cleanRelDir(relDir)
+	view, end := p.instrument(Operation{Kind: OperationDecideInDir, Path: relDir, Entries: len(entries)})
+	if end == nil && p.onDecision == nil {
+		return view.decideInDir(relDir, entries)
+	}
+
+	results, err := view.decideInDir(relDir, entries)
+	if end != nil {
+		end(OperationEnd{Err: err})
+	}
+
+	if p.onDecision != nil && err == nil {
+		for i, entry := range entries {
+			p.onDecision(path.Join(relDir, entry.Name), entry.IsDir, results[i], nil)
+		}
+	}
+
+	return results, err
+}
+
+// decideInDir is DecideInDir without instrumentation and OnDecision.
+func (p *Provider) decideInDir(relDir string, entries []DirEntry) ([]MatchResult, error) {
+	if err := p.checkNames(relDir); err != nil {
+		return nil, err
+	}
+
+	normalizedDir, err := p.cleanRelDir(relDir)
 	if err != nil {
 		return nil, err
 	}
 
-	dirMatchers, err := p.prepareProviderDirMatchers(normalizedDir)
+	normalizedDir = p.scopedPath(normalizedDir)
+
+	dirMatchers, err := p.prepareProviderDirMatchers(normalizedDir, normalizedDir)
 	if err != nil {
 		return nil, err
 	}
 
+	p.cache.counters.decisions.Add(uint64(len(entries)))
 	results := make([]MatchResult, len(entries))
-	for i := range entries {
-		entryName, err := cleanEntryName(entries[i].Name)
+	if p.parentBlocks && normalizedDir != "" {
+		blocked, ok, err := p.excludedAncestor(normalizedDir)
 		if err != nil {
-			return nil, fmt.Errorf("entry %d (%q): %w", i, entries[i].Name, err)
+			return nil, err
 		}
 
-		fullPath := entryName
-		if normalizedDir != "" {
-			fullPath = normalizedDir + "/" + entryName
+		if ok {
+			return p.blockedEntries(blocked, entries, results)
 		}
+	}
 
-		res := MatchResult{
-			Included:  p.defaultIncluded,
-			Matched:   false,
-			RuleIndex: -1,
+	if p.parallelThreshold > 0 && len(entries) >= p.parallelThreshold {
+		if err := p.decideEntriesParallel(dirMatchers, normalizedDir, entries, results); err != nil {
+			return nil, err
 		}
 
-		if p.baseMatcher != nil {
-			baseRes := p.baseMatcher.Decide(fullPath, entries[i].IsDir)
-			if baseRes.Matched {
-				res = baseRes
-			}
-		}
+		return results, nil
+	}
 
-		p.applyPreparedDirMatchers(dirMatchers, fullPath, entries[i].IsDir, &res)
+	for i := range entries {
+		res, err := p.decideEntry(dirMatchers, normalizedDir, i, entries[i])
+		if err != nil {
+			return nil, err
+		}
 
 		results[i] = res
 	}
@@ -224,6 +733,87 @@ func (p *Provider) DecideInDir(relDir string, entries []DirEntry) ([]MatchResult
 	return results, nil
 }
 
+// decideEntriesParallel fills results for entries using GOMAXPROCS goroutines.
+//
+// The error of the lowest failing entry index is returned, as in serial evaluation.
+func (p *Provider) decideEntriesParallel(
+	dirMatchers []providerDirMatcher,
+	normalizedDir string,
+	entries []DirEntry,
+	results []MatchResult,
+) error {
+	workers := min(runtime.GOMAXPROCS(0), len(entries))
+	chunk := (len(entries) + workers - 1) / workers
+	errs := make([]error, workers)
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		start := w * chunk
+		end := min(start+chunk, len(entries))
+		wg.Go(func() {
+			for i := start; i < end; i++ {
+				res, err := p.decideEntry(dirMatchers, normalizedDir, i, entries[i])
+				if err != nil {
+					errs[w] = err
+					return
+				}
+
+				results[i] = res
+			}
+		})
+	}
+
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decideEntry returns decision for entry at index i of one directory.
+func (p *Provider) decideEntry(dirMatchers []providerDirMatcher, normalizedDir string, i int, entry DirEntry) (MatchResult, error) {
+	entryName, err := p.cleanEntryName(entry.Name)
+	if err == nil {
+		err = p.checkNames(entry.Name)
+	}
+
+	if err != nil {
+		return MatchResult{}, fmt.Errorf("entry %d (%q): %w", i, entry.Name, err)
+	}
+
+	fullPath := entryName
+	if normalizedDir != "" {
+		fullPath = normalizedDir + "/" + entryName
+	}
+
+	if resolved, err := p.resolveCandidate(fullPath); err != nil {
+		return MatchResult{}, fmt.Errorf("entry %d (%q): %w", i, entry.Name, err)
+	} else if resolved != fullPath {
+		// The prepared chain belongs to the unresolved directory.
+		return p.decideResolved(resolved, entry.IsDir)
+	}
+
+	res := MatchResult{
+		Included:  p.defaultIncluded,
+		Matched:   false,
+		RuleIndex: -1,
+	}
+
+	if p.baseMatcher != nil {
+		baseRes := p.baseMatcher.Decide(fullPath, entry.IsDir)
+		if baseRes.Matched {
+			res = baseRes
+		}
+	}
+
+	p.applyPreparedDirMatchers(dirMatchers, fullPath, entry.IsDir, &res)
+	p.applyFinalRules(fullPath, entry.IsDir, &res)
+	return res, nil
+}
+
 // Included reports whether path is included by provider decision.
 func (p *Provider) Included(relPath string, isDir bool) (bool, error) {
 	res, err := p.Decide(relPath, isDir)
@@ -274,13 +864,87 @@ func (p *Provider) ExcludedInDir(relDir string, entries []DirEntry) ([]bool, err
 	return excluded, nil
 }
 
+// Refresh drops cached directory matchers whose rules file appeared,
+// disappeared or changed size or modification time since it was loaded.
+//
+// Dropped directories reload lazily on the next decision. Refresh returns the
+// number of dropped entries; entries failing to stat are dropped too and
+// their errors are joined into the returned error. Root markers are checked
+// again too. Snapshot views are not refreshed; with ProviderOptions.RulesSource
+// every loaded directory is dropped.
+func (p *Provider) Refresh() (int, error) {
+	if p == nil {
+		return 0, ErrNilProvider
+	}
+
+	if p.frozen {
+		return 0, nil
+	}
+
+	p.cache.mu.Lock()
+	snapshot := make(map[string]*cachedDirMatcher, len(p.cache.entries))
+	for relDir, cached := range p.cache.entries {
+		if !cached.loading {
+			snapshot[relDir] = cached
+		}
+	}
+	p.cache.mu.Unlock()
+
+	var errs []error
+	stale := make(map[string]*cachedDirMatcher)
+	for relDir, cached := range snapshot {
+		// Rules sources have no change stamps; every directory reloads.
+		if p.source != nil {
+			stale[relDir] = cached
+			continue
+		}
+
+		stamp, err := p.statDirRulesFile(relDir)
+		if err != nil {
+			errs = append(errs, err)
+		} else if stamp.equal(cached.stamp) {
+			continue
+		}
+
+		stale[relDir] = cached
+	}
+
+	p.cache.mu.Lock()
+	clear(p.cache.markers)
+	var dropped []string
+	for relDir, cached := range stale {
+		// Skip entries already replaced by a concurrent reload.
+		if p.cache.entries[relDir] == cached {
+			delete(p.cache.entries, relDir)
+			dropped = append(dropped, relDir)
+		}
+	}
+	p.cache.mu.Unlock()
+
+	for _, relDir := range dropped {
+		p.logDebug("rules cache entry dropped", slog.String("dir", relDir))
+		if p.onCacheEvict != nil {
+			p.onCacheEvict(relDir)
+		}
+	}
+
+	return len(dropped), errors.Join(errs...)
+}
+
 // loadDirMatcher returns cached or newly loaded matcher for one relative directory.
 func (p *Provider) loadDirMatcher(relDir string) (*Matcher, error) {
-	p.mu.Lock()
-	cached, ok := p.cache[relDir]
+	if err := p.checkHierarchyDepth(relDir); err != nil {
+		err = p.loadError(relDir, err)
+		p.reportRuleFileError(relDir, err)
+		return nil, err
+	}
+
+	p.cache.mu.Lock()
+	cached, ok := p.cache.entries[relDir]
 	if ok {
 		loading := cached.loading
-		p.mu.Unlock()
+		p.cache.mu.Unlock()
+		p.cache.counters.cacheHits.Add(1)
 		if loading {
 			cached.wg.Wait()
 		}
@@ -292,36 +956,220 @@ func (p *Provider) loadDirMatcher(relDir string) (*Matcher, error) {
 		loading: true,
 	}
 	cached.wg.Add(1)
-	p.cache[relDir] = cached
-	p.mu.Unlock()
+	p.cache.entries[relDir] = cached
+	p.cache.mu.Unlock()
+	p.cache.counters.cacheMisses.Add(1)
+
+	_, end := p.instrument(Operation{Kind: OperationLoadRules, Path: relDir})
+	matcher, stamp, loadErr := p.loadAndCompileDirMatcher(relDir)
+	if loadErr != nil {
+		loadErr = p.loadError(relDir, loadErr)
+	}
+	if end != nil {
+		end(OperationEnd{Err: loadErr, Rules: loadedRules(matcher)})
+	}
 
-	matcher, loadErr := p.loadAndCompileDirMatcher(relDir)
+	p.reportRuleFileError(relDir, loadErr)
+	p.reportRulesLoaded(relDir, matcher)
+	p.logRulesLoad(relDir, matcher, loadErr)
+	p.reportRuleFileEvent(relDir, matcher, loadErr)
 
-	p.mu.Lock()
+	p.cache.mu.Lock()
 	cached.matcher = matcher
 	cached.err = loadErr
+	cached.stamp = stamp
 	cached.loading = false
 	cached.wg.Done()
-	p.mu.Unlock()
+	p.cache.mu.Unlock()
 
 	return matcher, loadErr
 }
 
+// loadError wraps a load failure of relDir into a *LoadError.
+func (p *Provider) loadError(relDir string, err error) error {
+	loadErr := &LoadError{Err: err, Dir: relDir}
+	if p.source == nil {
+		loadErr.Path = p.rulesFilePath(relDir)
+	}
+
+	return loadErr
+}
+
 // loadAndCompileDirMatcher loads and compiles one directory rules file.
-func (p *Provider) loadAndCompileDirMatcher(relDir string) (*Matcher, error) {
+func (p *Provider) loadAndCompileDirMatcher(relDir string) (*Matcher, rulesFileStamp, error) {
+	if p.source != nil {
+		return p.loadSourceDirMatcher(relDir)
+	}
+
+	file, err := p.readDirRulesFile(relDir)
+	if err != nil || !file.found {
+		return nil, file.rulesFileStamp, err
+	}
+
+	p.cache.counters.filesLoaded.Add(1)
+	key := sha256.Sum256(file.content)
+	if matcher := p.cache.sharedMatcher(key); matcher != nil {
+		p.cache.counters.sharedMatchers.Add(1)
+		return matcher, file.rulesFileStamp, nil
+	}
+
+	matcher, err := p.compileDirRules(file.content, file.path)
+	if err != nil {
+		p.cache.counters.parseErrors.Add(1)
+		return nil, file.rulesFileStamp, err
+	}
+
+	return p.cache.shareMatcher(key, matcher), file.rulesFileStamp, nil
+}
+
+// sharedMatcher returns a live matcher compiled from identical rules file content.
+func (c *dirMatcherCache) sharedMatcher(key [sha256.Size]byte) *Matcher {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.shared[key].Value()
+}
+
+// shareMatcher records matcher for content key and returns the matcher to use,
+// an existing one when identical content was compiled concurrently.
+//
+// Entries are weak and dropped once no directory uses the matcher.
+func (c *dirMatcherCache) shareMatcher(key [sha256.Size]byte, matcher *Matcher) *Matcher {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if existing := c.shared[key].Value(); existing != nil {
+		return existing
+	}
+
+	ptr := weak.Make(matcher)
+	c.shared[key] = ptr
+	runtime.AddCleanup(matcher, c.dropShared, sharedMatcherKey{hash: key, ptr: ptr})
+	return matcher
+}
+
+// sharedMatcherKey identifies one shared matcher entry for removal after collection.
+type sharedMatcherKey struct {
+	// ptr is the weak pointer stored for hash.
+	ptr weak.Pointer[Matcher]
+	// hash is rules file content hash.
+	hash [sha256.Size]byte
+}
+
+// dropShared removes a collected shared matcher entry unless it was replaced since.
+func (c *dirMatcherCache) dropShared(key sharedMatcherKey) {
+	c.mu.Lock()
+	if c.shared[key.hash] == key.ptr {
+		delete(c.shared, key.hash)
+	}
+	c.mu.Unlock()
+}
+
+// readDirRulesFile reads one directory rules file, found is false when it does not exist.
+func (p *Provider) readDirRulesFile(relDir string) (rulesFile, error) {
+	if p.fsys != nil {
+		rulesPath := path.Join(relDir, p.rulesFileName)
+		f, err := p.fsys.Open(rulesPath)
+		return readRulesFile(f, err, rulesPath, p.maxRulesFileSize)
+	}
+
+	if p.osRoot != nil {
+		return p.readRootRulesFile(relDir)
+	}
+
 	if !p.enableSymlinkEscapeCheck {
 		fullDir := filepath.Join(p.root, filepath.FromSlash(relDir))
 		rulesPath := filepath.Join(fullDir, p.rulesFileName)
-		content, err := os.ReadFile(rulesPath)
-		if err != nil {
-			if os.IsNotExist(err) {
-				return nil, nil
-			}
+		f, err := os.Open(rulesPath)
+		return readRulesFile(f, err, rulesPath, p.maxRulesFileSize)
+	}
+
+	rulesPath, found, err := p.resolveAndValidateRulesPath(relDir)
+	if err != nil || !found {
+		return rulesFile{}, err
+	}
+
+	f, err := os.Open(rulesPath)
+	return readRulesFile(f, err, rulesPath, p.maxRulesFileSize)
+}
+
+// statDirRulesFile returns current change-detection stamp of one directory rules file.
+func (p *Provider) statDirRulesFile(relDir string) (rulesFileStamp, error) {
+	var (
+		info fs.FileInfo
+		err  error
+	)
+
+	switch {
+	case p.fsys != nil:
+		info, err = fs.Stat(p.fsys, path.Join(relDir, p.rulesFileName))
+	case p.osRoot != nil:
+		info, err = p.statRootRulesFile(relDir)
+	default:
+		info, err = os.Stat(filepath.Join(p.root, filepath.FromSlash(relDir), p.rulesFileName))
+	}
+
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return rulesFileStamp{}, nil
+		}
+
+		return rulesFileStamp{}, err
+	}
+
+	return newRulesFileStamp(info), nil
+}
+
+// readRulesFile reads an opened rules file together with its stamp.
+//
+// Files larger than maxSize fail with ErrRulesFileTooLarge, 0 disables the limit.
+func readRulesFile(f fs.File, openErr error, rulesPath string, maxSize int64) (rulesFile, error) {
+	if openErr != nil {
+		if errors.Is(openErr, fs.ErrNotExist) {
+			return rulesFile{}, nil
+		}
+
+		return rulesFile{}, fmt.Errorf("read %s: %w", rulesPath, openErr)
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return rulesFile{}, fmt.Errorf("stat %s: %w", rulesPath, err)
+	}
+
+	content, err := readLimited(f, info.Size(), maxSize)
+	if err != nil {
+		return rulesFile{}, fmt.Errorf("read %s: %w", rulesPath, err)
+	}
 
-			return nil, fmt.Errorf("read %s: %w", rulesPath, err)
+	return rulesFile{
+		rulesFileStamp: newRulesFileStamp(info),
+		content:        content,
+		path:           rulesPath,
+	}, nil
+}
+
+// newRulesFileStamp builds change-detection stamp of an existing rules file.
+func newRulesFileStamp(info fs.FileInfo) rulesFileStamp {
+	return rulesFileStamp{
+		modTime: info.ModTime(),
+		size:    info.Size(),
+		found:   true,
+	}
+}
+
+// compileDirRules parses one rules file content and compiles it with header directives applied.
+func (p *Provider) compileDirRules(content []byte, rulesPath string) (*Matcher, error) {
+	switch p.rulesFormat {
+	case RulesFormatRsyncFilter:
+		return p.compileRsyncDirRules(content, rulesPath)
+	case RulesFormatHgignore:
+		rules, err := ParseHgignore(bytes.NewReader(content), rulesPath)
+		if err == nil {
+			err = p.checkRulesCount(len(rules))
 		}
 
-		rules, err := ParseRules(bytes.NewReader(content))
 		if err != nil {
 			return nil, fmt.Errorf("parse %s: %w", rulesPath, err)
 		}
@@ -334,26 +1182,54 @@ func (p *Provider) loadAndCompileDirMatcher(relDir string) (*Matcher, error) {
 		return matcher, nil
 	}
 
-	rulesPath, found, err := p.resolveAndValidateRulesPath(relDir)
+	rs, err := ParseRuleSet(bytes.NewReader(content), ParseOptions{
+		SourceName: rulesPath,
+		Sections:   len(p.sections) > 0,
+	})
+	if err == nil {
+		err = p.checkRulesCount(len(rs.Rules))
+	}
+
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("parse %s: %w", rulesPath, err)
 	}
 
-	if !found {
-		return nil, nil
+	if len(p.sections) > 0 {
+		rs.Rules = SelectSections(rs.Rules, p.sections...)
 	}
 
-	content, err := os.ReadFile(rulesPath)
+	matcher, err := rs.NewMatcher(p.matcherOptions)
 	if err != nil {
-		return nil, fmt.Errorf("read %s: %w", rulesPath, err)
+		return nil, fmt.Errorf("compile %s: %w", rulesPath, err)
+	}
+
+	// Directory default applies to its subtree when no rule matched at any level.
+	matcher.explicitDefault = rs.Directives.DefaultAction.valid()
+	return matcher, nil
+}
+
+// compileRsyncDirRules parses one rsync per-directory merge file.
+//
+// Nested "dir-merge" rules may only repeat the provider rules file name,
+// which is already inherited by subdirectories; their position is ignored
+// and subdirectory rules override the whole parent file.
+func (p *Provider) compileRsyncDirRules(content []byte, rulesPath string) (*Matcher, error) {
+	f, err := ParseRsyncFilter(bytes.NewReader(content), rulesPath)
+	if err == nil {
+		err = p.checkRulesCount(len(f.Rules))
 	}
 
-	rules, err := ParseRules(bytes.NewReader(content))
 	if err != nil {
 		return nil, fmt.Errorf("parse %s: %w", rulesPath, err)
 	}
 
-	matcher, err := NewMatcher(rules, p.matcherOptions)
+	for _, name := range f.DirMerge {
+		if name != p.rulesFileName {
+			return nil, fmt.Errorf("parse %s: %w: nested dir-merge %q differs from %q", rulesPath, ErrInvalidRule, name, p.rulesFileName)
+		}
+	}
+
+	matcher, err := NewMatcher(f.Rules, p.matcherOptions)
 	if err != nil {
 		return nil, fmt.Errorf("compile %s: %w", rulesPath, err)
 	}
@@ -375,7 +1251,7 @@ func (p *Provider) resolveAndValidateRulesPath(relDir string) (string, bool, err
 		return "", false, fmt.Errorf("stat %s: %w", rulesPath, err)
 	}
 
-	resolvedRulesPath, err := resolvePathOrAbs(rulesPath)
+	resolvedRulesPath, err := resolvePathOrAbs(rulesPath, p.followJunctions)
 	if err != nil {
 		return "", false, fmt.Errorf("resolve %s: %w", rulesPath, err)
 	}
@@ -387,62 +1263,86 @@ func (p *Provider) resolveAndValidateRulesPath(relDir string) (string, bool, err
 	return rulesPath, true, nil
 }
 
-// prepareProviderDirMatchers loads and prepares directory-level matchers for one directory.
-func (p *Provider) prepareProviderDirMatchers(relDir string) ([]providerDirMatcher, error) {
+// prepareProviderDirMatchers loads and prepares directory-level matchers for
+// one directory; the root marker search starts at markerDir, relDir or one
+// of its parents.
+func (p *Provider) prepareProviderDirMatchers(relDir string, markerDir string) ([]providerDirMatcher, error) {
 	matchers := make([]providerDirMatcher, 0, strings.Count(relDir, "/")+2)
 
-	if matcher, err := p.loadDirMatcher(""); err != nil {
-		return nil, err
-	} else if matcher != nil {
+	// add appends the matcher of rel and reports whether rel excludes its subtree.
+	add := func(rel string) (bool, error) {
+		if p.eval.skipsLevel(rel, relDir) {
+			return false, nil
+		}
+
+		matcher, err := p.dirMatcher(rel)
+		if errors.Is(err, errSubtreeExcluded) {
+			// Rules files below an excluded subtree are not loaded.
+			matchers = append(matchers, providerDirMatcher{prefix: rel, excluded: true})
+			return true, nil
+		}
+
+		if err != nil || matcher == nil {
+			return false, err
+		}
+
 		matchers = append(matchers, providerDirMatcher{
 			matcher: matcher,
-			prefix:  "",
+			prefix:  rel,
 		})
+		return false, nil
 	}
 
-	if relDir == "" {
-		return matchers, nil
+	start, err := p.rulesStart(markerDir)
+	if err != nil {
+		return nil, err
 	}
 
-	for i := 0; i < len(relDir); i++ {
-		if relDir[i] != '/' {
-			continue
+	if start == "" {
+		if !p.eval.skipsLevel("..", relDir) {
+			matchers = append(matchers, p.ancestors...)
+			if n := len(matchers); n > 0 && matchers[n-1].excluded {
+				return matchers, nil
+			}
 		}
 
-		rel := relDir[:i]
-		matcher, err := p.loadDirMatcher(rel)
-		if err != nil {
+		if excluded, err := add(""); err != nil {
 			return nil, err
+		} else if excluded || relDir == "" {
+			return matchers, nil
 		}
+	}
 
-		if matcher == nil {
+	for i := len(start); i < len(relDir); i++ {
+		if relDir[i] != '/' {
 			continue
 		}
 
-		matchers = append(matchers, providerDirMatcher{
-			matcher: matcher,
-			prefix:  rel,
-		})
+		if excluded, err := add(relDir[:i]); err != nil {
+			return nil, err
+		} else if excluded {
+			return matchers, nil
+		}
 	}
 
-	matcher, err := p.loadDirMatcher(relDir)
-	if err != nil {
+	if _, err := add(relDir); err != nil {
 		return nil, err
 	}
 
-	if matcher != nil {
-		matchers = append(matchers, providerDirMatcher{
-			matcher: matcher,
-			prefix:  relDir,
-		})
-	}
-
 	return matchers, nil
 }
 
 // applyDirMatcherDecision evaluates one directory-level matcher and updates final result.
 func (p *Provider) applyDirMatcherDecision(rel string, normalized string, isDir bool, res *MatchResult) error {
-	matcher, err := p.loadDirMatcher(rel)
+	if p.eval.skipsDir(rel, normalized) {
+		return nil
+	}
+
+	matcher, err := p.dirMatcher(rel)
+	if errors.Is(err, errSubtreeExcluded) {
+		p.origin.set(DecisionSourceRuleFileError, rel, nil, -1)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -451,30 +1351,22 @@ func (p *Provider) applyDirMatcherDecision(rel string, normalized string, isDir
 		return nil
 	}
 
-	candidate := normalized
-	if rel != "" {
-		// Rules from "dir/.pathrules" apply to paths under that directory, not to the
-		// directory path itself when it is being evaluated as a directory entry.
-		if normalized == rel {
-			return nil
-		}
-
-		prefix := rel + "/"
-		if !strings.HasPrefix(candidate, prefix) {
-			return nil
-		}
-
-		candidate = candidate[len(prefix):]
+	candidate, ok := dirCandidate(rel, normalized)
+	if !ok {
+		return nil
 	}
 
 	decision := matcher.Decide(candidate, isDir)
 	if !decision.Matched {
+		if matcher.explicitDefault && !res.Matched {
+			res.Included = decision.Included
+			p.origin.set(DecisionSourceDefault, rel, matcher, -1)
+		}
+
 		return nil
 	}
 
-	res.Included = decision.Included
-	res.Matched = true
-	res.RuleIndex = decision.RuleIndex
+	p.applyDirDecision(rel, normalized, matcher, decision, res)
 	return nil
 }
 
@@ -485,31 +1377,34 @@ func (p *Provider) applyPreparedDirMatchers(
 	isDir bool,
 	res *MatchResult,
 ) {
-	for i := range matchers {
-		candidate := normalized
-		if matchers[i].prefix != "" {
-			// Rules from "dir/.pathrules" apply to paths under that directory, not to the
-			// directory path itself when it is being evaluated as a directory entry.
-			if normalized == matchers[i].prefix {
-				continue
-			}
+	if p.dirPrecedence == DirPrecedenceNearest {
+		p.applyNearestDirMatchers(matchers, normalized, isDir, res)
+		return
+	}
 
-			prefix := matchers[i].prefix + "/"
-			if !strings.HasPrefix(candidate, prefix) {
-				continue
-			}
+	for i := range matchers {
+		candidate, ok := matchers[i].candidate(normalized)
+		if !ok || p.eval.skipsDir(matchers[i].prefix, normalized) {
+			continue
+		}
 
-			candidate = candidate[len(prefix):]
+		if matchers[i].excluded {
+			*res = MatchResult{RuleIndex: -1}
+			p.origin.set(DecisionSourceRuleFileError, matchers[i].prefix, nil, -1)
+			return
 		}
 
 		decision := matchers[i].matcher.Decide(candidate, isDir)
 		if !decision.Matched {
+			if matchers[i].matcher.explicitDefault && !res.Matched {
+				res.Included = decision.Included
+				p.origin.set(DecisionSourceDefault, matchers[i].prefix, matchers[i].matcher, -1)
+			}
+
 			continue
 		}
 
-		res.Included = decision.Included
-		res.Matched = true
-		res.RuleIndex = decision.RuleIndex
+		p.applyDirDecision(matchers[i].prefix, normalized, matchers[i].matcher, decision, res)
 	}
 }
 
@@ -546,23 +1441,35 @@ func cleanRulesFileName(raw string) (string, error) {
 }
 
 // cleanRelDir normalizes and validates provider-relative directory path.
-func cleanRelDir(raw string) (string, error) {
+func (p *Provider) cleanRelDir(raw string) (string, error) {
 	trimmed := strings.TrimSpace(raw)
 	if trimmed == "" || trimmed == "." {
 		return "", nil
 	}
 
+	if p.posixSeparators() {
+		return cleanPOSIXRelPath(trimmed)
+	}
+
 	return cleanRelPath(trimmed)
 }
 
 // cleanEntryName normalizes and validates one directory entry name.
-func cleanEntryName(raw string) (string, error) {
+func (p *Provider) cleanEntryName(raw string) (string, error) {
 	trimmed := strings.TrimSpace(raw)
 	if trimmed == "" {
 		return "", ErrInvalidEntryName
 	}
 
-	if filepath.IsAbs(trimmed) {
+	if p.posixSeparators() {
+		if strings.Contains(trimmed, "/") || trimmed == "." || trimmed == ".." {
+			return "", ErrInvalidEntryName
+		}
+
+		return trimmed, nil
+	}
+
+	if filepath.IsAbs(trimmed) || (p.windowsVolumes() && windowsVolumeLen(trimmed) > 0) {
 		return "", ErrInvalidEntryName
 	}
 
@@ -580,8 +1487,8 @@ func cleanEntryName(raw string) (string, error) {
 }
 
 // resolvePathOrAbs resolves symlinks/junctions and falls back to absolute path for non-link paths.
-func resolvePathOrAbs(path string) (string, error) {
-	resolved, err := filepath.EvalSymlinks(path)
+func resolvePathOrAbs(path string, followJunctions bool) (string, error) {
+	resolved, err := resolveLinks(path, followJunctions)
 	if err == nil {
 		return resolved, nil
 	}
@@ -659,6 +1566,48 @@ func cleanRelPath(raw string) (string, error) {
 	return path, nil
 }
 
+// checkNames applies RejectUnsafeNames checks to raw input path.
+func (p *Provider) checkNames(raw string) error {
+	if !p.rejectUnsafeNames {
+		return nil
+	}
+
+	return checkWindowsPath(raw)
+}
+
+// cleanInputPath normalizes and validates a path relative to the provider view.
+//
+// Absolute Windows paths (drive letters, UNC and "\\?\" long paths) are
+// accepted when they point inside the view and made relative to it. They are
+// recognized only when windowsVolumes is set, so "c:" stays a plain POSIX name.
+func (p *Provider) cleanInputPath(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if p.posixSeparators() {
+		return cleanPOSIXRelPath(trimmed)
+	}
+
+	if !p.windowsVolumes() || windowsVolumeLen(trimmed) == 0 {
+		return cleanRelPath(trimmed)
+	}
+
+	rel, ok := windowsRootRel(p.root, trimmed)
+	if !ok {
+		return "", ErrPathOutsideRoot
+	}
+
+	normalized, err := cleanRelPath(rel)
+	if err != nil || p.scope == "" {
+		return normalized, err
+	}
+
+	normalized, ok = strings.CutPrefix(normalized, p.scope+"/")
+	if !ok {
+		return "", ErrPathOutsideRoot
+	}
+
+	return normalized, nil
+}
+
 // pathDir returns slash-separated directory part for a relative path.
 func pathDir(relPath string, isDir bool) string {
 	if isDir {
@@ -671,3 +1620,20 @@ func pathDir(relPath string, isDir bool) string {
 
 	return ""
 }
+
+// equal reports whether two stamps describe the same rules file state.
+func (s rulesFileStamp) equal(other rulesFileStamp) bool {
+	return s.found == other.found && s.size == other.size && s.modTime.Equal(other.modTime)
+}
+
+// scopedPath joins normalized scope-relative path with provider scope.
+func (p *Provider) scopedPath(normalized string) string {
+	switch {
+	case p.scope == "":
+		return normalized
+	case normalized == "":
+		return p.scope
+	default:
+		return p.scope + "/" + normalized
+	}
+}
@@ -6,9 +6,12 @@ package pathrules
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+	"testing/fstest"
 )
 
 func TestProviderRecursiveOverrides(t *testing.T) {
@@ -130,6 +133,57 @@ func TestProviderCachesDirectoryMatchers(t *testing.T) {
 	}
 }
 
+func TestProviderRefreshReloadsChangedFiles(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	rulesPath := filepath.Join(root, ".rules")
+	writeRulesFile(t, rulesPath, "*.tmp\n")
+	if err := os.MkdirAll(filepath.Join(root, "sub"), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	p, err := NewProvider(root, ProviderOptions{RulesFileName: ".rules"})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if included, err := p.Included("sub/a.tmp", false); err != nil || included {
+		t.Fatalf("Included(sub/a.tmp)=%v err=%v, want excluded", included, err)
+	}
+
+	if dropped, err := p.Refresh(); err != nil || dropped != 0 {
+		t.Fatalf("Refresh unchanged: dropped=%d err=%v", dropped, err)
+	}
+
+	// Changed size, new file in a cached directory and removed file are all detected.
+	writeRulesFile(t, rulesPath, "*.log\n*.bak\n")
+	writeRulesFile(t, filepath.Join(root, "sub", ".rules"), "!*.log\n")
+	if dropped, err := p.Refresh(); err != nil || dropped != 2 {
+		t.Fatalf("Refresh changed: dropped=%d err=%v, want 2", dropped, err)
+	}
+
+	if included, err := p.Included("sub/a.tmp", false); err != nil || !included {
+		t.Fatalf("Included(sub/a.tmp)=%v err=%v, want included", included, err)
+	}
+
+	if included, err := p.Included("sub/a.log", false); err != nil || !included {
+		t.Fatalf("Included(sub/a.log)=%v err=%v, want included", included, err)
+	}
+
+	if err := os.Remove(rulesPath); err != nil {
+		t.Fatalf("Remove rules file: %v", err)
+	}
+
+	if dropped, err := p.Refresh(); err != nil || dropped != 1 {
+		t.Fatalf("Refresh removed: dropped=%d err=%v, want 1", dropped, err)
+	}
+
+	if included, err := p.Included("a.bak", false); err != nil || !included {
+		t.Fatalf("Included(a.bak)=%v err=%v, want included", included, err)
+	}
+}
+
 func TestProviderDecideInDir(t *testing.T) {
 	t.Parallel()
 
@@ -270,3 +324,277 @@ func writeRulesFile(t *testing.T, path string, content string) {
 		t.Fatalf("WriteFile(%s): %v", path, err)
 	}
 }
+
+func TestProviderHonorsDirectoryDirectives(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, ".rules"), "*.tmp\n")
+	writeRulesFile(t, filepath.Join(root, "assets", ".rules"), "#pragma case-insensitive default=exclude\n!*.PAA\n")
+
+	p, err := NewProvider(root, ProviderOptions{
+		RulesFileName: ".rules",
+		MatcherOptions: MatcherOptions{
+			DefaultAction: ActionInclude,
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	cases := map[string]bool{
+		"readme.md":         true,
+		"a.tmp":             false,
+		"assets/ui/a.paa":   true,
+		"assets/ui/a.txt":   false,
+		"assets/ui/a.tmp":   false,
+		"textures/file.txt": true,
+	}
+
+	for path, want := range cases {
+		got, err := p.Included(path, false)
+		if err != nil || got != want {
+			t.Fatalf("Included(%s)=%v err=%v, want %v", path, got, err, want)
+		}
+	}
+}
+
+func TestProviderFS(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":           {Data: []byte("*.tmp\n")},
+		"textures/.pathrules":  {Data: []byte("!*.tmp\n")},
+		"textures/a.tmp":       {Data: []byte("x")},
+		"broken/.pathrules":    {Data: []byte("#pragma default=bogus\n")},
+		"scripts/sub/main.cpp": {Data: []byte("x")},
+	}
+
+	p, err := NewProviderFS(fsys, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	if included, err := p.Included("a.tmp", false); err != nil || included {
+		t.Fatalf("Included(a.tmp)=%v err=%v, want excluded", included, err)
+	}
+
+	if included, err := p.Included("textures/a.tmp", false); err != nil || !included {
+		t.Fatalf("Included(textures/a.tmp)=%v err=%v, want included", included, err)
+	}
+
+	if included, err := p.Included("scripts/sub/main.cpp", false); err != nil || !included {
+		t.Fatalf("Included(scripts/sub/main.cpp)=%v err=%v, want included", included, err)
+	}
+
+	if _, err := p.Included("broken/a.txt", false); !errors.Is(err, ErrInvalidDirective) {
+		t.Fatalf("err=%v, want ErrInvalidDirective", err)
+	}
+
+	if _, err := NewProviderFS(nil, ProviderOptions{}); !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("err=%v, want ErrInvalidOptions", err)
+	}
+}
+
+func TestProviderScope(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, ".pathrules"), "*.tmp\n")
+	if err := os.MkdirAll(filepath.Join(root, "mods", "core", "data"), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	writeRulesFile(t, filepath.Join(root, "mods", "core", ".pathrules"), "!keep.tmp\n/data/\n")
+
+	p, err := NewProvider(root, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	scope, err := p.Scope("mods")
+	if err != nil {
+		t.Fatalf("Scope: %v", err)
+	}
+
+	core, err := scope.Scope("core")
+	if err != nil {
+		t.Fatalf("Scope(core): %v", err)
+	}
+
+	cases := map[string]bool{
+		"a.tmp":      false,
+		"keep.tmp":   true,
+		"data/x.bin": false,
+		"main.c":     true,
+	}
+
+	for path, want := range cases {
+		got, err := core.Included(path, false)
+		if err != nil {
+			t.Fatalf("Included(%q): %v", path, err)
+		}
+
+		parent, err := p.Included("mods/core/"+path, false)
+		if err != nil || got != want || parent != want {
+			t.Fatalf("Included(%q)=%v parent=%v err=%v, want %v", path, got, parent, err, want)
+		}
+	}
+
+	results, err := scope.DecideInDir("core", []DirEntry{{Name: "keep.tmp"}, {Name: "x.tmp"}})
+	if err != nil || !results[0].Included || results[1].Included {
+		t.Fatalf("DecideInDir=%+v err=%v", results, err)
+	}
+
+	p.cache.mu.Lock()
+	_, shared := p.cache.entries["mods/core"]
+	p.cache.mu.Unlock()
+	if !shared {
+		t.Fatalf("scoped provider must share parent cache")
+	}
+
+	if _, err := p.Scope("../x"); !errors.Is(err, ErrPathOutsideRoot) {
+		t.Fatalf("err=%v, want ErrPathOutsideRoot", err)
+	}
+}
+
+func TestProviderDecideInDirParallel(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":        {Data: []byte("*.tmp\n")},
+		"assets/.pathrules": {Data: []byte("!keep_*.tmp\n")},
+	}
+
+	serial, err := NewProviderFS(fsys, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	parallel, err := NewProviderFS(fsys, ProviderOptions{ParallelDecideThreshold: 8})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	entries := make([]DirEntry, 0, 1000)
+	for i := range 1000 {
+		name := fmt.Sprintf("file_%04d.tmp", i)
+		if i%3 == 0 {
+			name = fmt.Sprintf("keep_%04d.tmp", i)
+		}
+
+		entries = append(entries, DirEntry{Name: name, IsDir: i%7 == 0})
+	}
+
+	want, err := serial.DecideInDir("assets", entries)
+	if err != nil {
+		t.Fatalf("serial DecideInDir: %v", err)
+	}
+
+	got, err := parallel.DecideInDir("assets", entries)
+	if err != nil {
+		t.Fatalf("parallel DecideInDir: %v", err)
+	}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("parallel results differ from serial")
+	}
+
+	entries[900].Name = "a/b"
+	entries[300].Name = ".."
+	_, wantErr := serial.DecideInDir("assets", entries)
+	_, gotErr := parallel.DecideInDir("assets", entries)
+	if wantErr == nil || gotErr == nil || gotErr.Error() != wantErr.Error() {
+		t.Fatalf("parallel err=%v, want %v", gotErr, wantErr)
+	}
+}
+
+func TestProviderSharesIdenticalRulesFiles(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"a/.pathrules": {Data: []byte("/local.tmp\n")},
+		"b/.pathrules": {Data: []byte("/local.tmp\n")},
+		"c/.pathrules": {Data: []byte("/other.tmp\n")},
+	}
+
+	p, err := NewProviderFS(fsys, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	for _, dir := range []string{"a", "b", "c"} {
+		if ok, err := p.Included(dir+"/local.tmp", false); err != nil || ok != (dir == "c") {
+			t.Fatalf("Included(%s/local.tmp)=%v err=%v", dir, ok, err)
+		}
+	}
+
+	a, _ := p.loadDirMatcher("a")
+	b, _ := p.loadDirMatcher("b")
+	c, _ := p.loadDirMatcher("c")
+	if a == nil || a != b || a == c {
+		t.Fatalf("identical rules files not shared: a=%p b=%p c=%p", a, b, c)
+	}
+
+	if got := p.Stats().SharedMatchers; got != 1 {
+		t.Fatalf("SharedMatchers=%d, want 1", got)
+	}
+}
+
+func TestProviderResultRule(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"a/.pathrules": {Data: []byte("*.log\n")},
+		"b/.pathrules": {Data: []byte("*.log\n")},
+	}
+
+	p, err := NewProviderFS(fsys, ProviderOptions{MatcherOptions: MatcherOptions{ResultRule: true}})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	for _, dir := range []string{"a", "b"} {
+		res, err := p.Decide(dir+"/x.log", false)
+		if err != nil {
+			t.Fatalf("Decide: %v", err)
+		}
+
+		if res.Rule == nil || res.Rule.Pattern != "*.log" || res.Rule.Source != dir+"/.pathrules" {
+			t.Fatalf("%s: Rule=%+v, want *.log from %s/.pathrules", dir, res.Rule, dir)
+		}
+	}
+}
+
+func TestProviderDecideDirLoadsOwnRulesFile(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"broken/.pathrules": {Data: []byte("#pragma default=bogus\n")},
+	}
+
+	p, err := NewProviderFS(fsys, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	if _, err := p.Decide("broken", true); !errors.Is(err, ErrInvalidDirective) {
+		t.Fatalf("Decide(broken)=%v, want ErrInvalidDirective", err)
+	}
+
+	var reported []string
+	p, err = NewProviderFS(fsys, ProviderOptions{
+		OnRuleFileError:      RuleFileErrorExcludeSubtree,
+		RuleFileErrorHandler: func(relDir string, _ error) { reported = append(reported, relDir) },
+	})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	if res, err := p.Decide("broken", true); err != nil || res.Included {
+		t.Fatalf("Decide(broken)=%+v err=%v, want excluded subtree", res, err)
+	}
+
+	if !slices.Equal(reported, []string{"broken"}) {
+		t.Fatalf("reported=%q, want [broken]", reported)
+	}
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"io/fs"
+)
+
+// DirVerdict classifies all paths below a directory.
+type DirVerdict uint8
+
+const (
+	// DirMixed means paths below need per-path decisions.
+	DirMixed DirVerdict = iota
+	// DirExcluded means every path below is excluded.
+	DirExcluded
+	// DirIncluded means every path below is included.
+	DirIncluded
+)
+
+// PrunePlan lists directories of a tree by DirVerdict. Directories below an
+// excluded or included one are not listed; their verdict is inherited.
+type PrunePlan struct {
+	// Excluded are directories whose subtree can be skipped.
+	Excluded []string `json:"excluded,omitempty" yaml:"excluded,omitempty"`
+	// Included are directories whose subtree can be copied whole.
+	Included []string `json:"included,omitempty" yaml:"included,omitempty"`
+	// Mixed are directories whose direct files need per-path decisions.
+	Mixed []string `json:"mixed,omitempty" yaml:"mixed,omitempty"`
+}
+
+// String returns verdict name.
+func (v DirVerdict) String() string {
+	switch v {
+	case DirMixed:
+		return "mixed"
+	case DirExcluded:
+		return "excluded"
+	case DirIncluded:
+		return "included"
+	default:
+		return fmt.Sprintf("dir-verdict(%d)", uint8(v))
+	}
+}
+
+// ClassifyDir returns the verdict for all paths below dirPath ("" for the
+// whole tree); the directory itself is not covered.
+//
+// The analysis is conservative: DirExcluded and DirIncluded are exact
+// guarantees, DirMixed may be returned for subtrees that turn out uniform.
+// Custom action rules and MaxPathLength always make subtrees they may
+// affect mixed.
+func (m *Matcher) ClassifyDir(dirPath string) DirVerdict {
+	if !m.ShouldDescend(dirPath) {
+		return DirExcluded
+	}
+
+	candidate := m.normalizeCandidate(dirPath)
+	if candidate == "." {
+		candidate = ""
+	}
+
+	if m.maxPathLength > 0 {
+		return DirMixed
+	}
+
+	cover := m.descendCover(candidate)
+	switch {
+	case cover < 0 && m.defaultAction == ActionInclude && !m.excludesBelow(candidate, -1):
+		return DirIncluded
+	case cover >= 0 && m.compiled[cover].source.Action == ActionInclude && !m.excludesBelow(candidate, cover):
+		return DirIncluded
+	}
+
+	return DirMixed
+}
+
+// PrunePlan walks the directories of fsys and classifies them with
+// ClassifyDir, reading only mixed ones. The root is listed as "".
+func (m *Matcher) PrunePlan(fsys fs.FS) (PrunePlan, error) {
+	var plan PrunePlan
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		dir := name
+		if dir == "." {
+			dir = ""
+		}
+
+		switch m.ClassifyDir(dir) {
+		case DirExcluded:
+			plan.Excluded = append(plan.Excluded, dir)
+			return fs.SkipDir
+		case DirIncluded:
+			plan.Included = append(plan.Included, dir)
+			return fs.SkipDir
+		}
+
+		plan.Mixed = append(plan.Mixed, dir)
+		return nil
+	})
+	if err != nil {
+		return PrunePlan{}, fmt.Errorf("prune plan: %w", err)
+	}
+
+	return plan, nil
+}
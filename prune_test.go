@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMatcherClassifyDir(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher(MustParseRulesString("build/\n!build/keep.txt\n/cache/**\n*.tmp\n/vendor/**\n!/vendor/**\n"), MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	for dir, want := range map[string]DirVerdict{
+		"":       DirMixed,
+		"src":    DirMixed,
+		"build":  DirMixed,
+		"cache":  DirExcluded,
+		"vendor": DirIncluded,
+	} {
+		if got := m.ClassifyDir(dir); got != want {
+			t.Fatalf("ClassifyDir(%q)=%s, want %s", dir, got, want)
+		}
+	}
+
+	allow, err := NewMatcher(MustParseRulesString("*\n!/docs/**\n"), MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	for dir, want := range map[string]DirVerdict{"docs": DirIncluded, "docs/api": DirIncluded, "src": DirExcluded} {
+		if got := allow.ClassifyDir(dir); got != want {
+			t.Fatalf("allowlist ClassifyDir(%q)=%s, want %s", dir, got, want)
+		}
+	}
+}
+
+func TestMatcherPrunePlan(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"src/main.go":           {},
+		"src/gen/a.tmp":         {},
+		"cache/x/y.bin":         {},
+		"vendor/lib/lib.go":     {},
+		"vendor/lib/sub/old.go": {},
+	}
+
+	m, err := NewMatcher(MustParseRulesString("/cache/**\n*.tmp\n/vendor/**\n!/vendor/**\n"), MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	plan, err := m.PrunePlan(fsys)
+	if err != nil {
+		t.Fatalf("PrunePlan: %v", err)
+	}
+
+	if want := []string{"cache"}; !slices.Equal(plan.Excluded, want) {
+		t.Fatalf("Excluded=%q, want %q", plan.Excluded, want)
+	}
+
+	if want := []string{"vendor"}; !slices.Equal(plan.Included, want) {
+		t.Fatalf("Included=%q, want %q", plan.Included, want)
+	}
+
+	if want := []string{"", "src", "src/gen"}; !slices.Equal(plan.Mixed, want) {
+		t.Fatalf("Mixed=%q, want %q", plan.Mixed, want)
+	}
+}
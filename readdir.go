@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "io/fs"
+
+// NewDirEntries converts fs.DirEntry values, as returned by os.ReadDir and
+// fs.ReadDir, to batch API entries. Symlinks to directories count as files,
+// as their DirEntry reports.
+func NewDirEntries(entries []fs.DirEntry) []DirEntry {
+	out := make([]DirEntry, len(entries))
+	for i, entry := range entries {
+		out[i] = DirEntry{Name: entry.Name(), IsDir: entry.IsDir()}
+	}
+
+	return out
+}
+
+// DecideReadDir is DecideInDir for entries listed by os.ReadDir or fs.ReadDir.
+func (p *Provider) DecideReadDir(relDir string, entries []fs.DirEntry) ([]MatchResult, error) {
+	return p.DecideInDir(relDir, NewDirEntries(entries))
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestDecideReadDir(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, "src", ".pathrules"), "*.log\nbuild/\n")
+	writeRulesFile(t, filepath.Join(root, "src", "a.go"), "")
+	writeRulesFile(t, filepath.Join(root, "src", "x.log"), "")
+	if err := os.Mkdir(filepath.Join(root, "src", "build"), 0o750); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, "src"))
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+
+	dirEntries := NewDirEntries(entries)
+	want := []DirEntry{{Name: ".pathrules"}, {Name: "a.go"}, {Name: "build", IsDir: true}, {Name: "x.log"}}
+	if !slices.Equal(dirEntries, want) {
+		t.Fatalf("NewDirEntries=%v, want %v", dirEntries, want)
+	}
+
+	p, err := NewProvider(root, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	results, err := p.DecideReadDir("src", entries)
+	if err != nil {
+		t.Fatalf("DecideReadDir: %v", err)
+	}
+
+	var included []bool
+	for _, res := range results {
+		included = append(included, res.Included)
+	}
+
+	if want := []bool{true, true, false, false}; !slices.Equal(included, want) {
+		t.Fatalf("included=%v, want %v", included, want)
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"regexp"
+	"runtime"
+	"sync"
+	"weak"
+)
+
+// sharedRegexps interns compiled regexps by source across all matchers.
+//
+// Entries are weak: a regexp no longer used by any matcher is collected and
+// its entry removed, so the cache never outgrows live rule sets.
+var sharedRegexps = regexpCache{entries: make(map[string]weak.Pointer[regexp.Regexp])}
+
+// regexpCache maps regexp source to a weakly held compiled regexp.
+type regexpCache struct {
+	// entries maps regexp source to compiled regexp.
+	entries map[string]weak.Pointer[regexp.Regexp]
+	// mu guards entries.
+	mu sync.Mutex
+}
+
+// compileRegexp returns a shared compiled regexp for expr.
+//
+// Compiled regexps are safe for concurrent use, so identical patterns in
+// many rules and rules files share one instance.
+func compileRegexp(expr string) (*regexp.Regexp, error) {
+	return sharedRegexps.compile(expr)
+}
+
+// compile returns cached regexp for expr or compiles and caches a new one.
+func (c *regexpCache) compile(expr string) (*regexp.Regexp, error) {
+	c.mu.Lock()
+	if re := c.entries[expr].Value(); re != nil {
+		c.mu.Unlock()
+		return re, nil
+	}
+	c.mu.Unlock()
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Another goroutine may have compiled the same source meanwhile.
+	if existing := c.entries[expr].Value(); existing != nil {
+		return existing, nil
+	}
+
+	ptr := weak.Make(re)
+	c.entries[expr] = ptr
+	runtime.AddCleanup(re, c.remove, regexpCacheKey{expr: expr, ptr: ptr})
+	return re, nil
+}
+
+// regexpCacheKey identifies one cache entry for removal after collection.
+type regexpCacheKey struct {
+	// expr is regexp source.
+	expr string
+	// ptr is the weak pointer stored for expr.
+	ptr weak.Pointer[regexp.Regexp]
+}
+
+// remove drops a collected entry unless expr was cached again since.
+func (c *regexpCache) remove(key regexpCacheKey) {
+	c.mu.Lock()
+	if c.entries[key.expr] == key.ptr {
+		delete(c.entries, key.expr)
+	}
+	c.mu.Unlock()
+}
+
+// len returns number of cache entries, including collected ones not yet removed.
+func (c *regexpCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"regexp"
+	"runtime"
+	"testing"
+	"time"
+	"weak"
+)
+
+func TestRegexpCacheSharesCompiled(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{{Action: ActionExclude, Pattern: "data/file_[0-9][0-9].bin"}}
+	a, err := NewMatcher(rules, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	b, err := NewMatcher(rules, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if a.compiled[0].pathRE == nil || a.compiled[0].pathRE != b.compiled[0].pathRE {
+		t.Fatalf("identical patterns compiled to different regexps")
+	}
+}
+
+func TestRegexpCacheDropsCollected(t *testing.T) {
+	t.Parallel()
+
+	cache := regexpCache{entries: make(map[string]weak.Pointer[regexp.Regexp])}
+	func() {
+		re, err := cache.compile(`^unique-[a-z]+$`)
+		if err != nil {
+			t.Fatalf("compile: %v", err)
+		}
+
+		if again, _ := cache.compile(`^unique-[a-z]+$`); again != re {
+			t.Fatalf("cached regexp not reused")
+		}
+	}()
+
+	if _, err := cache.compile(`(`); err == nil {
+		t.Fatalf("invalid regexp compiled")
+	}
+
+	for range 50 {
+		runtime.GC()
+		if cache.len() == 0 {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("collected regexp entry not removed, %d entries left", cache.len())
+}
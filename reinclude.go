@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"fmt"
+)
+
+// excludedAncestor returns the decision of the outermost directory excluded
+// by a matching rule among relDir and its ancestors, and reports whether one
+// was found. As in git, directories excluded only by the default action do
+// not block re-inclusion.
+func (p *Provider) excludedAncestor(relDir string) (MatchResult, bool, error) {
+	if relDir == "" {
+		return MatchResult{}, false, nil
+	}
+
+	for i := 0; i <= len(relDir); i++ {
+		if i < len(relDir) && relDir[i] != '/' {
+			continue
+		}
+
+		res, err := p.decideChain(relDir[:i], true)
+		if errors.Is(err, errSubtreeExcluded) {
+			return MatchResult{RuleIndex: -1}, true, nil
+		}
+
+		if err != nil {
+			return MatchResult{}, false, err
+		}
+
+		if res.Matched && !res.Included {
+			return res, true, nil
+		}
+	}
+
+	return MatchResult{}, false, nil
+}
+
+// blockedEntries fills results with the excluded ancestor decision after
+// validating entry names as DecideInDir does.
+func (p *Provider) blockedEntries(blocked MatchResult, entries []DirEntry, results []MatchResult) ([]MatchResult, error) {
+	for i, entry := range entries {
+		_, err := p.cleanEntryName(entry.Name)
+		if err == nil {
+			err = p.checkNames(entry.Name)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("entry %d (%q): %w", i, entry.Name, err)
+		}
+
+		results[i] = blocked
+	}
+
+	return results, nil
+}
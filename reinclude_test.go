@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestProviderParentExclusionBlocksReinclude(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":       {Data: []byte("build/\n!build/keep.txt\n*.tmp\n")},
+		"src/.pathrules":   {Data: []byte("!keep.tmp\n")},
+		"build/.pathrules": {Data: []byte("!*.bin\n")},
+	}
+
+	loose, err := NewProviderFS(fsys, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	strict, err := NewProviderFS(fsys, ProviderOptions{ParentExclusionBlocksReinclude: true})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	cases := []struct {
+		path   string
+		isDir  bool
+		loose  bool
+		strict bool
+	}{
+		{path: "build", isDir: true, loose: false, strict: false},
+		{path: "build/keep.txt", loose: true, strict: false},
+		{path: "build/out/a.bin", loose: true, strict: false},
+		{path: "src/keep.tmp", loose: true, strict: true},
+		{path: "src/a.tmp", loose: false, strict: false},
+	}
+
+	for _, tc := range cases {
+		if got, err := loose.Included(tc.path, tc.isDir); err != nil || got != tc.loose {
+			t.Fatalf("loose Included(%s)=%v err=%v, want %v", tc.path, got, err, tc.loose)
+		}
+
+		if got, err := strict.Included(tc.path, tc.isDir); err != nil || got != tc.strict {
+			t.Fatalf("strict Included(%s)=%v err=%v, want %v", tc.path, got, err, tc.strict)
+		}
+	}
+
+	res, err := strict.Decide("build/keep.txt", false)
+	if err != nil || res.RuleIndex != 0 || !res.Matched {
+		t.Fatalf("Decide(build/keep.txt)=%+v err=%v, want ancestor rule 0", res, err)
+	}
+
+	batch, err := strict.IncludedInDir("build/out", []DirEntry{{Name: "a.bin"}, {Name: "b.txt"}})
+	if err != nil || batch[0] || batch[1] {
+		t.Fatalf("IncludedInDir(build/out)=%v err=%v, want all excluded", batch, err)
+	}
+
+	if _, err := strict.DecideInDir("build", []DirEntry{{Name: "a/b"}}); err == nil {
+		t.Fatalf("DecideInDir with invalid entry name must fail under excluded parent")
+	}
+}
+
+func TestProviderParentExclusionAllowList(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewProviderFS(fstest.MapFS{
+		".pathrules": {Data: []byte("!*.go\n")},
+	}, ProviderOptions{
+		MatcherOptions:                 MatcherOptions{DefaultAction: ActionExclude},
+		ParentExclusionBlocksReinclude: true,
+	})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	res, err := p.Decide("src/main.go", false)
+	if err != nil || !res.Included || !res.Matched {
+		t.Fatalf("Decide(src/main.go)=%+v err=%v, want included by !*.go", res, err)
+	}
+
+	if included, err := p.Included("src/main.txt", false); err != nil || included {
+		t.Fatalf("Included(src/main.txt)=%v err=%v, want excluded by default", included, err)
+	}
+}
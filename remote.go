@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// defaultRemoteMaxSize limits remote rules bodies when RemoteRulesOptions.MaxSize is 0.
+const defaultRemoteMaxSize = 1 << 20
+
+// RemoteRulesOptions configures RemoteRules.
+type RemoteRulesOptions struct {
+	// Client sends requests, http.DefaultClient when nil.
+	Client *http.Client `json:"-" yaml:"-"`
+	// FallbackPath is a local copy of the last fetched rules: written after
+	// every successful fetch and read when a fetch fails. Empty disables it.
+	FallbackPath string `json:"fallback_path,omitempty" yaml:"fallback_path,omitempty"`
+	// MaxSize limits the response body in bytes, 1 MiB when zero.
+	MaxSize int64 `json:"max_size,omitempty" yaml:"max_size,omitempty"`
+	// AllowInsecure permits plain "http" URLs.
+	AllowInsecure bool `json:"allow_insecure,omitempty" yaml:"allow_insecure,omitempty"`
+}
+
+// RemoteRules fetches a rules file in pathrules syntax over HTTPS, revalidating
+// with ETag and If-Modified-Since. It is safe for concurrent use.
+type RemoteRules struct {
+	// client sends requests.
+	client *http.Client
+	// url is the rules file URL.
+	url string
+	// etag is the ETag of the cached response.
+	etag string
+	// lastModified is the Last-Modified of the cached response.
+	lastModified string
+	// fallbackPath is the local copy path, empty when disabled.
+	fallbackPath string
+	// rules are the last fetched rules, nil before the first success.
+	rules []Rule
+	// maxSize limits response bodies.
+	maxSize int64
+	// mu serializes fetches and guards cached state.
+	mu sync.Mutex
+}
+
+// NewRemoteRules returns a loader for rawURL; the URL must use https
+// unless AllowInsecure is set.
+func NewRemoteRules(rawURL string, opts RemoteRulesOptions) (*RemoteRules, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: remote rules url: %v", ErrInvalidOptions, err)
+	}
+
+	if u.Scheme != "https" && (u.Scheme != "http" || !opts.AllowInsecure) {
+		return nil, fmt.Errorf("%w: remote rules url scheme %q", ErrInvalidOptions, u.Scheme)
+	}
+
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	maxSize := opts.MaxSize
+	if maxSize <= 0 {
+		maxSize = defaultRemoteMaxSize
+	}
+
+	return &RemoteRules{
+		client:       client,
+		url:          rawURL,
+		fallbackPath: opts.FallbackPath,
+		maxSize:      maxSize,
+	}, nil
+}
+
+// Fetch returns current remote rules, reusing the cached copy when the
+// server answers 304 Not Modified.
+//
+// When the request fails, Fetch returns the last fetched rules, or rules
+// from FallbackPath, together with an error matching ErrRemoteRulesStale
+// and the cause; without either copy it returns only the error. Fresh
+// rules are returned together with a failure to write FallbackPath.
+func (r *RemoteRules) Fetch(ctx context.Context) ([]Rule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rules, err := r.fetch(ctx)
+	if rules != nil {
+		return slices.Clone(rules), err
+	}
+
+	if r.rules != nil {
+		return slices.Clone(r.rules), fmt.Errorf("%w: %w", ErrRemoteRulesStale, err)
+	}
+
+	if r.fallbackPath != "" {
+		if fallback, fallbackErr := LoadRulesFile(r.fallbackPath); fallbackErr == nil {
+			return fallback, fmt.Errorf("%w: %w", ErrRemoteRulesStale, err)
+		}
+	}
+
+	return nil, err
+}
+
+// fetch performs one conditional request and updates cached state; rules
+// are nil when the request failed.
+func (r *RemoteRules) fetch(ctx context.Context) ([]Rule, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", r.url, err)
+	}
+
+	if r.rules != nil {
+		if r.etag != "" {
+			req.Header.Set("If-None-Match", r.etag)
+		}
+
+		if r.lastModified != "" {
+			req.Header.Set("If-Modified-Since", r.lastModified)
+		}
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", r.url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && r.rules != nil:
+		return r.rules, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("fetch %s: unexpected status %s", r.url, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, r.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", r.url, err)
+	}
+
+	if int64(len(body)) > r.maxSize {
+		return nil, fmt.Errorf("fetch %s: %w: more than %d bytes", r.url, ErrRulesFileTooLarge, r.maxSize)
+	}
+
+	rules, err := ParseRulesNamed(bytes.NewReader(body), r.url)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", r.url, err)
+	}
+
+	r.rules = rules
+	r.etag = resp.Header.Get("ETag")
+	r.lastModified = resp.Header.Get("Last-Modified")
+	return rules, r.writeFallback(body)
+}
+
+// writeFallback atomically replaces the local copy with body.
+func (r *RemoteRules) writeFallback(body []byte) error {
+	if r.fallbackPath == "" {
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.fallbackPath), ".pathrules-remote-*")
+	if err != nil {
+		return fmt.Errorf("write fallback: %w", err)
+	}
+
+	_, err = tmp.Write(body)
+	err = errors.Join(err, tmp.Close())
+	if err == nil {
+		err = os.Rename(tmp.Name(), r.fallbackPath)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write fallback: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRemoteRules(t *testing.T) {
+	t.Parallel()
+
+	var (
+		requests    atomic.Int32
+		revalidated atomic.Int32
+		down        atomic.Bool
+	)
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if down.Load() {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidated.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte("*.tmp\n!keep.tmp\n"))
+	}))
+	defer srv.Close()
+
+	fallback := filepath.Join(t.TempDir(), "remote.rules")
+	r, err := NewRemoteRules(srv.URL, RemoteRulesOptions{Client: srv.Client(), FallbackPath: fallback})
+	if err != nil {
+		t.Fatalf("NewRemoteRules: %v", err)
+	}
+
+	ctx := context.Background()
+	for range 2 {
+		rules, err := r.Fetch(ctx)
+		if err != nil || len(rules) != 2 || rules[1].Action != ActionInclude {
+			t.Fatalf("Fetch=%+v err=%v, want 2 rules", rules, err)
+		}
+	}
+
+	if requests.Load() != 2 || revalidated.Load() != 1 {
+		t.Fatalf("requests=%d revalidated=%d, want 2 and 1", requests.Load(), revalidated.Load())
+	}
+
+	down.Store(true)
+	if rules, err := r.Fetch(ctx); !errors.Is(err, ErrRemoteRulesStale) || len(rules) != 2 {
+		t.Fatalf("Fetch while down=%+v err=%v, want cached rules and ErrRemoteRulesStale", rules, err)
+	}
+
+	cold, err := NewRemoteRules(srv.URL, RemoteRulesOptions{Client: srv.Client(), FallbackPath: fallback})
+	if err != nil {
+		t.Fatalf("NewRemoteRules: %v", err)
+	}
+
+	if rules, err := cold.Fetch(ctx); !errors.Is(err, ErrRemoteRulesStale) || len(rules) != 2 || rules[0].Source != fallback {
+		t.Fatalf("cold Fetch=%+v err=%v, want fallback rules", rules, err)
+	}
+
+	if _, err := NewRemoteRules("http://example.com/rules", RemoteRulesOptions{}); !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("err=%v, want ErrInvalidOptions for plain http", err)
+	}
+}
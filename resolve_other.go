@@ -0,0 +1,14 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+//go:build !windows
+
+package pathrules
+
+import "path/filepath"
+
+// resolveLinks resolves symlinks in path; followJunctions matters on Windows only.
+func resolveLinks(path string, _ bool) (string, error) {
+	return filepath.EvalSymlinks(path)
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+//go:build windows
+
+package pathrules
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+// GetFinalPathNameByHandleW flags.
+const (
+	volumeNameDOS  = 0x0
+	volumeNameGUID = 0x1
+)
+
+// procGetFinalPathNameByHandleW resolves an open handle to its final path.
+var procGetFinalPathNameByHandleW = syscall.NewLazyDLL("kernel32.dll").NewProc("GetFinalPathNameByHandleW")
+
+// resolveLinks resolves symlinks in path, and with followJunctions also NTFS
+// junctions, volume mount points and substituted drives.
+//
+// filepath.EvalSymlinks does not follow junctions and mount points, so the
+// final path is asked from the file system instead.
+func resolveLinks(path string, followJunctions bool) (string, error) {
+	if !followJunctions {
+		return filepath.EvalSymlinks(path)
+	}
+
+	name, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return "", &os.PathError{Op: "resolve", Path: path, Err: err}
+	}
+
+	// Backup semantics allows opening directories; zero access only queries metadata.
+	h, err := syscall.CreateFile(name, 0,
+		syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE|syscall.FILE_SHARE_DELETE,
+		nil, syscall.OPEN_EXISTING, syscall.FILE_FLAG_BACKUP_SEMANTICS, 0)
+	if err != nil {
+		return "", &os.PathError{Op: "open", Path: path, Err: err}
+	}
+	defer func() { _ = syscall.CloseHandle(h) }()
+
+	// Volumes mounted without a drive letter have no DOS name; their GUID
+	// path never lies under a drive letter root, so escapes are still caught.
+	final, err := finalPathName(h, volumeNameDOS)
+	if err != nil {
+		final, err = finalPathName(h, volumeNameGUID)
+	}
+
+	if err != nil {
+		return "", &os.PathError{Op: "GetFinalPathNameByHandle", Path: path, Err: err}
+	}
+
+	return canonicalWindowsPath(final), nil
+}
+
+// finalPathName returns the final path of an open handle with volume name flags.
+func finalPathName(h syscall.Handle, flags uint32) (string, error) {
+	buf := make([]uint16, syscall.MAX_PATH)
+	for {
+		n, _, err := procGetFinalPathNameByHandleW.Call(
+			uintptr(h), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)), uintptr(flags))
+		if n == 0 {
+			return "", err
+		}
+
+		if int(n) < len(buf) {
+			return syscall.UTF16ToString(buf[:n]), nil
+		}
+
+		buf = make([]uint16, n)
+	}
+}
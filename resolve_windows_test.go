@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+//go:build windows
+
+package pathrules
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestProviderJunctionEscape(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	outside := t.TempDir()
+	writeRulesFile(t, filepath.Join(outside, ".rules"), "*.tmp\n")
+	writeRulesFile(t, filepath.Join(root, "inner", ".rules"), "*.log\n")
+
+	mkjunction := func(link, target string) {
+		t.Helper()
+		if out, err := exec.Command("cmd", "/c", "mklink", "/J", link, target).CombinedOutput(); err != nil {
+			t.Skipf("mklink /J not available: %v: %s", err, out)
+		}
+	}
+
+	mkjunction(filepath.Join(root, "escaped"), outside)
+	mkjunction(filepath.Join(root, "aliased"), filepath.Join(root, "inner"))
+
+	strict, err := NewProvider(root, ProviderOptions{RulesFileName: ".rules", EnableSymlinkEscapeCheck: true})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if _, err := strict.Decide("escaped/file.tmp", false); !errors.Is(err, ErrRulesPathOutsideRoot) {
+		t.Fatalf("Decide err=%v, want ErrRulesPathOutsideRoot through junction", err)
+	}
+
+	if included, err := strict.Included("aliased/file.log", false); err != nil || included {
+		t.Fatalf("Included(aliased/file.log)=%v err=%v, want excluded by junction inside root", included, err)
+	}
+
+	trusting, err := NewProvider(root, ProviderOptions{
+		RulesFileName:            ".rules",
+		EnableSymlinkEscapeCheck: true,
+		TrustJunctions:           true,
+	})
+	if err != nil {
+		t.Fatalf("NewProvider(TrustJunctions): %v", err)
+	}
+
+	if included, err := trusting.Included("escaped/file.tmp", false); err != nil || included {
+		t.Fatalf("Included(escaped/file.tmp)=%v err=%v, want excluded with trusted junctions", included, err)
+	}
+}
+
+func TestResolveLinksFinalPath(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	resolved, err := resolveLinks(dir, true)
+	if err != nil {
+		t.Fatalf("resolveLinks: %v", err)
+	}
+
+	if !isPathWithinRoot(resolved, filepath.Join(resolved, "child")) || windowsVolumeLen(resolved) == 0 {
+		t.Fatalf("resolveLinks(%q)=%q, want absolute Windows path", dir, resolved)
+	}
+}
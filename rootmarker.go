@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// rulesStart returns the deepest non-root directory among relDir and its
+// ancestors holding the root marker file, "" when none does or markers are
+// disabled. Rules files above it do not apply below it.
+func (p *Provider) rulesStart(relDir string) (string, error) {
+	if p.rootMarker == "" {
+		return "", nil
+	}
+
+	for dir := relDir; dir != ""; dir = pathDir(dir, false) {
+		found, err := p.hasRootMarker(dir)
+		if err != nil {
+			return "", err
+		}
+
+		if found {
+			return dir, nil
+		}
+	}
+
+	return "", nil
+}
+
+// hasRootMarker reports whether relDir holds the root marker file, cached until Refresh.
+func (p *Provider) hasRootMarker(relDir string) (bool, error) {
+	p.cache.mu.Lock()
+	found, ok := p.cache.markers[relDir]
+	p.cache.mu.Unlock()
+	if ok {
+		return found, nil
+	}
+
+	found, err := p.statRootMarker(relDir)
+	if err != nil {
+		return false, err
+	}
+
+	p.cache.mu.Lock()
+	p.cache.markers[relDir] = found
+	p.cache.mu.Unlock()
+	return found, nil
+}
+
+// statRootMarker checks the root marker file of relDir on disk.
+func (p *Provider) statRootMarker(relDir string) (bool, error) {
+	var err error
+	switch {
+	case p.fsys != nil:
+		_, err = fs.Stat(p.fsys, path.Join(relDir, p.rootMarker))
+	case p.osRoot != nil:
+		_, err = p.osRoot.Stat(path.Join(relDir, p.rootMarker))
+	default:
+		_, err = os.Stat(filepath.Join(p.root, filepath.FromSlash(relDir), p.rootMarker))
+	}
+
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("stat root marker: %w", err)
+}
+
+// cleanRootMarkerFileName validates root marker file name, empty disables markers.
+func cleanRootMarkerFileName(raw string) (string, error) {
+	name := strings.TrimSpace(raw)
+	if name == "" {
+		return "", nil
+	}
+
+	name = filepath.ToSlash(name)
+	if filepath.IsAbs(name) || strings.Contains(name, "/") || name == "." || name == ".." {
+		return "", fmt.Errorf("%w: invalid root marker file name %q", ErrInvalidOptions, raw)
+	}
+
+	return name, nil
+}
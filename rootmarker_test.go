@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestProviderRootMarker(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":                {Data: []byte("*.gen.go\nvendor/old/\n")},
+		"vendor/.pathrules":         {Data: []byte("*.md\n")},
+		"vendor/lib/.pathrulesroot": {Data: nil},
+		"vendor/lib/.pathrules":     {Data: []byte("*.tmp\n")},
+		"vendor/lib/sub/.pathrules": {Data: []byte("!keep.tmp\n")},
+		"vendor/old/.pathrulesroot": {Data: nil},
+	}
+
+	p, err := NewProviderFS(fsys, ProviderOptions{
+		BaseRules:          []Rule{{Action: ActionExclude, Pattern: "*.bak"}},
+		RootMarkerFileName: ".pathrulesroot",
+	})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	for path, want := range map[string]bool{
+		"a.gen.go":                false,
+		"vendor/a.md":             false,
+		"vendor/lib/a.gen.go":     true,
+		"vendor/lib/a.md":         true,
+		"vendor/lib/a.tmp":        false,
+		"vendor/lib/a.bak":        false,
+		"vendor/lib/sub/a.gen.go": true,
+		"vendor/lib/sub/keep.tmp": true,
+		"vendor/other/a.gen.go":   false,
+	} {
+		if got, err := p.Included(path, false); err != nil || got != want {
+			t.Fatalf("Included(%s)=%v err=%v, want %v", path, got, err, want)
+		}
+
+		got, err := p.IncludedInDir(pathDir(path, false), []DirEntry{{Name: pathBase(path)}})
+		if err != nil || got[0] != want {
+			t.Fatalf("IncludedInDir(%s)=%v err=%v, want %v", path, got, err, want)
+		}
+	}
+
+	// The marked directory itself is still decided by outer rules.
+	if got, err := p.Included("vendor/old", true); err != nil || got {
+		t.Fatalf("Included(vendor/old)=%v err=%v, want excluded", got, err)
+	}
+
+	if _, err := NewProviderFS(fsys, ProviderOptions{RootMarkerFileName: "a/b"}); !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("invalid marker name err=%v", err)
+	}
+}
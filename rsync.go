@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// RulesFormat selects rules file syntax loaded by Provider.
+type RulesFormat uint8
+
+const (
+	// RulesFormatPathrules is native gitignore-like rules syntax.
+	RulesFormatPathrules RulesFormat = iota
+	// RulesFormatRsyncFilter is rsync filter rules syntax (see ParseRsyncFilter).
+	RulesFormatRsyncFilter
+	// RulesFormatHgignore is Mercurial hgignore syntax (see ParseHgignore).
+	RulesFormatHgignore
+)
+
+// RsyncFilter is a parsed rsync filter file.
+type RsyncFilter struct {
+	// Rules are filter rules in pathrules last-match-wins order
+	// (reverse of rsync first-match-wins file order).
+	Rules []Rule `json:"rules,omitempty" yaml:"rules,omitempty"`
+	// DirMerge lists per-directory merge file names from "dir-merge" rules.
+	DirMerge []string `json:"dir_merge,omitempty" yaml:"dir_merge,omitempty"`
+	// DirMergeAt is the number of rules written before the first
+	// "dir-merge" rule. They are the last DirMergeAt entries of Rules and
+	// take precedence over merged per-directory rules.
+	DirMergeAt int `json:"dir_merge_at,omitempty" yaml:"dir_merge_at,omitempty"`
+}
+
+// String returns rules format name.
+func (f RulesFormat) String() string {
+	switch f {
+	case RulesFormatPathrules:
+		return "pathrules"
+	case RulesFormatRsyncFilter:
+		return "rsync-filter"
+	case RulesFormatHgignore:
+		return "hgignore"
+	default:
+		return fmt.Sprintf("rules-format(%d)", uint8(f))
+	}
+}
+
+// valid reports whether rules format value is supported.
+func (f RulesFormat) valid() bool {
+	return f <= RulesFormatHgignore
+}
+
+// ParseRsyncFilter parses rsync filter rules from reader.
+//
+// Supported rules:
+//   - "+ pattern", "include pattern", "show pattern" create include rules
+//   - "- pattern", "exclude pattern", "hide pattern" create exclude rules
+//   - ": name", "dir-merge name" record a per-directory merge file name
+//   - "!", "clear" drop all rules parsed so far
+//   - lines starting with "#" or ";" are comments
+//
+// Rule name may be followed by one space or "_". Rule modifiers, "merge",
+// "protect" and "risk" rules are rejected with ErrInvalidRule. Patterns
+// should be matched with DialectRsync. All invalid lines are reported as
+// *ParseError.
+func ParseRsyncFilter(r io.Reader, sourceName string) (*RsyncFilter, error) {
+	s := bufio.NewScanner(r)
+	f := &RsyncFilter{}
+	lineNo := 0
+	mergeAt := -1
+
+	var lineErrs []*RuleError
+	for s.Scan() {
+		lineNo++
+		line := strings.TrimRight(s.Text(), "\r")
+		if strings.TrimSpace(line) == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		name, pattern, err := splitRsyncFilterLine(line)
+		if err != nil {
+			lineErrs = append(lineErrs, &RuleError{
+				File:    sourceName,
+				Line:    lineNo,
+				Pattern: line,
+				Err:     err,
+			})
+			continue
+		}
+
+		switch name {
+		case "!", "clear":
+			f.Rules = f.Rules[:0]
+			mergeAt = min(mergeAt, 0)
+		case ":", "dir-merge":
+			if pattern == "" || strings.ContainsAny(pattern, `/\`) {
+				lineErrs = append(lineErrs, &RuleError{
+					File:    sourceName,
+					Line:    lineNo,
+					Pattern: line,
+					Err:     fmt.Errorf("%w: dir-merge needs a plain file name", ErrInvalidRule),
+				})
+				continue
+			}
+
+			if !slices.Contains(f.DirMerge, pattern) {
+				f.DirMerge = append(f.DirMerge, pattern)
+			}
+
+			if mergeAt < 0 {
+				mergeAt = len(f.Rules)
+			}
+		default:
+			action := ActionExclude
+			if name == "+" || name == "include" || name == "show" {
+				action = ActionInclude
+			}
+
+			f.Rules = append(f.Rules, Rule{
+				Action:  action,
+				Pattern: pattern,
+				Source:  sourceName,
+				Line:    lineNo,
+			})
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("scan rsync filter: %w", err)
+	}
+
+	if len(lineErrs) > 0 {
+		return nil, &ParseError{Errors: lineErrs}
+	}
+
+	slices.Reverse(f.Rules)
+	f.DirMergeAt = max(mergeAt, 0)
+	return f, nil
+}
+
+// ProviderOptions returns provider options loading f as base rules and its
+// dir-merge file from every directory with DialectRsync matching.
+//
+// As in rsync, merged rules take the place of the dir-merge line: rules
+// written after it become BaseRules and are overridden by merged rules,
+// rules written before it become FinalRules and override them. More than
+// one dir-merge file name fails with ErrInvalidOptions.
+func (f *RsyncFilter) ProviderOptions() (ProviderOptions, error) {
+	if len(f.DirMerge) > 1 {
+		return ProviderOptions{}, fmt.Errorf("%w: %d dir-merge files, only one is supported", ErrInvalidOptions, len(f.DirMerge))
+	}
+
+	opts := ProviderOptions{
+		BaseRules:      slices.Clone(f.Rules),
+		RulesFormat:    RulesFormatRsyncFilter,
+		MatcherOptions: MatcherOptions{Dialect: DialectRsync},
+	}
+
+	if len(f.DirMerge) == 1 {
+		opts.RulesFileName = f.DirMerge[0]
+		if before := min(max(f.DirMergeAt, 0), len(f.Rules)); before > 0 {
+			at := len(f.Rules) - before
+			opts.BaseRules = slices.Clone(f.Rules[:at])
+			opts.FinalRules = slices.Clone(f.Rules[at:])
+		}
+	}
+
+	return opts, nil
+}
+
+// splitRsyncFilterLine splits one filter line into rule name and pattern.
+func splitRsyncFilterLine(line string) (string, string, error) {
+	var name, rest string
+	switch line[0] {
+	case '+', '-', ':', '!', '.', 'P', 'R', 'H', 'S':
+		name, rest = line[:1], line[1:]
+	default:
+		end := strings.IndexAny(line, " _,")
+		if end < 0 {
+			end = len(line)
+		}
+
+		name, rest = line[:end], line[end:]
+	}
+
+	switch name {
+	case "H":
+		name = "hide"
+	case "S":
+		name = "show"
+	}
+
+	switch name {
+	case "!", "clear":
+		if strings.TrimSpace(rest) != "" {
+			return "", "", fmt.Errorf("%w: unexpected text after %q", ErrInvalidRule, name)
+		}
+
+		return name, "", nil
+	case "+", "-", ":", "include", "exclude", "hide", "show", "dir-merge":
+	case ".", "merge", "P", "protect", "R", "risk":
+		return "", "", fmt.Errorf("%w: unsupported rsync rule %q", ErrInvalidRule, name)
+	default:
+		return "", "", fmt.Errorf("%w: unknown rsync rule %q", ErrInvalidRule, name)
+	}
+
+	if rest == "" || (rest[0] != ' ' && rest[0] != '_') {
+		return "", "", fmt.Errorf("%w: unsupported rsync rule modifiers in %q", ErrInvalidRule, name)
+	}
+
+	pattern := rest[1:]
+	if name != ":" && name != "dir-merge" && strings.TrimSpace(pattern) == "" {
+		return "", "", fmt.Errorf("%w: empty pattern", ErrInvalidRule)
+	}
+
+	return name, pattern, nil
+}
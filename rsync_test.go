@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRsyncFilter(t *testing.T) {
+	t.Parallel()
+
+	src := strings.Join([]string{
+		"# comment",
+		"; comment",
+		"- junk",
+		"!",
+		"+ */",
+		"include_*.c",
+		": .rsync-filter",
+		"dir-merge .rsync-filter",
+		"H *",
+	}, "\n")
+
+	f, err := ParseRsyncFilter(strings.NewReader(src), "filter.rules")
+	if err != nil {
+		t.Fatalf("ParseRsyncFilter: %v", err)
+	}
+
+	want := []Rule{
+		{Action: ActionExclude, Pattern: "*", Source: "filter.rules", Line: 9},
+		{Action: ActionInclude, Pattern: "*.c", Source: "filter.rules", Line: 6},
+		{Action: ActionInclude, Pattern: "*/", Source: "filter.rules", Line: 5},
+	}
+
+	if len(f.Rules) != len(want) {
+		t.Fatalf("Rules=%+v, want %+v", f.Rules, want)
+	}
+
+	for i := range want {
+		if !reflect.DeepEqual(f.Rules[i], want[i]) {
+			t.Fatalf("Rules[%d]=%+v, want %+v", i, f.Rules[i], want[i])
+		}
+	}
+
+	if len(f.DirMerge) != 1 || f.DirMerge[0] != ".rsync-filter" || f.DirMergeAt != 2 {
+		t.Fatalf("DirMerge=%v at %d, want [.rsync-filter] at 2", f.DirMerge, f.DirMergeAt)
+	}
+}
+
+func TestParseRsyncFilterRejectsUnsupported(t *testing.T) {
+	t.Parallel()
+
+	src := "-! negated\nmerge /etc/rules\nP protected\n+\n: sub/file\nbogus x\n"
+	_, err := ParseRsyncFilter(strings.NewReader(src), "")
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || !errors.Is(err, ErrInvalidRule) {
+		t.Fatalf("err=%v, want *ParseError with ErrInvalidRule", err)
+	}
+
+	if len(parseErr.Errors) != 6 {
+		t.Fatalf("len(Errors)=%d, want 6: %v", len(parseErr.Errors), err)
+	}
+}
+
+func TestDialectRsync(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		filter   string
+		path     string
+		isDir    bool
+		included bool
+	}{
+		{filter: "+ */\n+ *.c\n- *", path: "src/main.c", included: true},
+		{filter: "+ */\n+ *.c\n- *", path: "src/readme.md", included: false},
+		{filter: "+ */\n+ *.c\n- *", path: "src", isDir: true, included: true},
+		{filter: "- build/\n+ *", path: "build/keep.txt", included: false},
+		{filter: "- build/\n+ *", path: "build", included: true},
+		{filter: "+ keep/***\n- *", path: "keep/a/b.txt", included: true},
+		{filter: "+ keep/***\n- *", path: "keep", isDir: true, included: true},
+		{filter: "+ keep/***\n- *", path: "other.txt", included: false},
+		{filter: "- *.o\n+ *", path: "a/b/x.o", included: false},
+		{filter: "- /top.o\n+ *", path: "a/top.o", included: true},
+	}
+
+	for _, tc := range cases {
+		f, err := ParseRsyncFilter(strings.NewReader(tc.filter), "")
+		if err != nil {
+			t.Fatalf("ParseRsyncFilter(%q): %v", tc.filter, err)
+		}
+
+		m, err := NewMatcher(f.Rules, MatcherOptions{Dialect: DialectRsync})
+		if err != nil {
+			t.Fatalf("NewMatcher(%q): %v", tc.filter, err)
+		}
+
+		if got := m.Included(tc.path, tc.isDir); got != tc.included {
+			t.Errorf("filter %q: Included(%q, dir=%v)=%v, want %v", tc.filter, tc.path, tc.isDir, got, tc.included)
+		}
+	}
+}
+
+func TestProviderRsyncDirMerge(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "data"), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	writeRulesFile(t, filepath.Join(root, "data", ".rsync-filter"), "+ *.keep\n+ *.tmp\n- *.log\n")
+
+	f, err := ParseRsyncFilter(strings.NewReader("- *.keep\ndir-merge .rsync-filter\n- *.tmp\n"), "")
+	if err != nil {
+		t.Fatalf("ParseRsyncFilter: %v", err)
+	}
+
+	opts, err := f.ProviderOptions()
+	if err != nil {
+		t.Fatalf("ProviderOptions: %v", err)
+	}
+
+	p, err := NewProvider(root, opts)
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if f.DirMergeAt != 1 || len(opts.BaseRules) != 1 || len(opts.FinalRules) != 1 {
+		t.Fatalf("DirMergeAt=%d base=%v final=%v, want the rule before dir-merge final", f.DirMergeAt, opts.BaseRules, opts.FinalRules)
+	}
+
+	// "- *.keep" precedes dir-merge and wins over merged rules, "- *.tmp"
+	// follows it and loses to them.
+	cases := map[string]bool{
+		"a.tmp":       false,
+		"a.keep":      false,
+		"data/a.tmp":  true,
+		"data/a.keep": false,
+		"data/a.log":  false,
+		"data/a.txt":  true,
+	}
+
+	for path, want := range cases {
+		if got, err := p.Included(path, false); err != nil || got != want {
+			t.Fatalf("Included(%q)=%v err=%v, want %v", path, got, err, want)
+		}
+	}
+
+	writeRulesFile(t, filepath.Join(root, "data", ".rsync-filter"), "dir-merge .other\n")
+	p, err = NewProvider(root, opts)
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if _, err := p.Included("data/a.txt", false); !errors.Is(err, ErrInvalidRule) {
+		t.Fatalf("err=%v, want ErrInvalidRule for nested dir-merge", err)
+	}
+}
@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// RuleChange is one rule whose pattern is kept but whose other fields changed.
+type RuleChange struct {
+	// Old is the rule in the first set.
+	Old Rule `json:"old" yaml:"old"`
+	// New is the rule in the second set.
+	New Rule `json:"new" yaml:"new"`
+}
+
+// RuleDiff is the structural difference of two rule sets.
+type RuleDiff struct {
+	// Added are rules only in the second set, in its order.
+	Added []Rule `json:"added,omitempty" yaml:"added,omitempty"`
+	// Removed are rules only in the first set, in its order.
+	Removed []Rule `json:"removed,omitempty" yaml:"removed,omitempty"`
+	// Changed are rules with the same pattern whose action, priority, tags
+	// or metadata predicate changed, in first set order.
+	Changed []RuleChange `json:"changed,omitempty" yaml:"changed,omitempty"`
+	// Reordered reports that rules present in both sets appear in a
+	// different relative order, which may change last-match-wins decisions.
+	Reordered bool `json:"reordered,omitempty" yaml:"reordered,omitempty"`
+}
+
+// ruleIdentity identifies the nth rule with one pattern across rule sets.
+type ruleIdentity struct {
+	// pattern is Rule.Pattern.
+	pattern string
+	// section is Rule.Section.
+	section string
+	// nth counts earlier rules with the same pattern, syntax and section.
+	nth int
+	// syntax is Rule.Syntax.
+	syntax PatternSyntax
+}
+
+// Empty reports whether the rule sets are structurally equal.
+func (d RuleDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0 && !d.Reordered
+}
+
+// DiffRules compares rule sets a and b structurally. Rules are paired by
+// pattern, syntax and section (repeated patterns pair in order); Source and
+// Line are ignored, so the same rules loaded from different files are equal.
+func DiffRules(a, b []Rule) RuleDiff {
+	inB := make(map[ruleIdentity]int, len(b))
+	for i, id := range ruleIdentities(b) {
+		inB[id] = i
+	}
+
+	var (
+		diff    RuleDiff
+		matched = make([]bool, len(b))
+		lastB   = -1
+	)
+
+	for i, id := range ruleIdentities(a) {
+		j, ok := inB[id]
+		if !ok {
+			diff.Removed = append(diff.Removed, a[i])
+			continue
+		}
+
+		matched[j] = true
+		if j < lastB {
+			diff.Reordered = true
+		}
+
+		lastB = j
+		if !sameRule(&a[i], &b[j]) {
+			diff.Changed = append(diff.Changed, RuleChange{Old: a[i], New: b[j]})
+		}
+	}
+
+	for j, ok := range matched {
+		if !ok {
+			diff.Added = append(diff.Added, b[j])
+		}
+	}
+
+	return diff
+}
+
+// DedupRules returns rules without repeated rules (Source and Line ignored),
+// keeping the copy that can decide under policy: the last one for
+// PolicyLastMatchWins, the first one for PolicyFirstMatchWins. Decisions of a
+// matcher using the same policy are unchanged; RuleIndex values shift.
+func DedupRules(rules []Rule, policy Policy) []Rule {
+	kept := make(map[ruleIdentity][]int, len(rules))
+	keep := make([]bool, len(rules))
+	for n := range rules {
+		i := len(rules) - 1 - n
+		if policy == PolicyFirstMatchWins {
+			i = n
+		}
+
+		id := ruleIdentity{pattern: rules[i].Pattern, section: rules[i].Section, syntax: rules[i].Syntax}
+		if slices.ContainsFunc(kept[id], func(j int) bool { return sameRule(&rules[i], &rules[j]) }) {
+			continue
+		}
+
+		kept[id] = append(kept[id], i)
+		keep[i] = true
+	}
+
+	out := make([]Rule, 0, len(rules))
+	for i := range rules {
+		if keep[i] {
+			out = append(out, rules[i])
+		}
+	}
+
+	return out
+}
+
+// SortRules returns a copy of rules in canonical order: by section, pattern,
+// syntax, action and priority, stable for equal keys. The order is meant for
+// display and comparison; sorting changes last-match-wins decisions.
+func SortRules(rules []Rule) []Rule {
+	out := slices.Clone(rules)
+	slices.SortStableFunc(out, func(x, y Rule) int {
+		return cmp.Or(
+			strings.Compare(x.Section, y.Section),
+			strings.Compare(x.Pattern, y.Pattern),
+			cmp.Compare(x.Syntax, y.Syntax),
+			cmp.Compare(x.Action, y.Action),
+			cmp.Compare(x.Priority, y.Priority),
+		)
+	})
+
+	return out
+}
+
+// ruleIdentities returns the pairing identity of every rule.
+func ruleIdentities(rules []Rule) []ruleIdentity {
+	seen := make(map[ruleIdentity]int, len(rules))
+	ids := make([]ruleIdentity, len(rules))
+	for i := range rules {
+		id := ruleIdentity{pattern: rules[i].Pattern, section: rules[i].Section, syntax: rules[i].Syntax}
+		n := seen[id]
+		seen[id] = n + 1
+		id.nth = n
+		ids[i] = id
+	}
+
+	return ids
+}
+
+// sameRule reports whether paired rules have equal action, priority, tags and metadata predicate.
+func sameRule(x, y *Rule) bool {
+	return x.Action == y.Action &&
+		x.Priority == y.Priority &&
+		slices.Equal(x.Tags, y.Tags) &&
+		sameMetaPredicate(x.Meta, y.Meta)
+}
+
+// sameMetaPredicate reports whether two metadata predicates accept the same metadata.
+func sameMetaPredicate(x, y *MetaPredicate) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+
+	return x.ModifiedBefore.Equal(y.ModifiedBefore) &&
+		x.ModifiedAfter.Equal(y.ModifiedAfter) &&
+		samePtrValue(x.SizeAbove, y.SizeAbove) &&
+		samePtrValue(x.SizeBelow, y.SizeBelow) &&
+		samePtrValue(x.Symlink, y.Symlink) &&
+		x.ModeMask == y.ModeMask &&
+		x.Mode == y.Mode
+}
+
+// samePtrValue reports whether x and y are both nil or point to equal values.
+func samePtrValue[T comparable](x, y *T) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+
+	return *x == *y
+}
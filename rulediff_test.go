@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"slices"
+	"testing"
+)
+
+// rulePatterns returns the patterns of rules.
+func rulePatterns(rules []Rule) []string {
+	out := make([]string, len(rules))
+	for i, rule := range rules {
+		out[i] = rule.Pattern
+	}
+
+	return out
+}
+
+// formatPatterns returns patterns of rules with "!" marking include rules.
+func formatPatterns(rules []Rule) []string {
+	out := rulePatterns(rules)
+	for i, rule := range rules {
+		if rule.Action == ActionInclude {
+			out[i] = "!" + out[i]
+		}
+	}
+
+	return out
+}
+
+func TestDedupRules(t *testing.T) {
+	t.Parallel()
+
+	rules := MustParseRulesString("*.log\nbuild/\n!*.log\n*.log\nbuild/\n")
+	got := DedupRules(rules, PolicyLastMatchWins)
+	if want := []string{"!*.log", "*.log", "build/"}; !slices.Equal(formatPatterns(got), want) {
+		t.Fatalf("DedupRules=%v, want %v", formatPatterns(got), want)
+	}
+
+	first := DedupRules(rules, PolicyFirstMatchWins)
+	if want := []string{"*.log", "build/", "!*.log"}; !slices.Equal(formatPatterns(first), want) {
+		t.Fatalf("DedupRules(first-match)=%v, want %v", formatPatterns(first), want)
+	}
+
+	for _, tc := range []struct {
+		deduped []Rule
+		policy  Policy
+	}{
+		{deduped: got, policy: PolicyLastMatchWins},
+		{deduped: first, policy: PolicyFirstMatchWins},
+	} {
+		m1, _ := NewMatcher(rules, MatcherOptions{Policy: tc.policy})
+		m2, _ := NewMatcher(tc.deduped, MatcherOptions{Policy: tc.policy})
+		for _, path := range []string{"a.log", "build", "a.txt"} {
+			if m1.Included(path, true) != m2.Included(path, true) {
+				t.Fatalf("%s: decision of %s changed", tc.policy, path)
+			}
+		}
+	}
+}
+
+func TestSortRules(t *testing.T) {
+	t.Parallel()
+
+	rules := MustParseRulesString("b\n!a\na\n")
+	got := SortRules(rules)
+	if want := []string{"a", "!a", "b"}; !slices.Equal(formatPatterns(got), want) {
+		t.Fatalf("SortRules=%v, want %v", formatPatterns(got), want)
+	}
+
+	if rules[0].Pattern != "b" {
+		t.Fatal("SortRules modified its input")
+	}
+}
+
+func TestDiffRules(t *testing.T) {
+	t.Parallel()
+
+	a := MustParseRulesString("*.log\nbuild/\ntmp/\n")
+	b := MustParseRulesString("build/\n!*.log\ndist/\n")
+	for i := range b {
+		b[i].Source = "other"
+	}
+
+	diff := DiffRules(a, b)
+	if got := rulePatterns(diff.Removed); !slices.Equal(got, []string{"tmp/"}) {
+		t.Fatalf("Removed=%v", got)
+	}
+
+	if got := rulePatterns(diff.Added); !slices.Equal(got, []string{"dist/"}) {
+		t.Fatalf("Added=%v", got)
+	}
+
+	if len(diff.Changed) != 1 || diff.Changed[0].Old.Action != ActionExclude || diff.Changed[0].New.Action != ActionInclude {
+		t.Fatalf("Changed=%+v", diff.Changed)
+	}
+
+	if !diff.Reordered {
+		t.Fatal("Reordered=false, want true")
+	}
+
+	if d := DiffRules(a, slices.Clone(a)); !d.Empty() {
+		t.Fatalf("DiffRules(a, a)=%+v, want empty", d)
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"fmt"
+)
+
+// RuleFileErrorPolicy selects how Provider handles a rules file that cannot
+// be read, parsed or compiled.
+type RuleFileErrorPolicy uint8
+
+const (
+	// RuleFileErrorFail returns the error from every decision that needs the file.
+	RuleFileErrorFail RuleFileErrorPolicy = iota
+	// RuleFileErrorSkipFile ignores the file as if it did not exist.
+	RuleFileErrorSkipFile
+	// RuleFileErrorExcludeSubtree excludes every path under the file
+	// directory; rules files deeper in the subtree are not loaded.
+	RuleFileErrorExcludeSubtree
+)
+
+// RuleFileErrorHandler receives a rules file error of the directory relDir
+// ("" for root) once per load, before RuleFileErrorPolicy applies. It must
+// be safe for concurrent use.
+type RuleFileErrorHandler func(relDir string, err error)
+
+// errSubtreeExcluded marks a directory excluded by RuleFileErrorExcludeSubtree.
+var errSubtreeExcluded = errors.New("subtree excluded by rules file error")
+
+// String returns policy name.
+func (p RuleFileErrorPolicy) String() string {
+	switch p {
+	case RuleFileErrorFail:
+		return "fail"
+	case RuleFileErrorSkipFile:
+		return "skip-file"
+	case RuleFileErrorExcludeSubtree:
+		return "exclude-subtree"
+	default:
+		return fmt.Sprintf("rule-file-error(%d)", uint8(p))
+	}
+}
+
+// valid reports whether policy value is supported.
+func (p RuleFileErrorPolicy) valid() bool {
+	return p <= RuleFileErrorExcludeSubtree
+}
+
+// dirMatcher returns the matcher of relDir with the rules file error policy
+// applied; errSubtreeExcluded marks an excluded subtree.
+func (p *Provider) dirMatcher(relDir string) (*Matcher, error) {
+	matcher, err := p.loadDirMatcher(relDir)
+	if err == nil {
+		return matcher, nil
+	}
+
+	switch p.onRuleFileError {
+	case RuleFileErrorSkipFile:
+		return nil, nil
+	case RuleFileErrorExcludeSubtree:
+		return nil, errSubtreeExcluded
+	default:
+		return nil, err
+	}
+}
+
+// reportRuleFileError passes a fresh load error to the configured handler.
+func (p *Provider) reportRuleFileError(relDir string, err error) {
+	if err != nil && p.ruleFileErrorHandler != nil {
+		p.ruleFileErrorHandler(relDir, err)
+	}
+}
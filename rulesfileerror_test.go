@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+func TestProviderOnRuleFileError(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":            {Data: []byte("*.tmp\n")},
+		"broken/.pathrules":     {Data: []byte("#pragma default=bogus\n")},
+		"broken/sub/.pathrules": {Data: []byte("!*.tmp\n")},
+		"ok/.pathrules":         {Data: []byte("!keep.tmp\n")},
+	}
+
+	cases := []struct {
+		policy   RuleFileErrorPolicy
+		included bool
+		err      error
+	}{
+		{policy: RuleFileErrorFail, err: ErrInvalidDirective},
+		{policy: RuleFileErrorSkipFile, included: true},
+		{policy: RuleFileErrorExcludeSubtree, included: false},
+	}
+
+	for _, tc := range cases {
+		var (
+			mu       sync.Mutex
+			reported []string
+		)
+
+		p, err := NewProviderFS(fsys, ProviderOptions{
+			OnRuleFileError: tc.policy,
+			RuleFileErrorHandler: func(relDir string, err error) {
+				mu.Lock()
+				reported = append(reported, relDir)
+				mu.Unlock()
+			},
+		})
+		if err != nil {
+			t.Fatalf("NewProviderFS(%s): %v", tc.policy, err)
+		}
+
+		for range 2 {
+			included, err := p.Included("broken/a.txt", false)
+			if !errors.Is(err, tc.err) || included != tc.included {
+				t.Fatalf("%s: Included(broken/a.txt)=%v err=%v, want %v err=%v", tc.policy, included, err, tc.included, tc.err)
+			}
+		}
+
+		res, err := p.DecideInDir("broken/sub", []DirEntry{{Name: "a.tmp"}})
+		if tc.err == nil && (err != nil || res[0].Included != (tc.policy == RuleFileErrorSkipFile)) {
+			t.Fatalf("%s: DecideInDir(broken/sub)=%+v err=%v", tc.policy, res, err)
+		}
+
+		if included, err := p.Included("ok/keep.tmp", false); err != nil || !included {
+			t.Fatalf("%s: Included(ok/keep.tmp)=%v err=%v, want included", tc.policy, included, err)
+		}
+
+		if len(reported) != 1 || reported[0] != "broken" {
+			t.Fatalf("%s: reported=%v, want [broken] once", tc.policy, reported)
+		}
+	}
+
+	if _, err := NewProviderFS(fsys, ProviderOptions{OnRuleFileError: RuleFileErrorExcludeSubtree + 1}); !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("err=%v, want ErrInvalidOptions", err)
+	}
+}
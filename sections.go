@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "slices"
+
+// SelectSections returns common rules (empty Section) and rules from the
+// named sections, preserving input order.
+//
+// Passing no names keeps only common rules.
+func SelectSections(rules []Rule, names ...string) []Rule {
+	out := make([]Rule, 0, len(rules))
+	for i := range rules {
+		if rules[i].Section == "" || slices.Contains(names, rules[i].Section) {
+			out = append(out, rules[i])
+		}
+	}
+
+	return out
+}
+
+// RuleSections returns distinct section names in first-seen order.
+func RuleSections(rules []Rule) []string {
+	var out []string
+	for i := range rules {
+		if rules[i].Section != "" && !slices.Contains(out, rules[i].Section) {
+			out = append(out, rules[i].Section)
+		}
+	}
+
+	return out
+}
+
+// parseSectionHeader parses "[name]" line and reports whether it is a section header.
+//
+// Names are limited to ASCII letters, digits, "_", "-" and ".".
+func parseSectionHeader(line string) (string, bool) {
+	if len(line) < 3 || line[0] != '[' || line[len(line)-1] != ']' {
+		return "", false
+	}
+
+	name := line[1 : len(line)-1]
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+			c == '_' || c == '-' || c == '.' {
+			continue
+		}
+
+		return "", false
+	}
+
+	return name, true
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sectionsSource = `*.tmp
+
+[textures]
+!*.paa
+[scripts]
+!*.c
+[a-z]
+`
+
+func TestParseRulesSections(t *testing.T) {
+	t.Parallel()
+
+	plain, err := ParseRulesString(sectionsSource)
+	if err != nil {
+		t.Fatalf("ParseRulesString: %v", err)
+	}
+
+	if len(plain) != 6 || plain[1].Pattern != "[textures]" {
+		t.Fatalf("without sections headers must stay patterns: %+v", plain)
+	}
+
+	rules, err := ParseRulesWithOptions(strings.NewReader(sectionsSource), ParseOptions{Sections: true})
+	if err != nil {
+		t.Fatalf("ParseRulesWithOptions: %v", err)
+	}
+
+	if len(rules) != 3 {
+		t.Fatalf("len(rules)=%d, want 3: %+v", len(rules), rules)
+	}
+
+	if rules[0].Section != "" || rules[1].Section != "textures" || rules[2].Section != "scripts" {
+		t.Fatalf("unexpected sections: %+v", rules)
+	}
+
+	if got := RuleSections(rules); len(got) != 2 || got[0] != "textures" || got[1] != "scripts" {
+		t.Fatalf("RuleSections=%v", got)
+	}
+
+	selected := SelectSections(rules, "scripts")
+	if len(selected) != 2 || selected[0].Pattern != "*.tmp" || selected[1].Pattern != "*.c" {
+		t.Fatalf("SelectSections(scripts)=%+v", selected)
+	}
+
+	if common := SelectSections(rules); len(common) != 1 {
+		t.Fatalf("SelectSections()=%+v, want only common rules", common)
+	}
+}
+
+func TestProviderSections(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, ".rules"), "*\n[textures]\n!*.paa\n[scripts]\n!*.c\n")
+
+	p, err := NewProvider(root, ProviderOptions{
+		RulesFileName: ".rules",
+		Sections:      []string{"textures"},
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if included, err := p.Included("ui/a.paa", false); err != nil || !included {
+		t.Fatalf("Included(ui/a.paa)=%v err=%v, want included", included, err)
+	}
+
+	if included, err := p.Included("main.c", false); err != nil || included {
+		t.Fatalf("Included(main.c)=%v err=%v, want excluded", included, err)
+	}
+}
+
+func TestMatcherProfile(t *testing.T) {
+	t.Parallel()
+
+	rules, err := ParseRulesWithOptions(strings.NewReader(sectionsSource), ParseOptions{Sections: true})
+	if err != nil {
+		t.Fatalf("ParseRulesWithOptions: %v", err)
+	}
+
+	m, err := NewMatcher(rules, MatcherOptions{Profile: "scripts"})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if res := m.Decide("a.tmp", false); res.Included || res.RuleIndex != 0 {
+		t.Fatalf("Decide(a.tmp)=%+v, want common rule 0", res)
+	}
+
+	if res := m.Decide("a.paa", false); !res.Included || res.Matched {
+		t.Fatalf("Decide(a.paa)=%+v, want unmatched textures rule", res)
+	}
+
+	if got, want := HashRules(rules, MatcherOptions{Profile: "scripts"}), HashRules(SelectSections(rules, "scripts"), MatcherOptions{}); got != want {
+		t.Fatalf("HashRules with profile=%s, want %s", got, want)
+	}
+}
+
+func TestProviderProfile(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, ".rules"), "*\n[release]\n!*.pbo\n[debug]\n!*.pbo\n!*.log\n")
+	writeRulesFile(t, filepath.Join(root, "logs", ".rules"), "[docs]\n!*.md\n")
+
+	p, err := NewProvider(root, ProviderOptions{
+		RulesFileName:  ".rules",
+		MatcherOptions: MatcherOptions{Profile: "debug"},
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	for path, want := range map[string]bool{"a.pbo": true, "logs/a.log": true, "logs/a.md": false} {
+		if included, err := p.Included(path, false); err != nil || included != want {
+			t.Fatalf("Included(%s)=%v err=%v, want %v", path, included, err, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// PathSeparatorPolicy selects how "\" is treated in candidate paths and
+// DialectDefault and DialectRsync patterns.
+type PathSeparatorPolicy uint8
+
+const (
+	// PathSeparatorAuto accepts both "/" and "\" as separators on every
+	// system, so rules and paths written on Windows work anywhere.
+	PathSeparatorAuto PathSeparatorPolicy = iota
+	// PathSeparatorWindows treats "\" as a separator and strips Windows
+	// volume prefixes from candidate paths.
+	PathSeparatorWindows
+	// PathSeparatorPOSIX treats "/" as the only separator: "\" is an
+	// ordinary file name byte in paths and escapes the next byte in
+	// patterns. Windows volume prefixes are not stripped.
+	PathSeparatorPOSIX
+)
+
+// String returns policy name.
+func (p PathSeparatorPolicy) String() string {
+	switch p {
+	case PathSeparatorAuto:
+		return "auto"
+	case PathSeparatorWindows:
+		return "windows"
+	case PathSeparatorPOSIX:
+		return "posix"
+	default:
+		return fmt.Sprintf("path-separator(%d)", uint8(p))
+	}
+}
+
+// valid reports whether policy value is supported.
+func (p PathSeparatorPolicy) valid() bool {
+	return p <= PathSeparatorPOSIX
+}
+
+// normalizeCandidate returns path in matcher candidate form.
+func (m *Matcher) normalizeCandidate(path string) string {
+	var candidate string
+	if m.posixSeparators {
+		candidate = normalizePOSIXPath(path)
+	} else {
+		candidate = normalizePath(path)
+	}
+
+	if m.caseInsensitive {
+		candidate = asciiLower(candidate)
+	}
+
+	return candidate
+}
+
+// dirHint reports whether path carries a trailing separator directory hint.
+func (m *Matcher) dirHint(path string) bool {
+	return dirHint(path, m.posixSeparators)
+}
+
+// posixSeparators reports whether provider paths use PathSeparatorPOSIX.
+func (p *Provider) posixSeparators() bool {
+	return p.matcherOptions.PathSeparators == PathSeparatorPOSIX
+}
+
+// windowsVolumes reports whether provider input paths may carry Windows
+// volume prefixes: PathSeparatorWindows, or PathSeparatorAuto on Windows.
+func (p *Provider) windowsVolumes() bool {
+	switch p.matcherOptions.PathSeparators {
+	case PathSeparatorWindows:
+		return true
+	case PathSeparatorAuto:
+		return runtime.GOOS == "windows"
+	default:
+		return false
+	}
+}
+
+// cleanPOSIXRelPath is cleanRelPath with "/" as the only separator.
+func cleanPOSIXRelPath(raw string) (string, error) {
+	if strings.HasPrefix(raw, "/") {
+		return "", ErrPathOutsideRoot
+	}
+
+	for part := range strings.SplitSeq(raw, "/") {
+		if part == ".." {
+			return "", ErrPathOutsideRoot
+		}
+	}
+
+	path := normalizePOSIXPath(raw)
+	if path == "" {
+		return "", ErrPathOutsideRoot
+	}
+
+	return path, nil
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestMatcherPathSeparatorPolicy(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionExclude, Pattern: `a\*b`},
+		{Action: ActionExclude, Pattern: `back\\slash.txt`},
+		{Action: ActionExclude, Pattern: `/logs/\[x].log`},
+		{Action: ActionExclude, Pattern: `tmp/*.bak`},
+	}
+
+	posix, err := NewMatcher(rules, MatcherOptions{PathSeparators: PathSeparatorPOSIX})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	cases := map[string]bool{
+		`a*b`:               false,
+		`axb`:               true,
+		`back\slash.txt`:    false,
+		`back/slash.txt`:    true,
+		`logs/[x].log`:      false,
+		`logs/x.log`:        true,
+		`tmp/a.bak`:         false,
+		`tmp\a.bak`:         true,
+		`C:\repo\tmp\a.bak`: true,
+	}
+
+	for path, want := range cases {
+		if got := posix.Included(path, false); got != want {
+			t.Fatalf("POSIX Included(%q)=%v, want %v", path, got, want)
+		}
+	}
+
+	auto, err := NewMatcher(rules[3:], MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if auto.Included(`tmp\a.bak`, false) {
+		t.Fatalf(`auto Included("tmp\a.bak")=true, want false`)
+	}
+
+	data, err := posix.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	var decoded Matcher
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	if !decoded.Included(`tmp\a.bak`, false) || decoded.Included(`back\slash.txt`, false) {
+		t.Fatalf("decoded matcher lost POSIX separator policy")
+	}
+
+	if _, err := NewMatcher(nil, MatcherOptions{PathSeparators: PathSeparatorPOSIX + 1}); !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("err=%v, want ErrInvalidOptions", err)
+	}
+}
+
+func TestProviderPathSeparatorPOSIX(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("backslash is a separator in Windows file names")
+	}
+
+	root := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, ".pathrules"), "a\\\\b.txt\n")
+	if err := os.WriteFile(filepath.Join(root, `a\b.txt`), nil, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	p, err := NewProvider(root, ProviderOptions{
+		RulesFileName:  ".pathrules",
+		MatcherOptions: MatcherOptions{PathSeparators: PathSeparatorPOSIX},
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if got, err := p.Included(`a\b.txt`, false); err != nil || got {
+		t.Fatalf(`Included("a\b.txt")=%v err=%v, want false`, got, err)
+	}
+
+	if got, err := p.Included(`a/b.txt`, false); err != nil || !got {
+		t.Fatalf(`Included("a/b.txt")=%v err=%v, want true`, got, err)
+	}
+
+	res, err := p.DecideInDir("", []DirEntry{{Name: `a\b.txt`}})
+	if err != nil || len(res) != 1 || res[0].Included {
+		t.Fatalf("DecideInDir=%+v err=%v, want excluded entry", res, err)
+	}
+
+	if _, err := p.Included("../x", false); !errors.Is(err, ErrPathOutsideRoot) {
+		t.Fatalf("err=%v, want ErrPathOutsideRoot", err)
+	}
+}
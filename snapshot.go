@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"crypto/sha256"
+	"maps"
+	"weak"
+)
+
+// Snapshot returns a view of p pinned to the rules files loaded so far.
+//
+// Refresh on p no longer affects the view, and Refresh on the view is a
+// no-op. Directories not loaded yet are read on first use by the view and
+// then stay pinned too; call Warmup before Snapshot to pin the whole tree
+// up front. The view keeps its own Stats counters and shares Close with p.
+func (p *Provider) Snapshot() (*Provider, error) {
+	if p == nil {
+		return nil, ErrNilProvider
+	}
+
+	cache := &dirMatcherCache{
+		shared: make(map[[sha256.Size]byte]weak.Pointer[Matcher]),
+	}
+
+	p.cache.mu.Lock()
+	cache.markers = maps.Clone(p.cache.markers)
+	cache.entries = make(map[string]*cachedDirMatcher, len(p.cache.entries))
+	for relDir, cached := range p.cache.entries {
+		// Loaded entries are never mutated, only replaced, so they are shared.
+		if !cached.loading {
+			cache.entries[relDir] = cached
+		}
+	}
+	p.cache.mu.Unlock()
+
+	snapshot := *p
+	snapshot.cache = cache
+	snapshot.frozen = true
+	return &snapshot, nil
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProviderSnapshot(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	rulesPath := filepath.Join(root, ".rules")
+	writeRulesFile(t, rulesPath, "*.tmp\n")
+	if err := os.MkdirAll(filepath.Join(root, "sub"), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	p, err := NewProvider(root, ProviderOptions{RulesFileName: ".rules"})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if err := p.Warmup(context.Background(), -1); err != nil {
+		t.Fatalf("Warmup: %v", err)
+	}
+
+	snap, err := p.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+
+	writeRulesFile(t, rulesPath, "*.log\n")
+	writeRulesFile(t, filepath.Join(root, "sub", ".rules"), "*.bin\n")
+	if dropped, err := p.Refresh(); err != nil || dropped != 2 {
+		t.Fatalf("Refresh: dropped=%d err=%v, want 2", dropped, err)
+	}
+
+	if dropped, err := snap.Refresh(); err != nil || dropped != 0 {
+		t.Fatalf("snapshot Refresh: dropped=%d err=%v, want no-op", dropped, err)
+	}
+
+	for path, want := range map[string][2]bool{
+		"sub/a.tmp": {true, false},
+		"sub/a.log": {false, true},
+		"sub/a.bin": {false, true},
+	} {
+		if got, err := p.Included(path, false); err != nil || got != want[0] {
+			t.Fatalf("Included(%s)=%v err=%v, want %v", path, got, err, want[0])
+		}
+
+		if got, err := snap.Included(path, false); err != nil || got != want[1] {
+			t.Fatalf("snapshot Included(%s)=%v err=%v, want %v", path, got, err, want[1])
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "fmt"
+
+// RulesSource supplies per-directory rules to a Provider in place of rules
+// files, e.g. from a database, object storage or a config service. Without
+// one, a Provider reads RulesFileName files itself.
+//
+// Load returns the rules of relDir (slash-separated, "" for root) and
+// whether the directory has rules at all. It may be called concurrently for
+// different directories and is called once per directory until Refresh.
+type RulesSource interface {
+	// Load returns rules of relDir and whether relDir has any.
+	Load(relDir string) ([]Rule, bool, error)
+}
+
+// RulesSourceFunc adapts a function to RulesSource.
+type RulesSourceFunc func(relDir string) ([]Rule, bool, error)
+
+// Load calls f(relDir).
+func (f RulesSourceFunc) Load(relDir string) ([]Rule, bool, error) {
+	return f(relDir)
+}
+
+// MapRulesSource is an in-memory RulesSource keyed by relative directory
+// ("" for root). It must not be modified while a Provider uses it.
+type MapRulesSource map[string][]Rule
+
+// Load returns rules stored for relDir.
+func (m MapRulesSource) Load(relDir string) ([]Rule, bool, error) {
+	rules, ok := m[relDir]
+	return rules, ok, nil
+}
+
+// loadSourceDirMatcher loads and compiles rules of one directory from the rules source.
+func (p *Provider) loadSourceDirMatcher(relDir string) (*Matcher, rulesFileStamp, error) {
+	rules, found, err := p.source.Load(relDir)
+	if err != nil {
+		return nil, rulesFileStamp{}, fmt.Errorf("load rules of %q: %w", relDir, err)
+	}
+
+	if !found {
+		return nil, rulesFileStamp{}, nil
+	}
+
+	stamp := rulesFileStamp{found: true}
+	p.cache.counters.filesLoaded.Add(1)
+	if err := p.checkRulesCount(len(rules)); err != nil {
+		p.cache.counters.parseErrors.Add(1)
+		return nil, stamp, fmt.Errorf("load rules of %q: %w", relDir, err)
+	}
+
+	if len(p.sections) > 0 {
+		rules = SelectSections(rules, p.sections...)
+	}
+
+	matcher, err := NewMatcher(rules, p.matcherOptions)
+	if err != nil {
+		p.cache.counters.parseErrors.Add(1)
+		return nil, stamp, fmt.Errorf("compile rules of %q: %w", relDir, err)
+	}
+
+	return matcher, stamp, nil
+}
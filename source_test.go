@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+)
+
+func TestProviderRulesSource(t *testing.T) {
+	t.Parallel()
+
+	source := MapRulesSource{
+		"":    MustParseRulesString("*.log\n"),
+		"sub": MustParseRulesString("!keep.log\n"),
+	}
+
+	p, err := NewProvider(t.TempDir(), ProviderOptions{RulesSource: source})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	for path, want := range map[string]bool{
+		"a.log":        false,
+		"a.txt":        true,
+		"sub/keep.log": true,
+		"sub/x.log":    false,
+	} {
+		included, err := p.Included(path, false)
+		if err != nil {
+			t.Fatalf("Included(%s): %v", path, err)
+		}
+
+		if included != want {
+			t.Fatalf("Included(%s)=%v, want %v", path, included, want)
+		}
+	}
+}
+
+func TestProviderRulesSourceRefreshAndErrors(t *testing.T) {
+	t.Parallel()
+
+	var loads atomic.Int32
+	errBackend := errors.New("backend down")
+	source := RulesSourceFunc(func(relDir string) ([]Rule, bool, error) {
+		loads.Add(1)
+		if relDir == "broken" {
+			return nil, false, errBackend
+		}
+
+		return nil, false, nil
+	})
+
+	p, err := NewProviderFS(fstest.MapFS{}, ProviderOptions{RulesSource: source})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	if _, err := p.Decide("a/b.txt", false); err != nil {
+		t.Fatalf("Decide: %v", err)
+	}
+
+	if got := loads.Load(); got != 2 {
+		t.Fatalf("loads=%d, want 2", got)
+	}
+
+	dropped, err := p.Refresh()
+	if err != nil || dropped != 2 {
+		t.Fatalf("Refresh=%d, %v, want 2", dropped, err)
+	}
+
+	if _, err := p.Decide("broken/x", false); !errors.Is(err, errBackend) {
+		t.Fatalf("err=%v, want errBackend", err)
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// MissingPathPolicy selects how DecideStat treats paths that do not exist.
+type MissingPathPolicy uint8
+
+const (
+	// MissingPathError fails missing paths with an error wrapping fs.ErrNotExist.
+	MissingPathError MissingPathPolicy = iota
+	// MissingPathFile decides missing paths as files; a trailing separator
+	// still marks a directory, see DirHint.
+	MissingPathFile
+	// MissingPathExclude reports missing paths as excluded without a match.
+	MissingPathExclude
+)
+
+// String returns policy name.
+func (m MissingPathPolicy) String() string {
+	switch m {
+	case MissingPathError:
+		return "error"
+	case MissingPathFile:
+		return "file"
+	case MissingPathExclude:
+		return "exclude"
+	default:
+		return fmt.Sprintf("missing-paths(%d)", uint8(m))
+	}
+}
+
+// valid reports whether policy value is supported.
+func (m MissingPathPolicy) valid() bool {
+	return m <= MissingPathExclude
+}
+
+// DecideStat returns the decision for a path relative to provider root,
+// detecting isDir with lstat instead of taking it from the caller.
+//
+// The path is stated where it is evaluated: under root, in the NewProviderFS
+// file system, or at the resolved location with CandidateSymlinksResolve.
+// Symlinks are not followed, so a symlink to a directory counts as a file,
+// like in Walk. Missing paths are handled by ProviderOptions.MissingPaths.
+func (p *Provider) DecideStat(relPath string) (MatchResult, error) {
+	if p == nil {
+		return MatchResult{}, ErrNilProvider
+	}
+
+	normalized, err := p.candidatePath(relPath)
+	if err != nil {
+		return MatchResult{}, err
+	}
+
+	info, err := p.lstat(normalized)
+	return p.decideStat(relPath, normalized, info, err)
+}
+
+// DecideStatFS is DecideStat stating the path in fsys, which must be rooted
+// at provider root, for example an overlay or snapshot of the tree.
+//
+// Symlinks are not followed when fsys implements fs.ReadLinkFS.
+func (p *Provider) DecideStatFS(fsys fs.FS, relPath string) (MatchResult, error) {
+	if p == nil {
+		return MatchResult{}, ErrNilProvider
+	}
+
+	if fsys == nil {
+		return MatchResult{}, fmt.Errorf("%w: nil fs", ErrInvalidOptions)
+	}
+
+	normalized, err := p.candidatePath(relPath)
+	if err != nil {
+		return MatchResult{}, err
+	}
+
+	info, err := fs.Lstat(fsys, fsName(normalized))
+	return p.decideStat(relPath, normalized, info, err)
+}
+
+// decideStat decides normalized with isDir taken from a lstat result.
+func (p *Provider) decideStat(relPath, normalized string, info fs.FileInfo, err error) (MatchResult, error) {
+	var isDir bool
+	switch {
+	case err == nil:
+		isDir = info.IsDir()
+	case !errors.Is(err, fs.ErrNotExist):
+		return MatchResult{}, err
+	case p.missingPaths == MissingPathFile:
+		isDir = dirHint(relPath, p.posixSeparators())
+	case p.missingPaths == MissingPathExclude:
+		return MatchResult{RuleIndex: -1}, nil
+	default:
+		return MatchResult{}, err
+	}
+
+	p.cache.counters.decisions.Add(1)
+	return p.decideResolved(normalized, isDir)
+}
+
+// lstat stats a root-relative path without following a final symlink.
+func (p *Provider) lstat(normalized string) (fs.FileInfo, error) {
+	switch {
+	case p.fsys != nil:
+		return fs.Lstat(p.fsys, fsName(normalized))
+	case p.osRoot != nil:
+		return p.osRoot.Lstat(fsName(normalized))
+	default:
+		return os.Lstat(filepath.Join(p.root, filepath.FromSlash(normalized)))
+	}
+}
+
+// fsName returns a root-relative path as an fs.FS name, "." for root.
+func fsName(normalized string) string {
+	if normalized == "" {
+		return "."
+	}
+
+	return normalized
+}
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestProviderDecideStat(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, ".pathrules"), "cache/\n")
+	writeRulesFile(t, filepath.Join(root, "src", "cache", "a.txt"), "x")
+	writeRulesFile(t, filepath.Join(root, "docs", "cache"), "x")
+
+	p, err := NewProvider(root, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if res, err := p.DecideStat("src/cache"); err != nil || res.Included {
+		t.Fatalf("DecideStat(src/cache)=%+v err=%v, want excluded directory", res, err)
+	}
+
+	if res, err := p.DecideStat("docs/cache"); err != nil || !res.Included {
+		t.Fatalf("DecideStat(docs/cache)=%+v err=%v, want included file", res, err)
+	}
+
+	if _, err := p.DecideStat("missing/cache"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("DecideStat(missing) err=%v, want fs.ErrNotExist", err)
+	}
+
+	if _, err := p.DecideStat("../outside"); !errors.Is(err, ErrPathOutsideRoot) {
+		t.Fatalf("DecideStat(../outside) err=%v, want ErrPathOutsideRoot", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, "link"), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	if err := os.Symlink(filepath.Join(root, "src", "cache"), filepath.Join(root, "link", "cache")); err != nil {
+		t.Skipf("symlink not available: %v", err)
+	}
+
+	if res, err := p.DecideStat("link/cache"); err != nil || !res.Included {
+		t.Fatalf("DecideStat(link/cache)=%+v err=%v, want symlink decided as file", res, err)
+	}
+
+	resolve, err := NewProvider(root, ProviderOptions{CandidateSymlinks: CandidateSymlinksResolve})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if res, err := resolve.DecideStat("link/cache"); err != nil || res.Included {
+		t.Fatalf("resolve DecideStat(link/cache)=%+v err=%v, want resolved directory excluded", res, err)
+	}
+}
+
+func TestProviderDecideStatMissingPaths(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules": {Data: []byte("cache/\n")},
+	}
+
+	file, err := NewProviderFS(fsys, ProviderOptions{MissingPaths: MissingPathFile})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	if res, err := file.DecideStat("cache"); err != nil || !res.Included {
+		t.Fatalf("DecideStat(cache)=%+v err=%v, want missing path decided as file", res, err)
+	}
+
+	if res, err := file.DecideStat("cache/"); err != nil || res.Included {
+		t.Fatalf("DecideStat(cache/)=%+v err=%v, want directory hint", res, err)
+	}
+
+	exclude, err := NewProviderFS(fsys, ProviderOptions{MissingPaths: MissingPathExclude})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	if res, err := exclude.DecideStat("a.txt"); err != nil || res.Included || res.Matched || res.RuleIndex != -1 {
+		t.Fatalf("DecideStat(a.txt)=%+v err=%v, want unmatched exclusion", res, err)
+	}
+
+	if _, err := NewProviderFS(fsys, ProviderOptions{MissingPaths: MissingPathExclude + 1}); !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("err=%v, want ErrInvalidOptions", err)
+	}
+}
+
+func TestProviderDecideStatFS(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewProviderFS(fstest.MapFS{".pathrules": {Data: []byte("cache/\n")}}, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	view := fstest.MapFS{"cache/a.txt": {Data: []byte("x")}}
+	if res, err := p.DecideStatFS(view, "cache"); err != nil || res.Included {
+		t.Fatalf("DecideStatFS(cache)=%+v err=%v, want excluded directory", res, err)
+	}
+
+	if res, err := p.DecideStatFS(view, "cache/a.txt"); err != nil || res.Included {
+		t.Fatalf("DecideStatFS(cache/a.txt)=%+v err=%v, want excluded by parent", res, err)
+	}
+
+	if _, err := p.DecideStatFS(nil, "cache"); !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("err=%v, want ErrInvalidOptions", err)
+	}
+}
+
+func TestMissingPathPolicyString(t *testing.T) {
+	t.Parallel()
+
+	if got := MissingPathFile.String(); got != "file" {
+		t.Fatalf("String()=%q, want file", got)
+	}
+
+	if got := MissingPathPolicy(9).String(); got != "missing-paths(9)" {
+		t.Fatalf("String()=%q, want missing-paths(9)", got)
+	}
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "sync/atomic"
+
+// ProviderStats is a point-in-time snapshot of provider counters.
+type ProviderStats struct {
+	// CacheHits counts directory matcher lookups served from cache.
+	CacheHits uint64 `json:"cache_hits" yaml:"cache_hits"`
+	// CacheMisses counts directory matcher lookups that read the file system.
+	CacheMisses uint64 `json:"cache_misses" yaml:"cache_misses"`
+	// FilesLoaded counts rules files read and compiled, including failed ones.
+	FilesLoaded uint64 `json:"files_loaded" yaml:"files_loaded"`
+	// SharedMatchers counts rules files served by a matcher compiled for identical content.
+	SharedMatchers uint64 `json:"shared_matchers" yaml:"shared_matchers"`
+	// ParseErrors counts rules files that failed to parse or compile.
+	ParseErrors uint64 `json:"parse_errors" yaml:"parse_errors"`
+	// Decisions counts evaluated paths (one per Decide call, one per DecideInDir entry).
+	Decisions uint64 `json:"decisions" yaml:"decisions"`
+	// CachedDirs is the number of directories currently cached.
+	CachedDirs int `json:"cached_dirs" yaml:"cached_dirs"`
+}
+
+// providerCounters holds provider counters updated without locks.
+type providerCounters struct {
+	// cacheHits counts cached directory matcher lookups.
+	cacheHits atomic.Uint64
+	// cacheMisses counts directory matcher loads.
+	cacheMisses atomic.Uint64
+	// filesLoaded counts found rules files.
+	filesLoaded atomic.Uint64
+	// sharedMatchers counts rules files reusing a compiled matcher.
+	sharedMatchers atomic.Uint64
+	// parseErrors counts rules files failing to compile.
+	parseErrors atomic.Uint64
+	// decisions counts evaluated paths.
+	decisions atomic.Uint64
+}
+
+// Stats returns provider counters since creation.
+//
+// Scope views share counters with their parent. Counters are read one by one,
+// so a snapshot taken under concurrent load may be slightly inconsistent.
+func (p *Provider) Stats() ProviderStats {
+	if p == nil {
+		return ProviderStats{}
+	}
+
+	p.cache.mu.Lock()
+	cachedDirs := len(p.cache.entries)
+	p.cache.mu.Unlock()
+
+	c := &p.cache.counters
+	return ProviderStats{
+		CacheHits:      c.cacheHits.Load(),
+		CacheMisses:    c.cacheMisses.Load(),
+		FilesLoaded:    c.filesLoaded.Load(),
+		SharedMatchers: c.sharedMatchers.Load(),
+		ParseErrors:    c.parseErrors.Load(),
+		Decisions:      c.decisions.Load(),
+		CachedDirs:     cachedDirs,
+	}
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestProviderStats(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":     {Data: []byte("*.tmp\n")},
+		"bad/.pathrules": {Data: []byte("#pragma default=nope\n")},
+		"a/file.txt":     {Data: []byte("x")},
+	}
+
+	p, err := NewProviderFS(fsys, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	if _, err := p.Decide("a/file.txt", false); err != nil {
+		t.Fatalf("Decide: %v", err)
+	}
+
+	if _, err := p.Decide("a/other.tmp", false); err != nil {
+		t.Fatalf("Decide: %v", err)
+	}
+
+	if _, err := p.Decide("bad/x.txt", false); err == nil {
+		t.Fatalf("Decide(bad/x.txt) succeeded, want parse error")
+	}
+
+	scoped, err := p.Scope("a")
+	if err != nil {
+		t.Fatalf("Scope: %v", err)
+	}
+
+	if _, err := scoped.DecideInDir("", []DirEntry{{Name: "x"}, {Name: "y"}}); err != nil {
+		t.Fatalf("DecideInDir: %v", err)
+	}
+
+	want := ProviderStats{
+		CacheHits:   5,
+		CacheMisses: 3,
+		FilesLoaded: 2,
+		ParseErrors: 1,
+		Decisions:   5,
+		CachedDirs:  3,
+	}
+
+	if got := p.Stats(); got != want {
+		t.Fatalf("Stats()=%+v, want %+v", got, want)
+	}
+}
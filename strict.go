@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DecideErr is Decide failing with ErrInvalidPath for empty, absolute or
+// traversal ("..") paths when MatcherOptions.StrictPaths is set, as
+// Provider does, instead of excluding them. Without StrictPaths it never
+// returns an error.
+func (m *Matcher) DecideErr(path string, isDir bool) (MatchResult, error) {
+	if m.strictPaths {
+		if err := m.checkStrictPath(path); err != nil {
+			return MatchResult{}, err
+		}
+	}
+
+	return m.decidePath(path, isDir), nil
+}
+
+// rejectsPath reports whether StrictPaths is set and path fails its check.
+func (m *Matcher) rejectsPath(path string) bool {
+	return m.strictPaths && m.checkStrictPath(path) != nil
+}
+
+// checkStrictPath returns ErrInvalidPath when path is empty, absolute or
+// leads outside of its root with ".." components.
+func (m *Matcher) checkStrictPath(path string) error {
+	trimmed := strings.TrimSpace(path)
+	if !m.posixSeparators {
+		if windowsVolumeLen(trimmed) > 0 {
+			return fmt.Errorf("%w: %q is absolute", ErrInvalidPath, path)
+		}
+
+		trimmed = strings.ReplaceAll(trimmed, `\`, "/")
+	}
+
+	if strings.HasPrefix(trimmed, "/") {
+		return fmt.Errorf("%w: %q is absolute", ErrInvalidPath, path)
+	}
+
+	for part := range strings.SplitSeq(trimmed, "/") {
+		if part == ".." {
+			return fmt.Errorf("%w: %q contains \"..\"", ErrInvalidPath, path)
+		}
+	}
+
+	if cleanSlashPath(trimmed) == "" {
+		return fmt.Errorf("%w: %q is empty", ErrInvalidPath, path)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMatcherDecideErrStrictPaths(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher([]Rule{{Action: ActionExclude, Pattern: "*.tmp"}}, MatcherOptions{StrictPaths: true})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	invalid := []string{"", " ", ".", "./", "/etc/a.txt", `C:\repo\a.txt`, `\\server\share\a.txt`, "../a.txt", "a/../b.txt", `a\..\b.txt`}
+	for _, path := range invalid {
+		if _, err := m.DecideErr(path, false); !errors.Is(err, ErrInvalidPath) {
+			t.Fatalf("DecideErr(%q) err=%v, want ErrInvalidPath", path, err)
+		}
+
+		if res := m.Decide(path, false); res.Included || res.Matched || res.RuleIndex != -1 {
+			t.Fatalf("Decide(%q)=%+v, want unmatched exclusion", path, res)
+		}
+	}
+
+	for _, path := range []string{"a.txt", "./a.txt", "src//a.txt", "src/./a.txt", "src/", "..a/b.txt"} {
+		res, err := m.DecideErr(path, false)
+		if err != nil || !res.Included {
+			t.Fatalf("DecideErr(%q)=%+v err=%v, want included", path, res, err)
+		}
+	}
+
+	if res, err := m.DecideErr("a.tmp", false); err != nil || res.Included {
+		t.Fatalf("DecideErr(a.tmp)=%+v err=%v, want excluded", res, err)
+	}
+
+	if _, err := m.Decider().Excluded("../a.tmp", false); !errors.Is(err, ErrInvalidPath) {
+		t.Fatalf("Decider().Excluded err=%v, want ErrInvalidPath", err)
+	}
+}
+
+func TestMatcherDecideErrLenient(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher(nil, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if res, err := m.DecideErr("/a/../b.txt", false); err != nil || !res.Included {
+		t.Fatalf("DecideErr=%+v err=%v, want normalized path included", res, err)
+	}
+}
+
+func TestMatcherStrictPathsPOSIX(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher(nil, MatcherOptions{StrictPaths: true, PathSeparators: PathSeparatorPOSIX})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if _, err := m.DecideErr(`..\a.txt`, false); err != nil {
+		t.Fatalf(`DecideErr("..\a.txt") err=%v, want backslash kept as name`, err)
+	}
+
+	if _, err := m.DecideErr("../a.txt", false); !errors.Is(err, ErrInvalidPath) {
+		t.Fatalf("DecideErr(../a.txt) err=%v, want ErrInvalidPath", err)
+	}
+}
+
+func TestMatcherStrictPathsBinary(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher(nil, MatcherOptions{StrictPaths: true})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	var decoded Matcher
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	if _, err := decoded.DecideErr("../a.txt", false); !errors.Is(err, ErrInvalidPath) {
+		t.Fatalf("decoded DecideErr err=%v, want ErrInvalidPath", err)
+	}
+}
+
+func TestProviderIgnoresStrictPaths(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewProvider(t.TempDir(), ProviderOptions{
+		BaseRules:      []Rule{{Action: ActionExclude, Pattern: "*.tmp"}},
+		MatcherOptions: MatcherOptions{StrictPaths: true},
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	if included, err := p.Included("a.tmp", false); err != nil || included {
+		t.Fatalf("Included(a.tmp)=%v err=%v, want excluded", included, err)
+	}
+
+	if ok, err := p.ShouldDescend(""); err != nil || !ok {
+		t.Fatalf("ShouldDescend(root)=%v err=%v, want true", ok, err)
+	}
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// CandidateSymlinkPolicy selects how Provider treats candidate paths that
+// pass through symlinks (and, on Windows, junctions and mount points).
+type CandidateSymlinkPolicy uint8
+
+const (
+	// CandidateSymlinksAllow evaluates paths as given without resolving them.
+	CandidateSymlinksAllow CandidateSymlinkPolicy = iota
+	// CandidateSymlinksDeny fails paths resolving outside root with
+	// ErrPathOutsideRoot; other paths are evaluated as given.
+	CandidateSymlinksDeny
+	// CandidateSymlinksResolve evaluates the resolved location relative to
+	// root; paths resolving outside root fail with ErrPathOutsideRoot.
+	CandidateSymlinksResolve
+)
+
+// String returns policy name.
+func (p CandidateSymlinkPolicy) String() string {
+	switch p {
+	case CandidateSymlinksAllow:
+		return "allow"
+	case CandidateSymlinksDeny:
+		return "deny"
+	case CandidateSymlinksResolve:
+		return "resolve"
+	default:
+		return fmt.Sprintf("candidate-symlinks(%d)", uint8(p))
+	}
+}
+
+// valid reports whether policy value is supported.
+func (p CandidateSymlinkPolicy) valid() bool {
+	return p <= CandidateSymlinksResolve
+}
+
+// resolveCandidate applies the candidate symlink policy to a normalized
+// root-relative path and returns the path to evaluate.
+//
+// Missing trailing components are kept after resolving the longest existing
+// ancestor. Providers reading rules from fs.FS do not resolve candidates.
+func (p *Provider) resolveCandidate(normalized string) (string, error) {
+	if p.candidateSymlinks == CandidateSymlinksAllow || p.fsys != nil {
+		return normalized, nil
+	}
+
+	resolved, err := resolveExisting(filepath.Join(p.root, filepath.FromSlash(normalized)), p.followJunctions)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", normalized, err)
+	}
+
+	rel, err := filepath.Rel(p.resolvedRoot, resolved)
+	if err != nil || rel == "." || !isPathWithinRoot(p.resolvedRoot, resolved) {
+		return "", fmt.Errorf("%w: %s resolves to %s", ErrPathOutsideRoot, normalized, resolved)
+	}
+
+	if p.candidateSymlinks == CandidateSymlinksDeny {
+		return normalized, nil
+	}
+
+	return filepath.ToSlash(rel), nil
+}
+
+// resolveExisting resolves links of the longest existing ancestor of path
+// and appends the missing components unchanged.
+func resolveExisting(path string, followJunctions bool) (string, error) {
+	missing := ""
+	for {
+		resolved, err := resolveLinks(path, followJunctions)
+		if err == nil {
+			return filepath.Join(resolved, missing), nil
+		}
+
+		parent := filepath.Dir(path)
+		if !os.IsNotExist(err) || parent == path {
+			return "", err
+		}
+
+		missing = filepath.Join(filepath.Base(path), missing)
+		path = parent
+	}
+}
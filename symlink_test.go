@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProviderCandidateSymlinks(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	outside := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "secret"), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	writeRulesFile(t, filepath.Join(root, ".pathrules"), "secret/\n")
+	writeRulesFile(t, filepath.Join(root, "secret", "key"), "x")
+	if err := os.Symlink(filepath.Join(root, "secret"), filepath.Join(root, "link")); err != nil {
+		t.Skipf("symlink not available: %v", err)
+	}
+
+	if err := os.Symlink(outside, filepath.Join(root, "out")); err != nil {
+		t.Skipf("symlink not available: %v", err)
+	}
+
+	cases := []struct {
+		policy  CandidateSymlinkPolicy
+		linked  bool
+		outside error
+	}{
+		{policy: CandidateSymlinksAllow, linked: true},
+		{policy: CandidateSymlinksDeny, linked: true, outside: ErrPathOutsideRoot},
+		{policy: CandidateSymlinksResolve, linked: false, outside: ErrPathOutsideRoot},
+	}
+
+	for _, tc := range cases {
+		p, err := NewProvider(root, ProviderOptions{CandidateSymlinks: tc.policy})
+		if err != nil {
+			t.Fatalf("NewProvider(%s): %v", tc.policy, err)
+		}
+
+		if got, err := p.Included("link/key", false); err != nil || got != tc.linked {
+			t.Fatalf("%s: Included(link/key)=%v err=%v, want %v", tc.policy, got, err, tc.linked)
+		}
+
+		res, err := p.DecideInDir("link", []DirEntry{{Name: "key"}})
+		if err != nil || res[0].Included != tc.linked {
+			t.Fatalf("%s: DecideInDir(link)=%+v err=%v, want included=%v", tc.policy, res, err, tc.linked)
+		}
+
+		if _, err := p.Included("out/file", false); !errors.Is(err, tc.outside) {
+			t.Fatalf("%s: Included(out/file) err=%v, want %v", tc.policy, err, tc.outside)
+		}
+	}
+
+	if _, err := NewProvider(root, ProviderOptions{CandidateSymlinks: CandidateSymlinksResolve + 1}); !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("err=%v, want ErrInvalidOptions", err)
+	}
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "slices"
+
+// DecideTagged returns the decision for path considering only rules whose
+// Tags contain at least one of tags; untagged rules and calls without tags
+// consider no user rules. Dialect default rules always apply; rules with
+// Meta never match.
+//
+// Decision policy is the same as Decide. The decision memo, coverage and
+// trace are not used.
+func (m *Matcher) DecideTagged(path string, isDir bool, tags ...string) MatchResult {
+	if m.rejectsPath(path) {
+		return MatchResult{RuleIndex: -1}
+	}
+
+	candidate := m.normalizeCandidate(path)
+	isDir = isDir || m.dirHint(path)
+
+	keep := func(i int) bool {
+		user := i - m.implicitBefore
+		if user < 0 || user >= m.ruleCount {
+			return true
+		}
+
+		source := &m.compiled[i].source
+		return source.Meta == nil && slices.ContainsFunc(source.Tags, func(tag string) bool {
+			return slices.Contains(tags, tag)
+		})
+	}
+
+	return m.resolveAction(candidate, isDir, m.decideKeptCandidate(candidate, isDir, keep, keep))
+}
+
+// IncludedTagged reports whether path is included considering only rules with tags.
+func (m *Matcher) IncludedTagged(path string, isDir bool, tags ...string) bool {
+	return m.DecideTagged(path, isDir, tags...).Included
+}
+
+// decideKeptCandidate returns the decision for a normalized candidate
+// considering rules accepted by keep, and by parentKeep for parent exclusion.
+func (m *Matcher) decideKeptCandidate(candidate string, isDir bool, parentKeep, keep func(int) bool) MatchResult {
+	if m.overPathLimit(candidate) {
+		return MatchResult{RuleIndex: -1}
+	}
+
+	if m.dialect.parentExclusion() {
+		for i := 0; i < len(candidate); i++ {
+			if candidate[i] != '/' {
+				continue
+			}
+
+			if res := m.decideKept(candidate[:i], true, parentKeep); res.Matched && !res.Included {
+				return m.userResult(res)
+			}
+		}
+	}
+
+	return m.userResult(m.decideKept(candidate, isDir, keep))
+}
+
+// decideKept evaluates rules accepted by keep for a normalized candidate.
+func (m *Matcher) decideKept(candidate string, isDir bool, keep func(int) bool) MatchResult {
+	res := MatchResult{
+		Included:  m.defaultAction == ActionInclude,
+		RuleIndex: -1,
+	}
+
+	if winner := m.prioritizedWinner(candidate, isDir, keep); winner >= 0 {
+		res.Matched = true
+		res.RuleIndex = winner
+		res.Action = m.compiled[winner].source.Action
+		res.Included = res.Action != ActionExclude
+	}
+
+	return res
+}
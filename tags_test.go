@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "testing"
+
+func TestMatcherDecideTagged(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionExclude, Pattern: "*.tmp", Tags: []string{"pack", "sign"}},
+		{Action: ActionExclude, Pattern: "*.sig", Tags: []string{"sign"}},
+		{Action: ActionInclude, Pattern: "keep.tmp", Tags: []string{"pack"}},
+		{Action: ActionExclude, Pattern: "*.md"},
+	}
+
+	m, err := NewMatcher(rules, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	cases := []struct {
+		path     string
+		tags     []string
+		included bool
+		rule     int
+	}{
+		{path: "keep.tmp", tags: []string{"pack"}, included: true, rule: 2},
+		{path: "keep.tmp", tags: []string{"sign"}, included: false, rule: 0},
+		{path: "a.sig", tags: []string{"pack"}, included: true, rule: -1},
+		{path: "a.sig", tags: []string{"pack", "sign"}, included: false, rule: 1},
+		{path: "a.md", tags: []string{"pack"}, included: true, rule: -1},
+		{path: "a.tmp", included: true, rule: -1},
+	}
+
+	for _, tc := range cases {
+		res := m.DecideTagged(tc.path, false, tc.tags...)
+		if res.Included != tc.included || res.RuleIndex != tc.rule {
+			t.Fatalf("DecideTagged(%q, %v)=%+v, want included=%v rule=%d", tc.path, tc.tags, res, tc.included, tc.rule)
+		}
+	}
+
+	if m.Included("a.md", false) || m.Included("a.sig", false) {
+		t.Fatalf("Decide must consider every rule regardless of tags")
+	}
+
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	var decoded Matcher
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	if decoded.IncludedTagged("a.sig", false, "sign") {
+		t.Fatalf("decoded matcher lost rule tags")
+	}
+}
+
+func TestMatcherDecideTaggedParentExclusion(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher([]Rule{
+		{Action: ActionExclude, Pattern: "build/", Tags: []string{"pack"}},
+		{Action: ActionInclude, Pattern: "*.sig", Tags: []string{"pack"}},
+	}, MatcherOptions{Dialect: DialectGit})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if res := m.DecideTagged("build/a.sig", false, "pack"); res.Included || res.RuleIndex != 0 {
+		t.Fatalf("DecideTagged=%+v, want excluded by parent rule 0", res)
+	}
+}
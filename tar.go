@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// WriteTarFiltered writes a tar archive of included regular files under
+// root to w, walking it with provider p.
+//
+// Like WriteZipFiltered, root is the OS directory p is rooted at, entries
+// are named by provider-relative paths, excluded directories are not read
+// and non-regular files are skipped. The stream is not compressed; wrap w
+// with gzip.NewWriter for a .tar.gz.
+func WriteTarFiltered(w io.Writer, root string, p *Provider) error {
+	tw := tar.NewWriter(w)
+	err := p.Walk(context.Background(), func(relPath string, d fs.DirEntry) error {
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		return addTarFile(tw, filepath.Join(root, filepath.FromSlash(relPath)), relPath, d)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("close tar: %w", err)
+	}
+
+	return nil
+}
+
+// addTarFile writes one OS file to tw under name.
+func addTarFile(tw *tar.Writer, fullPath string, name string, d fs.DirEntry) error {
+	info, err := d.Info()
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", fullPath, err)
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return fmt.Errorf("tar header %s: %w", fullPath, err)
+	}
+
+	header.Name = name
+
+	src, err := os.Open(fullPath)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", fullPath, err)
+	}
+	defer func() { _ = src.Close() }()
+
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("tar entry %s: %w", name, err)
+	}
+
+	if _, err := io.Copy(tw, src); err != nil {
+		return fmt.Errorf("tar copy %s: %w", fullPath, err)
+	}
+
+	return nil
+}
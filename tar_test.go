@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestWriteTarFiltered(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, ".pathrules"), "*.log\n.pathrules\nbuild/\n")
+	writeRulesFile(t, filepath.Join(root, "a", "b.txt"), "hello")
+	writeRulesFile(t, filepath.Join(root, "a", "c.log"), "x")
+	writeRulesFile(t, filepath.Join(root, "build", "out.bin"), "bin")
+
+	p, err := NewProvider(root, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTarFiltered(&buf, root, p); err != nil {
+		t.Fatalf("WriteTarFiltered: %v", err)
+	}
+
+	tr := tar.NewReader(&buf)
+	var names []string
+	contents := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("ReadAll: %v", err)
+		}
+
+		names = append(names, header.Name)
+		contents[header.Name] = string(data)
+	}
+
+	if want := []string{"a/b.txt"}; !slices.Equal(names, want) {
+		t.Fatalf("entries=%v, want %v", names, want)
+	}
+
+	if contents["a/b.txt"] != "hello" {
+		t.Fatalf("content=%q, want hello", contents["a/b.txt"])
+	}
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns action name; custom actions are formatted as numbers.
+func (a Action) String() string {
+	switch a {
+	case ActionUnknown:
+		return "unknown"
+	case ActionExclude:
+		return "exclude"
+	case ActionInclude:
+		return "include"
+	default:
+		return strconv.Itoa(int(a))
+	}
+}
+
+// MarshalText encodes action as its name, custom actions as numbers.
+func (a Action) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes an action name ("include", "exclude", "unknown")
+// or a number; other values fail with ErrInvalidRule.
+func (a *Action) UnmarshalText(text []byte) error {
+	switch name := string(text); name {
+	case "unknown":
+		*a = ActionUnknown
+	case "exclude":
+		*a = ActionExclude
+	case "include":
+		*a = ActionInclude
+	default:
+		n, err := strconv.ParseUint(name, 10, 8)
+		if err != nil {
+			return fmt.Errorf("%w: unknown action %q", ErrInvalidRule, name)
+		}
+
+		*a = Action(n)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON decodes an action name or a JSON number, so documents
+// written before actions had names keep working.
+func (a *Action) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case string(trimmed) == "null":
+		return nil
+	case len(trimmed) > 0 && trimmed[0] == '"':
+		var name string
+		if err := json.Unmarshal(trimmed, &name); err != nil {
+			return err
+		}
+
+		return a.UnmarshalText([]byte(name))
+	default:
+		return a.UnmarshalText(trimmed)
+	}
+}
+
+// UnmarshalText decodes the compact one-line rules file form of a rule:
+// "pattern" excludes, "!pattern" includes, "\!" and "\#" escape.
+func (r *Rule) UnmarshalText(text []byte) error {
+	line := string(text)
+	if strings.ContainsAny(line, "\r\n") {
+		return fmt.Errorf("%w: compact rule %q spans lines", ErrInvalidRule, line)
+	}
+
+	rules, err := ParseRulesString(line)
+	if err != nil {
+		return err
+	}
+
+	if len(rules) != 1 {
+		return fmt.Errorf("%w: compact rule %q has no pattern", ErrInvalidRule, line)
+	}
+
+	*r = Rule{Action: rules[0].Action, Pattern: rules[0].Pattern}
+	return nil
+}
+
+// UnmarshalJSON decodes a rule object or the compact string form accepted
+// by UnmarshalText.
+func (r *Rule) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '"' {
+		var line string
+		if err := json.Unmarshal(trimmed, &line); err != nil {
+			return err
+		}
+
+		return r.UnmarshalText([]byte(line))
+	}
+
+	// ruleObject drops Rule methods to decode the object form.
+	type ruleObject Rule
+	return json.Unmarshal(data, (*ruleObject)(r))
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestActionText(t *testing.T) {
+	t.Parallel()
+
+	for _, a := range []Action{ActionUnknown, ActionExclude, ActionInclude, ActionCustom + 3} {
+		text, err := a.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText(%d): %v", a, err)
+		}
+
+		var got Action
+		if err := got.UnmarshalText(text); err != nil || got != a {
+			t.Fatalf("UnmarshalText(%q)=%d err=%v, want %d", text, got, err, a)
+		}
+	}
+
+	var a Action
+	if err := a.UnmarshalText([]byte("keep")); !errors.Is(err, ErrInvalidRule) {
+		t.Fatalf("err=%v, want ErrInvalidRule", err)
+	}
+}
+
+func TestRuleJSON(t *testing.T) {
+	t.Parallel()
+
+	var rules []Rule
+	doc := `["!keep.tmp", "*.tmp", "\\!bang", {"pattern": "a/", "action": "exclude", "priority": 2}, {"pattern": "b", "action": 2}]`
+	if err := json.Unmarshal([]byte(doc), &rules); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	want := []Rule{
+		{Action: ActionInclude, Pattern: "keep.tmp"},
+		{Action: ActionExclude, Pattern: "*.tmp"},
+		{Action: ActionExclude, Pattern: "!bang"},
+		{Action: ActionExclude, Pattern: "a/", Priority: 2},
+		{Action: ActionInclude, Pattern: "b"},
+	}
+
+	if !reflect.DeepEqual(rules, want) {
+		t.Fatalf("rules=%+v, want %+v", rules, want)
+	}
+
+	data, err := json.Marshal(want[0])
+	if err != nil || string(data) != `{"pattern":"keep.tmp","action":"include"}` {
+		t.Fatalf("Marshal=%s err=%v", data, err)
+	}
+
+	for _, bad := range []string{`"# comment"`, `"a\nb"`} {
+		var r Rule
+		if err := json.Unmarshal([]byte(bad), &r); !errors.Is(err, ErrInvalidRule) {
+			t.Fatalf("Unmarshal(%s) err=%v, want ErrInvalidRule", bad, err)
+		}
+	}
+}
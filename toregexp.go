@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RegexpRule is one RE2 expression with the action applied when it matches.
+type RegexpRule struct {
+	// Pattern is RE2 source matched against normalized relative file paths.
+	Pattern string `json:"pattern" yaml:"pattern"`
+	// Action is applied when Pattern matches.
+	Action Action `json:"action" yaml:"action"`
+}
+
+// RegexpSet is a regexp form of matcher decisions.
+//
+// Decision: the last matching rule wins, DefaultAction when none matched.
+// Consecutive rules with the same action are merged into one alternation.
+type RegexpSet struct {
+	// Rules are merged regexp rules in evaluation order.
+	Rules []RegexpRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+	// DefaultAction is applied when no rule matched.
+	DefaultAction Action `json:"default_action" yaml:"default_action"`
+}
+
+// ToRegexp returns RE2 expressions equivalent to matcher decisions for
+// normalized relative file paths ("dir/name", no leading "./" or "/").
+//
+// Directory-only rules ("build/") match paths inside the directory; the
+// directory path itself cannot be told apart from a file. Case-insensitive
+// matchers and rules folded by SmartCase emit ASCII letters as classes of
+// both cases ("[aA]") rather than "(?i)", which would also fold non-ASCII
+// letters the matcher keeps apart. Rules with Priority are emitted in
+// priority order.
+//
+// Matchers with dialects other than DialectDefault, with MaxPathLength, with
+// regexp-syntax rules, custom actions or metadata predicates fail with
+// errors.ErrUnsupported.
+func (m *Matcher) ToRegexp() (*RegexpSet, error) {
+	if m.dialect != DialectDefault {
+		return nil, fmt.Errorf("%w: regexp export of %s dialect", errors.ErrUnsupported, m.dialect)
+	}
+
+	if m.maxPathLength > 0 {
+		return nil, fmt.Errorf("%w: regexp export with MaxPathLength %d", errors.ErrUnsupported, m.maxPathLength)
+	}
+
+	sources := make([]Rule, len(m.compiled))
+	for i := range m.compiled {
+		sources[i] = m.compiled[i].source
+	}
+
+	set := &RegexpSet{DefaultAction: m.defaultAction}
+	var group []string
+	for _, i := range priorityOrder(sources) {
+		rule := sources[i]
+		index := m.userResult(MatchResult{RuleIndex: i}).RuleIndex
+		if rule.Syntax != PatternGlob {
+			return nil, fmt.Errorf("%w: rule %d (%q) has regexp syntax", errors.ErrUnsupported, index, rule.Pattern)
+		}
+
+		if rule.Meta != nil {
+			return nil, fmt.Errorf("%w: rule %d (%q) has metadata predicate", errors.ErrUnsupported, index, rule.Pattern)
+		}
+
+		if rule.Action.custom() {
+			return nil, fmt.Errorf("%w: rule %d (%q) has custom action %s", errors.ErrUnsupported, index, rule.Pattern, rule.Action)
+		}
+
+		if len(set.Rules) > 0 && set.Rules[len(set.Rules)-1].Action != rule.Action {
+			set.Rules[len(set.Rules)-1].Pattern = joinRegexpAlternation(group)
+			group = group[:0]
+		}
+
+		if len(group) == 0 {
+			set.Rules = append(set.Rules, RegexpRule{Action: rule.Action})
+		}
+
+		fold := m.caseInsensitive || m.compiled[i].foldCase
+		group = append(group, ruleToRegexp(rule.Pattern, m.posixSeparators, fold))
+	}
+
+	if len(group) > 0 {
+		set.Rules[len(set.Rules)-1].Pattern = joinRegexpAlternation(group)
+	}
+
+	return set, nil
+}
+
+// Single returns one expression and the action for paths it matches;
+// other paths get the opposite action.
+//
+// Leading rules with DefaultAction never change a decision and are dropped.
+// When include and exclude rules still alternate, one RE2 expression cannot
+// express the decision and errors.ErrUnsupported is returned.
+func (s *RegexpSet) Single() (string, Action, error) {
+	rules := s.Rules
+	for len(rules) > 0 && rules[0].Action == s.DefaultAction {
+		rules = rules[1:]
+	}
+
+	switch len(rules) {
+	case 0:
+		// Empty expression matches every path.
+		return "", s.DefaultAction, nil
+	case 1:
+		return rules[0].Pattern, rules[0].Action, nil
+	default:
+		return "", ActionUnknown, fmt.Errorf("%w: %d alternating rule groups need separate regexps", errors.ErrUnsupported, len(rules))
+	}
+}
+
+// ruleToRegexp converts one DialectDefault glob pattern to RE2 source,
+// matching ASCII letters in both cases with fold.
+func ruleToRegexp(raw string, backslashEscapes, fold bool) string {
+	pattern := strings.TrimSpace(raw)
+	if !backslashEscapes {
+		pattern = normalizePattern(pattern)
+	}
+
+	anchored := strings.HasPrefix(pattern, "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.Trim(pattern, "/")
+
+	suffix := "$"
+	if dirOnly {
+		suffix = "/"
+	}
+
+	if !anchored && !strings.Contains(pattern, "/") {
+		return `(?:^|/)` + globToRegexComponent(pattern, fold) + suffix
+	}
+
+	prefix := `^(?:.*/)?`
+	if anchored {
+		prefix = `^`
+	}
+
+	return prefix + globToRegexPath(pattern, fold) + suffix
+}
+
+// joinRegexpAlternation joins expressions into one alternation.
+func joinRegexpAlternation(exprs []string) string {
+	if len(exprs) == 1 {
+		return exprs[0]
+	}
+
+	var b strings.Builder
+	for i, expr := range exprs {
+		if i > 0 {
+			b.WriteByte('|')
+		}
+
+		b.WriteString("(?:")
+		b.WriteString(expr)
+		b.WriteByte(')')
+	}
+
+	return b.String()
+}
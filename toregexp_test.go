@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+// regexpSetIncluded evaluates regexp set decision for one path.
+func regexpSetIncluded(t *testing.T, set *RegexpSet, path string) bool {
+	t.Helper()
+
+	action := set.DefaultAction
+	for _, rule := range set.Rules {
+		if regexp.MustCompile(rule.Pattern).MatchString(path) {
+			action = rule.Action
+		}
+	}
+
+	return action == ActionInclude
+}
+
+func TestMatcherToRegexpEquivalence(t *testing.T) {
+	t.Parallel()
+
+	rules := []Rule{
+		{Action: ActionExclude, Pattern: "*.tmp"},
+		{Action: ActionExclude, Pattern: "build/"},
+		{Action: ActionExclude, Pattern: "/dist"},
+		{Action: ActionInclude, Pattern: "keep.tmp"},
+		{Action: ActionInclude, Pattern: "docs/**/*.md"},
+		{Action: ActionExclude, Pattern: "file[0-2].txt"},
+		{Action: ActionExclude, Pattern: "cache/**"},
+		{Action: ActionExclude, Pattern: "a?c/*.bin"},
+		{Action: ActionExclude, Pattern: "ä.txt"},
+		{Action: ActionExclude, Pattern: "grüß/*.log"},
+		{Action: ActionExclude, Pattern: "[a-c]é?.dat"},
+		{Action: ActionExclude, Pattern: "k.bin"},
+		{Action: ActionExclude, Pattern: "[x-].cfg"},
+	}
+
+	paths := []string{
+		"a.tmp", "x/keep.tmp", "build/out.o", "src/build/x", "build", "dist", "x/dist",
+		"docs/a.md", "docs/x/y/b.md", "docs/a.txt", "file1.txt", "file3.txt",
+		"cache/x", "cache", "p/cache/a/b", "abc/x.bin", "x/abc/y.bin", "ac/x.bin", "README.md",
+		"ä.txt", "x/ä.txt", "Ä.txt", "a.txt", "grüß/a.log", "GRÜß/a.log", "Grüß/a.LOG",
+		"bé1.dat", "Bé1.dat", "BÉ1.dat", "dé1.dat", "k.bin", "K.bin", "\u212a.bin",
+		"x.cfg", "X.cfg", "-.cfg", "y.cfg",
+	}
+
+	for _, opts := range []MatcherOptions{{}, {CaseInsensitive: true}, {SmartCase: true}} {
+		m, err := NewMatcher(rules, opts)
+		if err != nil {
+			t.Fatalf("NewMatcher: %v", err)
+		}
+
+		set, err := m.ToRegexp()
+		if err != nil {
+			t.Fatalf("ToRegexp: %v", err)
+		}
+
+		if len(set.Rules) != 3 {
+			t.Fatalf("len(Rules)=%d, want 3 merged groups: %+v", len(set.Rules), set.Rules)
+		}
+
+		for _, path := range paths {
+			if got, want := regexpSetIncluded(t, set, path), m.Included(path, false); got != want {
+				t.Errorf("opts=%+v %q: regexp included=%v, matcher included=%v", opts, path, got, want)
+			}
+		}
+	}
+}
+
+func TestRegexpSetSingle(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewMatcher([]Rule{
+		{Action: ActionInclude, Pattern: "*.go"},
+		{Action: ActionExclude, Pattern: "vendor/"},
+		{Action: ActionExclude, Pattern: "*.tmp"},
+	}, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	set, err := m.ToRegexp()
+	if err != nil {
+		t.Fatalf("ToRegexp: %v", err)
+	}
+
+	expr, action, err := set.Single()
+	if err != nil || action != ActionExclude {
+		t.Fatalf("Single: action=%v err=%v", action, err)
+	}
+
+	re := regexp.MustCompile(expr)
+	if !re.MatchString("vendor/a.go") || !re.MatchString("x.tmp") || re.MatchString("main.go") {
+		t.Fatalf("unexpected single regexp %q", expr)
+	}
+
+	mixed := &RegexpSet{
+		DefaultAction: ActionInclude,
+		Rules: []RegexpRule{
+			{Action: ActionExclude, Pattern: "a"},
+			{Action: ActionInclude, Pattern: "b"},
+		},
+	}
+
+	if _, _, err := mixed.Single(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("err=%v, want ErrUnsupported", err)
+	}
+}
+
+func TestMatcherToRegexpUnsupported(t *testing.T) {
+	t.Parallel()
+
+	git, err := NewMatcher(nil, MatcherOptions{Dialect: DialectGit})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if _, err := git.ToRegexp(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("err=%v, want ErrUnsupported", err)
+	}
+
+	re, err := NewMatcher([]Rule{{Action: ActionExclude, Pattern: "x", Syntax: PatternRegexp}}, MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if _, err := re.ToRegexp(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("err=%v, want ErrUnsupported", err)
+	}
+
+	custom, err := NewMatcher([]Rule{{Action: testActionWarn, Pattern: "*.tmp"}}, MatcherOptions{
+		ActionHandlers: map[Action]ActionHandler{
+			testActionWarn: func(string, bool, Rule) bool { return true },
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if _, err := custom.ToRegexp(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("custom action err=%v, want ErrUnsupported", err)
+	}
+
+	limited, err := NewMatcher([]Rule{{Action: ActionExclude, Pattern: "*.tmp"}}, MatcherOptions{MaxPathLength: 64})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if _, err := limited.ToRegexp(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Fatalf("MaxPathLength err=%v, want ErrUnsupported", err)
+	}
+}
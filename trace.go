@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+// TraceEvent describes one rule tested against one path.
+type TraceEvent struct {
+	// Path is the normalized path tested, a parent directory during parent
+	// exclusion checks.
+	Path string `json:"path" yaml:"path"`
+	// Rule is the tested rule.
+	Rule Rule `json:"rule" yaml:"rule"`
+	// RuleIndex is the rule index in matcher input order, -1 for dialect default rules.
+	RuleIndex int `json:"rule_index" yaml:"rule_index"`
+	// IsDir reports whether Path was tested as a directory.
+	IsDir bool `json:"is_dir,omitempty" yaml:"is_dir,omitempty"`
+	// Matched reports whether the rule matched Path.
+	Matched bool `json:"matched" yaml:"matched"`
+}
+
+// decideTraced evaluates every rule in input order, reporting each test to trace.
+//
+// It returns the same decision as decideNormalized without the literal index
+// and early exit.
+func (m *Matcher) decideTraced(candidate string, isDir bool) MatchResult {
+	res := MatchResult{
+		Included:  m.defaultAction == ActionInclude,
+		RuleIndex: -1,
+	}
+
+	for _, i := range m.inputOrder() {
+		rule := &m.compiled[i]
+		matched := rule.source.Meta == nil && rule.matches(candidate, isDir)
+		m.trace(TraceEvent{
+			Path:      candidate,
+			Rule:      rule.source,
+			RuleIndex: m.userResult(MatchResult{RuleIndex: i}).RuleIndex,
+			IsDir:     isDir,
+			Matched:   matched,
+		})
+
+		if matched && (!res.Matched || m.outranks(i, res.RuleIndex)) {
+			res.Matched = true
+			res.RuleIndex = i
+			res.Action = rule.source.Action
+			res.Included = res.Action != ActionExclude
+		}
+	}
+
+	return res
+}
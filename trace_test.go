@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "testing"
+
+func TestMatcherTrace(t *testing.T) {
+	t.Parallel()
+
+	var events []TraceEvent
+	rules := []Rule{
+		{Action: ActionExclude, Pattern: "*.log"},
+		{Action: ActionInclude, Pattern: "keep.log"},
+		{Action: ActionExclude, Pattern: "/other"},
+	}
+
+	m, err := NewMatcher(rules, MatcherOptions{
+		MemoSize: 4,
+		Trace:    func(e TraceEvent) { events = append(events, e) },
+	})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	for range 2 {
+		events = events[:0]
+		res := m.Decide("./logs/keep.log", false)
+		if !res.Included || res.RuleIndex != 1 {
+			t.Fatalf("Decide=%+v, want included by rule 1", res)
+		}
+
+		if len(events) != len(rules) {
+			t.Fatalf("events=%+v, want one per rule", events)
+		}
+
+		for i, e := range events {
+			want := i < 2
+			if e.Path != "logs/keep.log" || e.RuleIndex != i || e.Rule.Pattern != rules[i].Pattern || e.Matched != want {
+				t.Fatalf("events[%d]=%+v, want rule %d matched=%v", i, e, i, want)
+			}
+		}
+	}
+}
+
+func TestMatcherTraceParentExclusion(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	m, err := NewMatcher([]Rule{{Action: ActionExclude, Pattern: "build/"}}, MatcherOptions{
+		Dialect: DialectGit,
+		Trace: func(e TraceEvent) {
+			if e.RuleIndex >= 0 {
+				paths = append(paths, e.Path)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	if m.Included("build/a.txt", false) {
+		t.Fatalf("Included(build/a.txt)=true, want false")
+	}
+
+	if len(paths) != 1 || paths[0] != "build" {
+		t.Fatalf("traced paths=%v, want [build]", paths)
+	}
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import "context"
+
+// DecideTree walks relDir (relative to provider root or Scope directory,
+// "" for the whole tree) once and returns decisions for every entry below
+// it, keyed by slash-separated path relative to provider scope.
+//
+// Each directory's rules chain is prepared once for all of its entries.
+// Excluded directories are recorded but not read, as in Walk, so their
+// contents have no entries. relDir itself is not decided. Symlinked
+// directories are not followed. Read and rules file errors stop the walk,
+// cancellation returns ctx.Err().
+func (p *Provider) DecideTree(ctx context.Context, relDir string) (map[string]MatchResult, error) {
+	if p == nil {
+		return nil, ErrNilProvider
+	}
+
+	if err := p.checkNames(relDir); err != nil {
+		return nil, err
+	}
+
+	normalized, err := p.cleanRelDir(relDir)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]MatchResult)
+	if err := p.decideTreeDir(ctx, normalized, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// decideTreeDir records decisions of one directory and descends into included subdirectories.
+func (p *Provider) decideTreeDir(ctx context.Context, relDir string, out map[string]MatchResult) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	entries, results, err := p.readDirDecisions(relDir)
+	if err != nil {
+		return err
+	}
+
+	for i, entry := range entries {
+		relPath := entry.Name()
+		if relDir != "" {
+			relPath = relDir + "/" + relPath
+		}
+
+		out[relPath] = results[i]
+		if entry.IsDir() && results[i].Included {
+			if err := p.decideTreeDir(ctx, relPath, out); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
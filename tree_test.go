@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestProviderDecideTree(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewProviderFS(walkTestFS, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	got, err := p.DecideTree(context.Background(), "")
+	if err != nil {
+		t.Fatalf("DecideTree: %v", err)
+	}
+
+	want := map[string]bool{
+		".pathrules":             true,
+		"build":                  false,
+		"src":                    true,
+		"src/cache.tmp":          false,
+		"src/gen":                true,
+		"src/gen/.pathrules":     true,
+		"src/gen/keep.tmp":       true,
+		"src/gen/zz":             true,
+		"src/gen/zz/deep.go":     true,
+		"src/main.go":            true,
+		"vendor":                 true,
+		"vendor/lib":             true,
+		"vendor/lib/lib.go":      true,
+		"vendor/lib/lib_test.go": true,
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("DecideTree returned %d entries, want %d: %v", len(got), len(want), got)
+	}
+
+	for path, included := range want {
+		res, ok := got[path]
+		if !ok || res.Included != included {
+			t.Fatalf("DecideTree[%s]=%+v ok=%v, want included=%v", path, res, ok, included)
+		}
+
+	}
+
+	if decided, err := p.Decide("src/gen/keep.tmp", false); err != nil || decided != got["src/gen/keep.tmp"] {
+		t.Fatalf("Decide(src/gen/keep.tmp)=%+v err=%v, DecideTree=%+v", decided, err, got["src/gen/keep.tmp"])
+	}
+
+	sub, err := p.DecideTree(context.Background(), "src/gen")
+	if err != nil || len(sub) != 4 || !sub["src/gen/zz/deep.go"].Included {
+		t.Fatalf("DecideTree(src/gen)=%v err=%v", sub, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.DecideTree(ctx, ""); !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled DecideTree err=%v", err)
+	}
+}
@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"iter"
+	"path/filepath"
+)
+
+// WalkFunc is called by Walk for each included entry.
+//
+// relPath is slash-separated and relative to provider root (or Scope
+// directory). Returning fs.SkipDir from a directory skips its contents,
+// from a file skips remaining entries of its directory; fs.SkipAll stops
+// the walk without error. Any other error stops the walk and is returned.
+type WalkFunc func(relPath string, d fs.DirEntry) error
+
+// Walk walks provider root (or Scope directory) in lexical order and calls
+// fn for every included entry.
+//
+// Excluded directories are pruned: their contents are not read, even when
+// rules re-include paths below them. The starting directory itself is not
+// passed to fn. Symlinked directories are not followed. Directory read and
+// rules file errors stop the walk and are returned, cancellation returns
+// ctx.Err().
+func (p *Provider) Walk(ctx context.Context, fn WalkFunc) error {
+	if p == nil {
+		return ErrNilProvider
+	}
+
+	err := p.walkDir(ctx, "", fn)
+	if errors.Is(err, fs.SkipAll) || errors.Is(err, fs.SkipDir) {
+		return nil
+	}
+
+	return err
+}
+
+// CollectIncluded returns included file paths under provider root (or Scope
+// directory) in Walk order. Directories are pruned and not listed.
+func (p *Provider) CollectIncluded(ctx context.Context) ([]string, error) {
+	var files []string
+	for relPath, err := range p.IncludedFiles(ctx) {
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, relPath)
+	}
+
+	return files, nil
+}
+
+// IncludedFiles streams included file paths in Walk order.
+//
+// A walk error is yielded once with an empty path and ends the sequence.
+// Breaking out of the loop stops the walk.
+func (p *Provider) IncludedFiles(ctx context.Context) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		err := p.Walk(ctx, func(relPath string, d fs.DirEntry) error {
+			if d.IsDir() || yield(relPath, nil) {
+				return nil
+			}
+
+			return fs.SkipAll
+		})
+		if err != nil {
+			yield("", err)
+		}
+	}
+}
+
+// WalkFilter wraps fn for fs.WalkDir or filepath.WalkDir started at root
+// so that fn sees only included entries.
+//
+// Walked paths are made relative to root before evaluation, so root must
+// match the directory the provider (or Scope view) is rooted at: "." for
+// fs.WalkDir, the provider root directory for filepath.WalkDir. Excluded
+// directories are pruned with fs.SkipDir, excluded files are skipped. The
+// root itself and calls carrying a walk error are passed to fn unchanged.
+// Unlike Walk, each directory is decided with Decide, which also loads the
+// directory's own rules file.
+func (p *Provider) WalkFilter(root string, fn fs.WalkDirFunc) fs.WalkDirFunc {
+	return func(walkPath string, d fs.DirEntry, err error) error {
+		if err != nil || walkPath == root {
+			return fn(walkPath, d, err)
+		}
+
+		rel, err := filepath.Rel(root, walkPath)
+		if err != nil {
+			return fmt.Errorf("walk filter %q: %w", walkPath, err)
+		}
+
+		res, err := p.Decide(filepath.ToSlash(rel), d.IsDir())
+		if err != nil {
+			return err
+		}
+
+		if !res.Included {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		return fn(walkPath, d, nil)
+	}
+}
+
+// walkDir visits included entries of one directory relative to provider scope.
+func (p *Provider) walkDir(ctx context.Context, relDir string, fn WalkFunc) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	entries, results, err := p.readDirDecisions(relDir)
+	if err != nil {
+		return err
+	}
+
+	for i, entry := range entries {
+		if !results[i].Included {
+			continue
+		}
+
+		relPath := entry.Name()
+		if relDir != "" {
+			relPath = relDir + "/" + relPath
+		}
+
+		if err := fn(relPath, entry); err != nil {
+			if errors.Is(err, fs.SkipDir) && entry.IsDir() {
+				continue
+			}
+
+			return err
+		}
+
+		if entry.IsDir() {
+			// SkipDir from a file inside ends only that directory.
+			if err := p.walkDir(ctx, relPath, fn); err != nil && !errors.Is(err, fs.SkipDir) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// readDirDecisions lists one directory relative to provider scope and decides its entries.
+func (p *Provider) readDirDecisions(relDir string) ([]fs.DirEntry, []MatchResult, error) {
+	entries, err := p.readDir(p.scopedPath(relDir))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results, err := p.DecideReadDir(relDir, entries)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return entries, results, nil
+}
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"runtime"
+	"sync"
+)
+
+// WalkParallel is Walk spreading directories across workers goroutines.
+//
+// fn is called concurrently and in no particular order; a directory is
+// always passed to fn before its contents. workers <= 0 uses GOMAXPROCS.
+// fs.SkipDir and fs.SkipAll behave as in Walk. The first error stops the
+// walk and is returned; entries already in flight may still reach fn.
+// Rules files are loaded once and shared between workers.
+func (p *Provider) WalkParallel(ctx context.Context, workers int, fn WalkFunc) error {
+	if p == nil {
+		return ErrNilProvider
+	}
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	w := &parallelWalk{
+		p:       p,
+		ctx:     ctx,
+		fn:      fn,
+		queue:   []string{""},
+		pending: 1,
+	}
+	w.cond = sync.NewCond(&w.mu)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(w.work)
+	}
+
+	wg.Wait()
+	return w.err
+}
+
+// parallelWalk is one running WalkParallel walk.
+type parallelWalk struct {
+	// p is the walked provider.
+	p *Provider
+	// ctx cancels the walk.
+	ctx context.Context
+	// fn receives included entries.
+	fn WalkFunc
+	// err is the first walk error, guarded by mu.
+	err error
+	// cond wakes idle workers when queue grows or the walk ends.
+	cond *sync.Cond
+	// queue holds directories waiting to be read, guarded by mu.
+	queue []string
+	// pending counts queued and in-progress directories, guarded by mu.
+	pending int
+	// mu guards walk state.
+	mu sync.Mutex
+	// stopped reports whether the walk ended early, guarded by mu.
+	stopped bool
+}
+
+// work processes queued directories until the walk ends.
+func (w *parallelWalk) work() {
+	for {
+		w.mu.Lock()
+		for len(w.queue) == 0 && w.pending > 0 && !w.stopped {
+			w.cond.Wait()
+		}
+
+		if len(w.queue) == 0 || w.stopped {
+			w.mu.Unlock()
+			return
+		}
+
+		// LIFO keeps the queue small on wide trees.
+		relDir := w.queue[len(w.queue)-1]
+		w.queue = w.queue[:len(w.queue)-1]
+		w.mu.Unlock()
+
+		err := w.visit(relDir)
+
+		w.mu.Lock()
+		w.pending--
+		if err != nil && !w.stopped {
+			w.stopped = true
+			if !errors.Is(err, fs.SkipAll) {
+				w.err = err
+			}
+		}
+
+		if w.pending == 0 || w.stopped {
+			w.cond.Broadcast()
+		}
+		w.mu.Unlock()
+	}
+}
+
+// visit passes included entries of one directory to fn and queues included subdirectories.
+func (w *parallelWalk) visit(relDir string) error {
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
+
+	entries, results, err := w.p.readDirDecisions(relDir)
+	if err != nil {
+		return err
+	}
+
+	for i, entry := range entries {
+		if !results[i].Included {
+			continue
+		}
+
+		if w.isStopped() {
+			return nil
+		}
+
+		relPath := entry.Name()
+		if relDir != "" {
+			relPath = relDir + "/" + relPath
+		}
+
+		if err := w.fn(relPath, entry); err != nil {
+			if errors.Is(err, fs.SkipDir) {
+				if entry.IsDir() {
+					continue
+				}
+
+				return nil
+			}
+
+			return err
+		}
+
+		if entry.IsDir() {
+			w.push(relPath)
+		}
+	}
+
+	return nil
+}
+
+// push queues one directory for reading.
+func (w *parallelWalk) push(relDir string) {
+	w.mu.Lock()
+	w.queue = append(w.queue, relDir)
+	w.pending++
+	w.cond.Signal()
+	w.mu.Unlock()
+}
+
+// isStopped reports whether the walk ended early.
+func (w *parallelWalk) isStopped() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.stopped
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+func TestProviderWalkParallel(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewProviderFS(walkTestFS, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	want := collectWalk(t, p, nil)
+	for _, workers := range []int{0, 1, 4} {
+		var (
+			got []string
+			mu  sync.Mutex
+		)
+
+		err := p.WalkParallel(context.Background(), workers, func(relPath string, _ fs.DirEntry) error {
+			mu.Lock()
+			got = append(got, relPath)
+			mu.Unlock()
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("WalkParallel(%d): %v", workers, err)
+		}
+
+		slices.Sort(got)
+		if !slices.Equal(got, want) {
+			t.Fatalf("WalkParallel(%d)=%v, want %v", workers, got, want)
+		}
+	}
+}
+
+func TestProviderWalkParallelStops(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{}
+	for i := range 50 {
+		fsys[fmt.Sprintf("d%02d/sub/f.txt", i)] = &fstest.MapFile{}
+	}
+
+	p, err := NewProviderFS(fsys, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	errStop := errors.New("stop")
+	err = p.WalkParallel(context.Background(), 4, func(relPath string, d fs.DirEntry) error {
+		if relPath == "d10/sub" {
+			return errStop
+		}
+
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("err=%v, want errStop", err)
+	}
+
+	var (
+		seen int
+		mu   sync.Mutex
+	)
+
+	err = p.WalkParallel(context.Background(), 4, func(relPath string, d fs.DirEntry) error {
+		if d.IsDir() {
+			return fs.SkipDir
+		}
+
+		mu.Lock()
+		seen++
+		mu.Unlock()
+		return nil
+	})
+	if err != nil || seen != 0 {
+		t.Fatalf("SkipDir walk: seen=%d err=%v, want 0 files", seen, err)
+	}
+
+	err = p.WalkParallel(context.Background(), 4, func(string, fs.DirEntry) error { return fs.SkipAll })
+	if err != nil {
+		t.Fatalf("SkipAll walk err=%v, want nil", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.WalkParallel(ctx, 4, func(string, fs.DirEntry) error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err=%v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,263 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"context"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+// walkTestFS is a tree where the broken rules file is reachable only through an excluded directory.
+var walkTestFS = fstest.MapFS{
+	".pathrules":             {Data: []byte("build/\n*.tmp\n")},
+	"build/.pathrules":       {Data: []byte("#pragma default=bogus\n")},
+	"build/out.bin":          {Data: []byte("x")},
+	"src/main.go":            {Data: []byte("x")},
+	"src/cache.tmp":          {Data: []byte("x")},
+	"src/gen/.pathrules":     {Data: []byte("!*.tmp\n")},
+	"src/gen/keep.tmp":       {Data: []byte("x")},
+	"src/gen/zz/deep.go":     {Data: []byte("x")},
+	"vendor/lib/lib.go":      {Data: []byte("x")},
+	"vendor/lib/lib_test.go": {Data: []byte("x")},
+}
+
+// collectWalk returns paths passed to fn by Walk.
+func collectWalk(t *testing.T, p *Provider, skip func(string, fs.DirEntry) error) []string {
+	t.Helper()
+
+	var got []string
+	err := p.Walk(context.Background(), func(relPath string, d fs.DirEntry) error {
+		got = append(got, relPath)
+		if skip != nil {
+			return skip(relPath, d)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+
+	return got
+}
+
+func TestProviderWalk(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewProviderFS(walkTestFS, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	want := []string{
+		".pathrules",
+		"src",
+		"src/gen",
+		"src/gen/.pathrules",
+		"src/gen/keep.tmp",
+		"src/gen/zz",
+		"src/gen/zz/deep.go",
+		"src/main.go",
+		"vendor",
+		"vendor/lib",
+		"vendor/lib/lib.go",
+		"vendor/lib/lib_test.go",
+	}
+
+	if got := collectWalk(t, p, nil); !slices.Equal(got, want) {
+		t.Fatalf("Walk=%v, want %v", got, want)
+	}
+
+	got := collectWalk(t, p, func(relPath string, _ fs.DirEntry) error {
+		switch relPath {
+		case "src/gen":
+			return fs.SkipDir
+		case "vendor/lib/lib.go":
+			return fs.SkipDir
+		}
+
+		return nil
+	})
+
+	want = []string{".pathrules", "src", "src/gen", "src/main.go", "vendor", "vendor/lib", "vendor/lib/lib.go"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Walk with SkipDir=%v, want %v", got, want)
+	}
+
+	got = collectWalk(t, p, func(relPath string, _ fs.DirEntry) error {
+		if relPath == "src" {
+			return fs.SkipAll
+		}
+
+		return nil
+	})
+
+	if want = []string{".pathrules", "src"}; !slices.Equal(got, want) {
+		t.Fatalf("Walk with SkipAll=%v, want %v", got, want)
+	}
+
+	scoped, err := p.Scope("src/gen")
+	if err != nil {
+		t.Fatalf("Scope: %v", err)
+	}
+
+	if got, want := collectWalk(t, scoped, nil), []string{".pathrules", "keep.tmp", "zz", "zz/deep.go"}; !slices.Equal(got, want) {
+		t.Fatalf("scoped Walk=%v, want %v", got, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.Walk(ctx, func(string, fs.DirEntry) error { return nil }); err != context.Canceled {
+		t.Fatalf("Walk err=%v, want context.Canceled", err)
+	}
+}
+
+func TestProviderWalkOS(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, ".pathrules"), "*.log\n")
+	writeRulesFile(t, filepath.Join(root, "a", "b.txt"), "x")
+	writeRulesFile(t, filepath.Join(root, "a", "c.log"), "x")
+	if err := os.Symlink(filepath.Join(root, "a"), filepath.Join(root, "link")); err != nil {
+		t.Skipf("Symlink: %v", err)
+	}
+
+	p, err := NewProvider(root, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	want := []string{".pathrules", "a", "a/b.txt", "link"}
+	if got := collectWalk(t, p, nil); !slices.Equal(got, want) {
+		t.Fatalf("Walk=%v, want %v", got, want)
+	}
+}
+
+func TestProviderWalkFilter(t *testing.T) {
+	t.Parallel()
+
+	// WalkFilter decides excluded directories too, which loads their rules files.
+	fsys := maps.Clone(walkTestFS)
+	delete(fsys, "build/.pathrules")
+
+	p, err := NewProviderFS(fsys, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	want := collectWalk(t, p, nil)
+
+	var got []string
+	err = fs.WalkDir(fsys, ".", p.WalkFilter(".", func(walkPath string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if walkPath != "." {
+			got = append(got, walkPath)
+		}
+
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("WalkDir: %v", err)
+	}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("WalkDir with filter=%v, want %v", got, want)
+	}
+}
+
+func TestProviderWalkFilterOS(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, ".pathrules"), "skip/\n")
+	writeRulesFile(t, filepath.Join(root, "skip", "a.txt"), "x")
+	writeRulesFile(t, filepath.Join(root, "keep", "a.txt"), "x")
+
+	p, err := NewProvider(root, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	var got []string
+	err = filepath.WalkDir(root, p.WalkFilter(root, func(walkPath string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, _ := filepath.Rel(root, walkPath)
+		got = append(got, filepath.ToSlash(rel))
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("WalkDir: %v", err)
+	}
+
+	want := []string{".", ".pathrules", "keep", "keep/a.txt"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("WalkDir with filter=%v, want %v", got, want)
+	}
+}
+
+func TestProviderCollectIncluded(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewProviderFS(walkTestFS, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	got, err := p.CollectIncluded(context.Background())
+	if err != nil {
+		t.Fatalf("CollectIncluded: %v", err)
+	}
+
+	want := []string{
+		".pathrules",
+		"src/gen/.pathrules",
+		"src/gen/keep.tmp",
+		"src/gen/zz/deep.go",
+		"src/main.go",
+		"vendor/lib/lib.go",
+		"vendor/lib/lib_test.go",
+	}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("CollectIncluded=%v, want %v", got, want)
+	}
+
+	var first []string
+	for relPath, err := range p.IncludedFiles(context.Background()) {
+		if err != nil {
+			t.Fatalf("IncludedFiles: %v", err)
+		}
+
+		if first = append(first, relPath); len(first) == 2 {
+			break
+		}
+	}
+
+	if !slices.Equal(first, want[:2]) {
+		t.Fatalf("IncludedFiles with break=%v, want %v", first, want[:2])
+	}
+
+	broken, err := NewProviderFS(fstest.MapFS{"a/.pathrules": {Data: []byte("#pragma default=bogus\n")}, "a/x": {}}, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	if files, err := broken.CollectIncluded(context.Background()); err == nil || files != nil {
+		t.Fatalf("CollectIncluded=%v err=%v, want error", files, err)
+	}
+}
@@ -0,0 +1,219 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// Warmup walks provider root (or Scope directory) up to maxDepth directory
+// levels and loads every rules file concurrently on GOMAXPROCS workers.
+//
+// maxDepth 0 loads only the starting directory, negative value is unlimited.
+// Symlinked directories are not followed. Rules file parse errors stay cached
+// for Decide and are also joined into the returned error together with
+// directory read errors. Cancellation stops the walk and returns ctx.Err().
+func (p *Provider) Warmup(ctx context.Context, maxDepth int) error {
+	if p == nil {
+		return ErrNilProvider
+	}
+
+	return p.WarmupDirs(ctx, maxDepth, "")
+}
+
+// WarmupDirs is Warmup starting from the given directories relative to
+// provider root (or Scope directory). Directories above each start are
+// loaded too because their rules apply to the whole subtree.
+func (p *Provider) WarmupDirs(ctx context.Context, maxDepth int, relDirs ...string) error {
+	if p == nil {
+		return ErrNilProvider
+	}
+
+	w := &providerWarmup{
+		p:   p,
+		ctx: ctx,
+	}
+	w.cond = sync.NewCond(&w.mu)
+
+	for _, relDir := range relDirs {
+		normalizedDir, err := p.cleanRelDir(relDir)
+		if err != nil {
+			return fmt.Errorf("warmup %q: %w", relDir, err)
+		}
+
+		normalizedDir = p.scopedPath(normalizedDir)
+		// Ancestor rules apply to the subtree, load them without descending.
+		for i := 0; i < len(normalizedDir); i++ {
+			if normalizedDir[i] == '/' {
+				w.push(normalizedDir[:i], 0)
+			}
+		}
+
+		if normalizedDir != "" {
+			w.push("", 0)
+		}
+
+		w.push(normalizedDir, maxDepth)
+	}
+
+	var wg sync.WaitGroup
+	for range runtime.GOMAXPROCS(0) {
+		wg.Go(w.work)
+	}
+
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return errors.Join(w.errs...)
+}
+
+// warmupDir is one queued Warmup directory.
+type warmupDir struct {
+	// relDir is the directory relative to provider root.
+	relDir string
+	// depth is the number of subdirectory levels left, negative for unlimited.
+	depth int
+}
+
+// providerWarmup is one running Warmup walk.
+type providerWarmup struct {
+	// p is the provider being warmed up.
+	p *Provider
+	// ctx cancels the walk.
+	ctx context.Context
+	// cond wakes idle workers when queue grows or the walk ends.
+	cond *sync.Cond
+	// errs collects load and read errors, guarded by mu.
+	errs []error
+	// queue holds directories waiting to be loaded, guarded by mu.
+	queue []warmupDir
+	// pending counts queued and in-progress directories, guarded by mu.
+	pending int
+	// mu guards walk state.
+	mu sync.Mutex
+	// stopped reports whether ctx was canceled, guarded by mu.
+	stopped bool
+}
+
+// work loads queued directories until the walk ends.
+func (w *providerWarmup) work() {
+	for {
+		w.mu.Lock()
+		for len(w.queue) == 0 && w.pending > 0 && !w.stopped {
+			w.cond.Wait()
+		}
+
+		if len(w.queue) == 0 || w.stopped {
+			w.mu.Unlock()
+			return
+		}
+
+		// LIFO keeps the queue small on wide trees.
+		dir := w.queue[len(w.queue)-1]
+		w.queue = w.queue[:len(w.queue)-1]
+		w.mu.Unlock()
+
+		w.visit(dir.relDir, dir.depth)
+
+		w.mu.Lock()
+		w.pending--
+		if w.ctx.Err() != nil {
+			w.stopped = true
+		}
+
+		if w.pending == 0 || w.stopped {
+			w.cond.Broadcast()
+		}
+		w.mu.Unlock()
+	}
+}
+
+// push queues relDir to be loaded with up to depth levels of its
+// subdirectories (negative depth is unlimited).
+func (w *providerWarmup) push(relDir string, depth int) {
+	w.mu.Lock()
+	w.queue = append(w.queue, warmupDir{relDir: relDir, depth: depth})
+	w.pending++
+	w.cond.Signal()
+	w.mu.Unlock()
+}
+
+// visit loads one directory matcher and queues its subdirectories.
+func (w *providerWarmup) visit(relDir string, depth int) {
+	if w.ctx.Err() != nil {
+		return
+	}
+
+	if _, err := w.p.dirMatcher(relDir); errors.Is(err, errSubtreeExcluded) {
+		return
+	} else if err != nil {
+		w.addErr(err)
+	}
+
+	if depth == 0 || w.ctx.Err() != nil {
+		return
+	}
+
+	entries, err := w.p.readDir(relDir)
+	if err != nil {
+		w.addErr(err)
+		return
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		child := entry.Name()
+		if relDir != "" {
+			child = relDir + "/" + child
+		}
+
+		w.push(child, depth-1)
+	}
+}
+
+// addErr records one walk error.
+func (w *providerWarmup) addErr(err error) {
+	w.mu.Lock()
+	w.errs = append(w.errs, err)
+	w.mu.Unlock()
+}
+
+// readDir lists one directory relative to provider root.
+func (p *Provider) readDir(relDir string) ([]fs.DirEntry, error) {
+	if p.fsys != nil {
+		name := relDir
+		if name == "" {
+			name = "."
+		}
+
+		entries, err := fs.ReadDir(p.fsys, path.Clean(name))
+		if err != nil {
+			return nil, fmt.Errorf("read dir %s: %w", name, err)
+		}
+
+		return entries, nil
+	}
+
+	fullDir := filepath.Join(p.root, filepath.FromSlash(relDir))
+	entries, err := os.ReadDir(fullDir)
+	if err != nil {
+		return nil, fmt.Errorf("read dir %s: %w", fullDir, err)
+	}
+
+	return entries, nil
+}
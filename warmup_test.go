@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+// cachedDirs returns loaded (or failed) cache entry directories.
+func cachedDirs(p *Provider) map[string]bool {
+	p.cache.mu.Lock()
+	defer p.cache.mu.Unlock()
+
+	out := make(map[string]bool, len(p.cache.entries))
+	for relDir := range p.cache.entries {
+		out[relDir] = true
+	}
+
+	return out
+}
+
+func TestProviderWarmup(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".pathrules":         {Data: []byte("*.tmp\n")},
+		"a/.pathrules":       {Data: []byte("!*.tmp\n")},
+		"a/b/c/.pathrules":   {Data: []byte("*.log\n")},
+		"x/y/file.txt":       {Data: []byte("x")},
+		"bad/.pathrules":     {Data: []byte("#pragma default=nope\n")},
+		"bad/deeper/z/a.txt": {Data: []byte("x")},
+	}
+
+	p, err := NewProviderFS(fsys, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProviderFS: %v", err)
+	}
+
+	err = p.Warmup(context.Background(), 1)
+	if !errors.Is(err, ErrInvalidDirective) {
+		t.Fatalf("Warmup err=%v, want ErrInvalidDirective", err)
+	}
+
+	got := cachedDirs(p)
+	for _, dir := range []string{"", "a", "x", "bad"} {
+		if !got[dir] {
+			t.Fatalf("dir %q not loaded: %v", dir, got)
+		}
+	}
+
+	if got["a/b"] {
+		t.Fatalf("depth limit exceeded: %v", got)
+	}
+
+	if err := p.WarmupDirs(context.Background(), -1, "a/b"); err != nil {
+		t.Fatalf("WarmupDirs: %v", err)
+	}
+
+	if got := cachedDirs(p); !got["a/b/c"] {
+		t.Fatalf("a/b/c not loaded: %v", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.Warmup(ctx, -1); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err=%v, want context.Canceled", err)
+	}
+}
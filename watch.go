@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WatchFilter applies a Decider to a recursive file watcher rooted at a
+// directory, such as one built on fsnotify: which directories to add, and
+// which events to deliver.
+//
+// Paths below an excluded directory are never watched, even when a rule
+// re-includes them, matching the pruning of Walk.
+type WatchFilter struct {
+	// decider decides watcher-relative paths.
+	decider Decider
+	// onError receives decision errors of filtered events, nil drops them silently.
+	onError func(path string, err error)
+	// root is the absolute watched root directory.
+	root string
+}
+
+// NewWatchFilter returns a filter for a watcher of root whose paths d
+// decides relative to root, usually a Provider created for the same root.
+//
+// onError, when set, receives errors of events dropped by FilterEvents.
+func NewWatchFilter(root string, d Decider, onError func(path string, err error)) (*WatchFilter, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("abs root: %w", err)
+	}
+
+	return &WatchFilter{decider: d, onError: onError, root: absRoot}, nil
+}
+
+// ShouldWatch reports whether an OS path below root should be watched
+// (directories) or reported (files): it and every parent directory must be
+// included. Root itself is always watched; paths outside it never are.
+func (w *WatchFilter) ShouldWatch(osPath string, isDir bool) (bool, error) {
+	rel, ok, err := w.rel(osPath)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	if rel == "" {
+		return true, nil
+	}
+
+	if err := visible(w.decider, rel, isDir); err != nil {
+		if err == fs.ErrNotExist {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Dirs returns root and every directory below it a recursive watcher should
+// add, without reading excluded directories. Symlinked directories are not
+// followed.
+func (w *WatchFilter) Dirs(ctx context.Context) ([]string, error) {
+	var dirs []string
+	err := filepath.WalkDir(w.root, func(osPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		ok, err := w.ShouldWatch(osPath, true)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fs.SkipDir
+		}
+
+		dirs = append(dirs, osPath)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dirs, nil
+}
+
+// FilterEvents forwards events of in that pass w.ShouldWatch to the returned
+// channel, which is closed when in is closed or ctx is done. pathOf returns
+// the OS path of an event, for fsnotify "func(e fsnotify.Event) string {
+// return e.Name }".
+//
+// Whether an event path is a directory is checked on disk; removed paths
+// count as files, so their events pass unless a parent is excluded.
+func FilterEvents[E any](ctx context.Context, w *WatchFilter, in <-chan E, pathOf func(E) string) <-chan E {
+	out := make(chan E)
+	go func() {
+		defer close(out)
+		for {
+			var (
+				event E
+				ok    bool
+			)
+
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok = <-in:
+				if !ok {
+					return
+				}
+			}
+
+			osPath := pathOf(event)
+			info, err := os.Lstat(osPath)
+			watch, err := w.ShouldWatch(osPath, err == nil && info.IsDir())
+			if err != nil {
+				if w.onError != nil {
+					w.onError(osPath, err)
+				}
+
+				continue
+			}
+
+			if !watch {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- event:
+			}
+		}
+	}()
+
+	return out
+}
+
+// rel returns osPath relative to root in slash form and reports whether it is inside root.
+func (w *WatchFilter) rel(osPath string) (string, bool, error) {
+	abs, err := filepath.Abs(osPath)
+	if err != nil {
+		return "", false, fmt.Errorf("abs path: %w", err)
+	}
+
+	rel, err := filepath.Rel(w.root, abs)
+	if err != nil {
+		return "", false, nil
+	}
+
+	rel = filepath.ToSlash(rel)
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", false, nil
+	}
+
+	if rel == "." {
+		rel = ""
+	}
+
+	return rel, true, nil
+}
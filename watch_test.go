@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestWatchFilter(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for _, dir := range []string{"src/pkg", "node_modules/lib", "build"} {
+		if err := os.MkdirAll(filepath.Join(root, filepath.FromSlash(dir)), 0o750); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+	}
+
+	m, err := NewMatcher(MustParseRulesString("node_modules/\nbuild/\n*.tmp\n!node_modules/lib/keep.js\n"), MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	w, err := NewWatchFilter(root, m.Decider(), nil)
+	if err != nil {
+		t.Fatalf("NewWatchFilter: %v", err)
+	}
+
+	for _, tc := range []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{path: root, isDir: true, want: true},
+		{path: filepath.Join(root, "src", "main.go"), want: true},
+		{path: filepath.Join(root, "src", "a.tmp"), want: false},
+		{path: filepath.Join(root, "build"), isDir: true, want: false},
+		{path: filepath.Join(root, "node_modules", "lib", "keep.js"), want: false},
+		{path: filepath.Dir(root), isDir: true, want: false},
+	} {
+		got, err := w.ShouldWatch(tc.path, tc.isDir)
+		if err != nil {
+			t.Fatalf("ShouldWatch(%s): %v", tc.path, err)
+		}
+
+		if got != tc.want {
+			t.Fatalf("ShouldWatch(%s)=%v, want %v", tc.path, got, tc.want)
+		}
+	}
+
+	dirs, err := w.Dirs(context.Background())
+	if err != nil {
+		t.Fatalf("Dirs: %v", err)
+	}
+
+	want := []string{root, filepath.Join(root, "src"), filepath.Join(root, "src", "pkg")}
+	if !slices.Equal(dirs, want) {
+		t.Fatalf("Dirs=%v, want %v", dirs, want)
+	}
+}
+
+func TestFilterEvents(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "build"), 0o750); err != nil {
+		t.Fatalf("Mkdir: %v", err)
+	}
+
+	m, err := NewMatcher(MustParseRulesString("build/\n*.tmp\n"), MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	w, err := NewWatchFilter(root, m.Decider(), nil)
+	if err != nil {
+		t.Fatalf("NewWatchFilter: %v", err)
+	}
+
+	in := make(chan string, 4)
+	in <- filepath.Join(root, "main.go")
+	in <- filepath.Join(root, "x.tmp")
+	in <- filepath.Join(root, "build")
+	in <- filepath.Join(root, "build", "out.bin")
+	close(in)
+
+	var got []string
+	for event := range FilterEvents(context.Background(), w, in, func(e string) string { return e }) {
+		got = append(got, event)
+	}
+
+	if want := []string{filepath.Join(root, "main.go")}; !slices.Equal(got, want) {
+		t.Fatalf("events=%v, want %v", got, want)
+	}
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FilterZip returns zr entries included by provider decisions, in archive order.
+//
+// Entry names are evaluated as paths relative to provider root (or Scope
+// directory). Like Walk, entries under an excluded directory are dropped
+// even when rules re-include them. Names escaping the archive root ("../x",
+// absolute paths) fail with ErrPathOutsideRoot, unsafe Windows names with
+// ErrUnsafeName when ProviderOptions.RejectUnsafeNames is set.
+func (p *Provider) FilterZip(zr *zip.Reader) ([]*zip.File, error) {
+	if p == nil {
+		return nil, ErrNilProvider
+	}
+
+	dirs := make(map[string]bool)
+	var dirIncluded func(relDir string) (bool, error)
+	dirIncluded = func(relDir string) (bool, error) {
+		if relDir == "" {
+			return true, nil
+		}
+
+		if included, ok := dirs[relDir]; ok {
+			return included, nil
+		}
+
+		included, err := dirIncluded(pathDir(relDir, false))
+		if err == nil && included {
+			included, err = p.Included(relDir, true)
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		dirs[relDir] = included
+		return included, nil
+	}
+
+	files := make([]*zip.File, 0, len(zr.File))
+	for _, f := range zr.File {
+		isDir := strings.HasSuffix(f.Name, "/") || f.Mode().IsDir()
+		normalized, err := cleanRelPath(f.Name)
+		if err == nil {
+			err = p.checkNames(f.Name)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("zip entry %q: %w", f.Name, err)
+		}
+
+		included, err := dirIncluded(pathDir(normalized, false))
+		if err == nil && included {
+			included, err = p.Included(normalized, isDir)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("zip entry %q: %w", f.Name, err)
+		}
+
+		if included {
+			files = append(files, f)
+		}
+	}
+
+	return files, nil
+}
+
+// WriteZipFiltered writes a zip archive of included regular files under
+// root to w, walking it with provider p.
+//
+// root is the OS directory p is rooted at (its Scope directory for scoped
+// views). Entries are named by provider-relative paths and deflated;
+// symlinks and other non-regular files are skipped.
+func WriteZipFiltered(w io.Writer, root string, p *Provider) error {
+	zw := zip.NewWriter(w)
+	err := p.Walk(context.Background(), func(relPath string, d fs.DirEntry) error {
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		return addZipFile(zw, filepath.Join(root, filepath.FromSlash(relPath)), relPath, d)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("close zip: %w", err)
+	}
+
+	return nil
+}
+
+// AddFSFiltered adds files of fsys included by d to zw, like zw.AddFS
+// over FilterFS(fsys, d): excluded directories are not read and their
+// contents stay out even when rules re-include them. d decides paths
+// relative to the fsys root; zw is not closed.
+func AddFSFiltered(zw *zip.Writer, fsys fs.FS, d Decider) error {
+	if err := zw.AddFS(FilterFS(fsys, d)); err != nil {
+		return fmt.Errorf("zip add fs: %w", err)
+	}
+
+	return nil
+}
+
+// addZipFile writes one OS file to zw under name.
+func addZipFile(zw *zip.Writer, fullPath string, name string, d fs.DirEntry) error {
+	info, err := d.Info()
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", fullPath, err)
+	}
+
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return fmt.Errorf("zip header %s: %w", fullPath, err)
+	}
+
+	header.Name = name
+	header.Method = zip.Deflate
+
+	src, err := os.Open(fullPath)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", fullPath, err)
+	}
+	defer func() { _ = src.Close() }()
+
+	dst, err := zw.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("zip entry %s: %w", name, err)
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return fmt.Errorf("zip copy %s: %w", fullPath, err)
+	}
+
+	return nil
+}
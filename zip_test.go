@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: MIT
+// Copyright (c) 2026 WoozyMasta
+// Source: github.com/woozymasta/pathrules
+
+package pathrules
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+// buildZip returns a zip reader with empty entries named names.
+func buildZip(t *testing.T, names ...string) *zip.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range names {
+		if _, err := zw.Create(name); err != nil {
+			t.Fatalf("Create(%q): %v", name, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	return zr
+}
+
+// zipNames returns entry names of files.
+func zipNames(files []*zip.File) []string {
+	names := make([]string, len(files))
+	for i, f := range files {
+		names[i] = f.Name
+	}
+
+	return names
+}
+
+func TestProviderFilterZip(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewProvider(t.TempDir(), ProviderOptions{
+		BaseRules: []Rule{
+			{Action: ActionExclude, Pattern: "build/"},
+			{Action: ActionExclude, Pattern: "*.tmp"},
+			{Action: ActionInclude, Pattern: "build/keep.txt"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	zr := buildZip(t, "src/", "src/a.go", "src/a.tmp", "build/", "build/keep.txt", "readme.md")
+	files, err := p.FilterZip(zr)
+	if err != nil {
+		t.Fatalf("FilterZip: %v", err)
+	}
+
+	if got, want := zipNames(files), []string{"src/", "src/a.go", "readme.md"}; !slices.Equal(got, want) {
+		t.Fatalf("FilterZip=%v, want %v", got, want)
+	}
+
+	if _, err := p.FilterZip(buildZip(t, "../evil")); !errors.Is(err, ErrPathOutsideRoot) {
+		t.Fatalf("err=%v, want ErrPathOutsideRoot", err)
+	}
+}
+
+func TestWriteZipFiltered(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeRulesFile(t, filepath.Join(root, ".pathrules"), "*.log\n.pathrules\n")
+	writeRulesFile(t, filepath.Join(root, "a", "b.txt"), "hello")
+	writeRulesFile(t, filepath.Join(root, "a", "c.log"), "x")
+
+	p, err := NewProvider(root, ProviderOptions{})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteZipFiltered(&buf, root, p); err != nil {
+		t.Fatalf("WriteZipFiltered: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	if got, want := zipNames(zr.File), []string{"a/b.txt"}; !slices.Equal(got, want) {
+		t.Fatalf("entries=%v, want %v", got, want)
+	}
+
+	rc, err := zr.File[0].Open()
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	if data, err := io.ReadAll(rc); err != nil || string(data) != "hello" {
+		t.Fatalf("content=%q err=%v, want hello", data, err)
+	}
+}
+
+func TestAddFSFiltered(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"a/b.txt":       {Data: []byte("hello")},
+		"a/c.log":       {Data: []byte("x")},
+		"logs/keep.txt": {Data: []byte("k")},
+	}
+
+	m, err := NewMatcher(MustParseRulesString("*.log\nlogs/\n!logs/keep.txt\n"), MatcherOptions{})
+	if err != nil {
+		t.Fatalf("NewMatcher: %v", err)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if err := AddFSFiltered(zw, fsys, m.Decider()); err != nil {
+		t.Fatalf("AddFSFiltered: %v", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+
+	var files []string
+	for _, f := range zr.File {
+		if !f.Mode().IsDir() {
+			files = append(files, f.Name)
+		}
+	}
+
+	if want := []string{"a/b.txt"}; !slices.Equal(files, want) {
+		t.Fatalf("files=%v, want %v", files, want)
+	}
+}